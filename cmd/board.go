@@ -0,0 +1,267 @@
+//go:build unix
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultBoardWidth is used when the terminal width cannot be detected
+	defaultBoardWidth = 120
+
+	// minColumnWidth keeps cards readable on narrow terminals with many columns
+	minColumnWidth = 16
+
+	// columnGap is the space between board columns
+	columnGap = 2
+)
+
+var (
+	boardByStatus  bool
+	boardSwimlanes bool
+	boardAssignee  string
+	boardSprint    string
+	boardLabels    []string
+	boardWidth     int
+	boardWatch     bool
+	boardJSON      bool
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show issues as a kanban board",
+	Long: `Render the project's issues as a kanban board with a column per status
+category: To Do, In Progress and Done (and Other for statuses the workflow
+does not know). Categories come from the bridge's workflow cache (see
+'takl jira workflow'); open and closed states map to To Do and Done.
+
+Cards are ordered by board rank where the tracker has one, then by last
+update, and are truncated to fit the terminal width.
+
+Examples:
+  takl board
+  takl board --by-status --swimlanes   # A column per status, a row per assignee
+  takl board --sprint "Sprint 12" --watch`,
+	Args: cobra.NoArgs,
+	RunE: runBoard,
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+	boardCmd.Flags().BoolVar(&boardByStatus, "by-status", false, "one column per status instead of per category")
+	boardCmd.Flags().BoolVar(&boardSwimlanes, "swimlanes", false, "one swimlane per assignee")
+	boardCmd.Flags().StringVar(&boardAssignee, "assignee", "", "filter by assignee")
+	boardCmd.Flags().StringVar(&boardSprint, "sprint", "", "filter by sprint")
+	boardCmd.Flags().StringSliceVar(&boardLabels, "label", nil, "filter by label (can be repeated)")
+	boardCmd.Flags().IntVar(&boardWidth, "width", 0, "board width in characters (default terminal width)")
+	boardCmd.Flags().BoolVar(&boardWatch, "watch", false, "redraw when issue files change")
+	boardCmd.Flags().BoolVar(&boardJSON, "json", false, "output JSON")
+}
+
+func runBoard(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if boardWatch && boardJSON {
+		return errors.New("--watch cannot be combined with --json")
+	}
+
+	client := apiclient.New()
+	if !boardWatch {
+		return showBoard(cmd.Context(), client, projectPath, os.Stdout)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fingerprint := ""
+	for {
+		// Fingerprint first, so changes made while drawing trigger a redraw
+		params := url.Values{}
+		params.Set("project_path", projectPath)
+		params.Set("since", fingerprint)
+		var changes struct {
+			Fingerprint string `json:"fingerprint"`
+			Changed     bool   `json:"changed"`
+		}
+		if err := client.GetJSON(ctx, "/api/changes?"+params.Encode(), &changes); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to watch for changes: %w", err)
+		}
+		if fingerprint != "" && !changes.Changed {
+			continue
+		}
+		fingerprint = changes.Fingerprint
+
+		// Clear the screen and move the cursor home
+		fmt.Print("\033[H\033[2J")
+		if err := showBoard(ctx, client, projectPath, os.Stdout); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		fmt.Println("\nWatching for changes (Ctrl-C to stop)")
+	}
+}
+
+// showBoard fetches the issues and workflow and renders the board
+func showBoard(ctx context.Context, client *apiclient.Client, projectPath string, out io.Writer) error {
+	params := url.Values{}
+	params.Set("project_path", projectPath)
+	params.Set("sort", issue.SortRank)
+	if boardAssignee != "" {
+		params.Set("assignee", boardAssignee)
+	}
+	if boardSprint != "" {
+		params.Set("sprint", boardSprint)
+	}
+	if len(boardLabels) > 0 {
+		params.Set("labels", strings.Join(boardLabels, ","))
+	}
+
+	var issues struct {
+		Issues []*issue.Issue `json:"issues"`
+		Count  int            `json:"count"`
+	}
+	if err := client.GetJSON(ctx, "/api/issues?"+params.Encode(), &issues); err != nil {
+		return listError(projectPath, err)
+	}
+
+	var workflow struct {
+		Statuses []*issue.StatusInfo `json:"statuses"`
+	}
+	if err := client.GetJSON(ctx, "/api/workflow?"+url.Values{"project_path": {projectPath}}.Encode(), &workflow); err != nil {
+		return err
+	}
+	cache := issue.NewWorkflowCache()
+	for _, status := range workflow.Statuses {
+		cache.AddStatus(status)
+	}
+
+	var lanes []*issue.BoardLane
+	if boardSwimlanes {
+		lanes = issue.BoardLanes(issues.Issues, cache, boardByStatus)
+	} else {
+		lanes = []*issue.BoardLane{{Columns: issue.BoardColumns(issues.Issues, cache, boardByStatus)}}
+	}
+
+	if boardJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if boardSwimlanes {
+			return enc.Encode(map[string]interface{}{"lanes": lanes})
+		}
+		return enc.Encode(map[string]interface{}{"columns": lanes[0].Columns})
+	}
+
+	if issues.Count == 0 {
+		fmt.Fprintln(out, "No issues found")
+		return nil
+	}
+
+	width := boardWidth
+	if width <= 0 {
+		width = terminalWidth()
+	}
+	renderBoard(out, issue.BoardColumns(issues.Issues, cache, boardByStatus), lanes, boardSwimlanes, width)
+	return nil
+}
+
+// renderBoard prints the column headers with their totals, then the cards of
+// each lane side by side, truncated to the column width
+func renderBoard(out io.Writer, totals []*issue.BoardColumn, lanes []*issue.BoardLane, swimlanes bool, width int) {
+	columnWidth := max((width-columnGap*(len(totals)-1))/len(totals), minColumnWidth)
+
+	headers := make([]string, len(totals))
+	rules := make([]string, len(totals))
+	for n, c := range totals {
+		headers[n] = fmt.Sprintf("%s (%d)", strings.ToUpper(c.Name), len(c.Issues))
+		rules[n] = strings.Repeat("-", columnWidth)
+	}
+	printRow(out, headers, columnWidth)
+	printRow(out, rules, columnWidth)
+
+	for _, lane := range lanes {
+		if swimlanes {
+			name, count := lane.Assignee, 0
+			if name == "" {
+				name = "Unassigned"
+			}
+			for _, c := range lane.Columns {
+				count += len(c.Issues)
+			}
+			fmt.Fprintf(out, "\n== %s (%d) ==\n", name, count)
+		}
+
+		rows := 0
+		for _, c := range lane.Columns {
+			rows = max(rows, len(c.Issues))
+		}
+		for r := 0; r < rows; r++ {
+			cells := make([]string, len(lane.Columns))
+			for n, c := range lane.Columns {
+				if r < len(c.Issues) {
+					cells[n] = c.Issues[r].Key + " " + c.Issues[r].Title
+				}
+			}
+			printRow(out, cells, columnWidth)
+		}
+	}
+}
+
+// printRow prints cells truncated and padded to the column width
+func printRow(out io.Writer, cells []string, columnWidth int) {
+	var line strings.Builder
+	for n, cell := range cells {
+		cell = truncateRunes(cell, columnWidth)
+		line.WriteString(cell)
+		if n < len(cells)-1 {
+			line.WriteString(strings.Repeat(" ", columnWidth-len([]rune(cell))+columnGap))
+		}
+	}
+	fmt.Fprintln(out, strings.TrimRight(line.String(), " "))
+}
+
+// truncateRunes shortens s to at most width characters, ending in "..." when cut
+func truncateRunes(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// terminalWidth returns the width of the terminal on stdout, falling back to
+// $COLUMNS and then defaultBoardWidth
+func terminalWidth() int {
+	if cols, _, err := term.GetSize(os.Stdout.Fd()); err == nil && cols > 0 {
+		return cols
+	}
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return defaultBoardWidth
+}
@@ -0,0 +1,111 @@
+//go:build unix
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var depsJSON bool
+
+var depsCmd = &cobra.Command{
+	Use:   "deps <issue-key>",
+	Short: "Show the issues blocking an issue",
+	Long: `Print the tree of issues blocking an issue, following "blocks" links
+transitively through the local issues. Dependency cycles are flagged and make
+the command exit with an error.
+
+Examples:
+  takl deps PROJ-1
+  takl deps PROJ-1 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeps,
+}
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+	depsCmd.Flags().BoolVar(&depsJSON, "json", false, "output JSON")
+}
+
+func runDeps(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("project_path", projectPath)
+
+	client := apiclient.New()
+	var resp struct {
+		Issues []*issue.Issue `json:"issues"`
+	}
+	if err := client.GetJSON(cmd.Context(), "/api/issues?"+params.Encode(), &resp); err != nil {
+		return err
+	}
+
+	tree, cycles := issue.BlockingTree(resp.Issues, args[0])
+	if tree.Missing {
+		return fmt.Errorf("issue %q not found in %s", args[0], projectPath)
+	}
+
+	if depsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(map[string]interface{}{"tree": tree, "cycles": cycles}); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	} else {
+		fmt.Println(depLabel(tree))
+		printDeps(tree.Blockers, "")
+		if len(tree.Blockers) == 0 {
+			fmt.Println("\nNot blocked by any issue")
+		}
+	}
+
+	if len(cycles) > 0 {
+		for _, cycle := range cycles {
+			fmt.Fprintf(os.Stderr, "Cycle: %s\n", strings.Join(cycle, " → "))
+		}
+		return errors.New("dependency cycle detected")
+	}
+	return nil
+}
+
+// printDeps prints blocker nodes as an indented tree
+func printDeps(nodes []*issue.DepNode, prefix string) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Println(prefix + branch + depLabel(node))
+		printDeps(node.Blockers, prefix+indent)
+	}
+}
+
+// depLabel formats a node as "KEY [Status] Title" with cycle and repeat markers
+func depLabel(node *issue.DepNode) string {
+	label := node.Key
+	if node.Missing {
+		label += " (not found locally)"
+	} else {
+		label += fmt.Sprintf(" [%s] %s", node.Status, node.Title)
+	}
+	switch {
+	case node.Cycle:
+		label += "  ⟲ cycle"
+	case node.Repeat:
+		label += "  (see above)"
+	}
+	return label
+}
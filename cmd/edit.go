@@ -0,0 +1,204 @@
+//go:build unix
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+// problemHeaderStart opens the error header added above the issue when the
+// editor is reopened; it is removed before the file is checked again
+const problemHeaderStart = "<!-- takl:"
+
+var editCmd = &cobra.Command{
+	Use:   "edit <issue-key>",
+	Short: "Edit an issue file in $EDITOR",
+	Long: `Open the issue's markdown in $VISUAL or $EDITOR (default vi). When the
+editor exits, the file is checked: frontmatter fields against the schema,
+status against the workflow cache and assignee against the member cache. If
+there are problems, the editor reopens with them listed at the top and
+nothing is written; save without changes or empty the file to give up.
+
+The change is sent to the tracker on the next push. If the issue file changes
+while the editor is open (e.g. by a pull), nothing is written and your edits
+are kept in a temporary file to apply again.
+
+Examples:
+  takl edit PROJ-1
+  EDITOR="code --wait" takl edit PROJ-1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	client := apiclient.New()
+	var resp struct {
+		IssueKey string `json:"issue_key"`
+		Markdown string `json:"markdown"`
+		Base     string `json:"base"`
+	}
+	query := url.Values{"project_path": {projectPath}, "issue_key": {args[0]}}
+	if err := client.GetJSON(cmd.Context(), "/api/edit?"+query.Encode(), &resp); err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	saved, err := editIssue(cmd.Context(), client, projectPath, resp.IssueKey, resp.Markdown, resp.Base)
+	if err != nil || !saved {
+		return err
+	}
+	fmt.Printf("Saved %s\n", resp.IssueKey)
+	fmt.Println("Run 'takl push' to sync the change.")
+	return nil
+}
+
+// editIssue opens markdown in the editor until the daemon accepts it, reopening
+// it with the problems found. The issue is created if base is empty, else
+// saved over the file base is the digest of. Returns false if the user gave up
+// or made no changes; nothing is written then.
+func editIssue(ctx context.Context, client *apiclient.Client, projectPath, key, markdown, base string) (bool, error) {
+	file, err := os.CreateTemp("", "takl-"+key+"-*.md")
+	if err != nil {
+		return false, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+	keep := false
+	defer func() {
+		if !keep {
+			os.Remove(path)
+		}
+	}()
+
+	content := markdown
+	var problems []issue.Problem
+	for {
+		if err := os.WriteFile(path, []byte(withProblemHeader(key, content, problems)), 0600); err != nil {
+			return false, fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := runEditor(path); err != nil {
+			return false, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to read temp file: %w", err)
+		}
+		edited := stripProblemHeader(string(data))
+
+		switch {
+		case strings.TrimSpace(edited) == "":
+			fmt.Println("Aborted: the file is empty, nothing written")
+			return false, nil
+		case edited == markdown:
+			fmt.Println("No changes, nothing written")
+			return false, nil
+		case problems != nil && edited == content:
+			keep = true
+			fmt.Printf("Aborted: no changes since the problems were listed, nothing written\nYour edits are in %s\n", path)
+			return false, nil
+		}
+
+		reqBody := map[string]interface{}{
+			"project_path": projectPath,
+			"issue_key":    key,
+			"markdown":     edited,
+			"create":       base == "",
+		}
+		if base != "" {
+			reqBody["base"] = base
+		}
+		err = client.PostJSON(ctx, "/api/edit", reqBody, nil)
+		if err == nil {
+			return true, nil
+		}
+
+		var apiErr *apiclient.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict && base != "" {
+			keep = true
+			return false, fmt.Errorf("%s changed while it was being edited, nothing written\nYour edits are in %s; run 'takl edit %s' and apply them again", key, path, key)
+		}
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+			keep = true
+			return false, fmt.Errorf("failed to save %s: %w\nYour edits are in %s", key, err, path)
+		}
+		var invalid struct {
+			Problems []issue.Problem `json:"problems"`
+		}
+		if err := json.Unmarshal(apiErr.Body, &invalid); err != nil || len(invalid.Problems) == 0 {
+			keep = true
+			return false, fmt.Errorf("failed to save %s: %w\nYour edits are in %s", key, apiErr, path)
+		}
+		content, problems = edited, invalid.Problems
+	}
+}
+
+// withProblemHeader prefixes content with an HTML comment listing the problems,
+// with line numbers shifted past the header itself
+func withProblemHeader(key, content string, problems []issue.Problem) string {
+	if len(problems) == 0 {
+		return content
+	}
+
+	headerLines := len(problems) + 3
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s was not saved. Fix these problems and save again,\n", problemHeaderStart, key)
+	b.WriteString("     or save without changes or empty the file to abort:\n")
+	for _, p := range problems {
+		if p.Line > 0 {
+			p.Line += headerLines
+		}
+		fmt.Fprintf(&b, "  - %s\n", p)
+	}
+	b.WriteString("-->\n")
+	return b.String() + content
+}
+
+// stripProblemHeader removes the header added by withProblemHeader
+func stripProblemHeader(content string) string {
+	if !strings.HasPrefix(content, problemHeaderStart) {
+		return content
+	}
+	if _, rest, ok := strings.Cut(content, "-->\n"); ok {
+		return rest
+	}
+	return content
+}
+
+// runEditor opens path in $VISUAL or $EDITOR (default vi). The editor is run
+// through the shell so that it may carry arguments, e.g. "code --wait".
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
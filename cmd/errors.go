@@ -0,0 +1,43 @@
+//go:build unix
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gurisko/takl/internal/apiclient"
+)
+
+// remoteErrorHints suggest a next step for tracker failures relayed by the daemon
+var remoteErrorHints = map[int]string{
+	http.StatusUnauthorized: "The tracker rejected the credentials. For Jira, store them with 'takl jira credentials set'\n" +
+		"or set TAKL_JIRA_EMAIL and TAKL_JIRA_API_TOKEN (or TAKL_JIRA_TOKEN) in the daemon's environment;\n" +
+		"other trackers read the token from the project's .takl/ config file.",
+	http.StatusForbidden:       "The tracker account is not allowed to do this. Check its permissions on the project.",
+	http.StatusNotFound:        "Check the issue key and the project configured in .takl/.",
+	http.StatusTooManyRequests: "The tracker is rate limiting requests. Wait a minute and try again.",
+	http.StatusBadGateway:      "The tracker returned an error; see the message above.",
+}
+
+// listError replaces the daemon's 404 for a project without an issues
+// directory with a hint to pull first
+func listError(projectPath string, err error) error {
+	var apiErr *apiclient.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("no issues found in %s (have you run 'takl pull'?)", projectPath)
+	}
+	return err
+}
+
+// remoteError wraps a failed sync request, adding a hint for known statuses
+func remoteError(action string, err error) error {
+	var apiErr *apiclient.APIError
+	if errors.As(err, &apiErr) {
+		if hint, ok := remoteErrorHints[apiErr.StatusCode]; ok {
+			return fmt.Errorf("%s failed: %w\n\n%s", action, err, hint)
+		}
+	}
+	return fmt.Errorf("%s failed: %w", action, err)
+}
@@ -0,0 +1,61 @@
+//go:build unix
+
+package cmd
+
+import (
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var githubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "GitHub Issues bridge commands",
+	Long:  "Sync GitHub Issues with local markdown files",
+}
+
+var githubPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Pull issues from GitHub",
+	Long: `Fetch issues from GitHub and save them as markdown files in .takl/issues/.
+Always uses the GitHub bridge, whatever bridge the project is registered with.
+
+Pull requests are skipped. Issues are stored as <prefix>-<number>.md (GH-123.md
+by default).
+
+The GitHub configuration should be in .takl/github.json with the following format:
+{
+  "token": "your-personal-access-token",
+  "owner": "your-org",
+  "repo": "your-repo",
+  "base_url": "https://api.github.com",
+  "key_prefix": "GH"
+}
+
+base_url and key_prefix are optional. The daemon reads the configuration; the
+token is never sent over the socket.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pullBridge(cmd, issue.BridgeGitHub)
+	},
+}
+
+var githubPushCmd = &cobra.Command{
+	Use:   "push [issue-key]",
+	Short: "Push local changes to GitHub",
+	Long: `Upload modified issues to GitHub. Always uses the GitHub bridge,
+whatever bridge the project is registered with.
+
+Title, description, state (open/closed), labels, assignees and milestone are
+updated, and new comments are posted. If any issue has been modified remotely
+since the last pull, push will fail with a conflict error.
+
+If an issue key is provided (e.g., GH-123), only that issue will be pushed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pushBridge(cmd, args, issue.BridgeGitHub)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(githubCmd)
+	githubCmd.AddCommand(githubPullCmd)
+	githubCmd.AddCommand(githubPushCmd)
+}
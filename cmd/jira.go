@@ -4,12 +4,14 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/gurisko/takl/internal/apiclient"
-	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/gurisko/takl/internal/issue"
 	"github.com/spf13/cobra"
 )
 
@@ -27,10 +29,11 @@ var jiraPullCmd = &cobra.Command{
 The Jira configuration should be in .takl/jira.json with the following format:
 {
   "base_url": "https://your-domain.atlassian.net",
-  "email": "your-email@example.com",
-  "api_token": "your-api-token",
   "project": "PROJ"
-}`,
+}
+
+Credentials are resolved by the daemon from TAKL_JIRA_EMAIL/TAKL_JIRA_API_TOKEN,
+or from the credential store (see 'takl jira credentials set').`,
 	RunE: runJiraPull,
 }
 
@@ -85,9 +88,9 @@ func init() {
 }
 
 func runJiraPull(cmd *cobra.Command, args []string) error {
-	config, projectPath, err := jira.LoadConfigFromCwd()
+	projectPath, err := os.Getwd()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
 	// Create API client
@@ -96,13 +99,12 @@ func runJiraPull(cmd *cobra.Command, args []string) error {
 	// Prepare request
 	reqBody := map[string]interface{}{
 		"project_path": projectPath,
-		"config":       config,
 	}
 
 	// Make API call to daemon
-	var result jira.PullResult
+	var result issue.PullResult
 	if err := client.PostJSON(cmd.Context(), "/api/jira/pull", reqBody, &result); err != nil {
-		return fmt.Errorf("pull request failed: %w", err)
+		return remoteError("pull request", err)
 	}
 
 	// Display results
@@ -113,6 +115,9 @@ func runJiraPull(cmd *cobra.Command, args []string) error {
 	if result.Deleted > 0 {
 		fmt.Printf("  Deleted: %d archived/removed issues\n", result.Deleted)
 	}
+	if len(result.Kept) > 0 {
+		fmt.Printf("  Kept: %d out-of-scope issues with local edits (%s); push or discard them\n", len(result.Kept), strings.Join(result.Kept, ", "))
+	}
 
 	if len(result.Errors) > 0 {
 		fmt.Printf("\nErrors:\n")
@@ -125,9 +130,9 @@ func runJiraPull(cmd *cobra.Command, args []string) error {
 }
 
 func runJiraPush(cmd *cobra.Command, args []string) error {
-	config, projectPath, err := jira.LoadConfigFromCwd()
+	projectPath, err := os.Getwd()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
 	// Create API client
@@ -136,7 +141,6 @@ func runJiraPush(cmd *cobra.Command, args []string) error {
 	// Prepare request
 	reqBody := map[string]interface{}{
 		"project_path": projectPath,
-		"config":       config,
 	}
 
 	// Add optional issue key filter
@@ -145,11 +149,13 @@ func runJiraPush(cmd *cobra.Command, args []string) error {
 	}
 
 	// Make API call to daemon
-	var result jira.PushResult
+	var result issue.PushResult
 	if err := client.PostJSON(cmd.Context(), "/api/jira/push", reqBody, &result); err != nil {
 		// Check if it's a conflict error
-		if apiErr, ok := err.(*apiclient.APIError); ok && apiErr.StatusCode == 409 {
-			// Display conflict information
+		var apiErr *apiclient.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 409 {
+			// Conflict responses carry the push result in the body
+			_ = json.Unmarshal(apiErr.Body, &result)
 			fmt.Printf("Error: Cannot push - %d issue(s) have conflicts:\n", len(result.Conflicts))
 			for _, conflict := range result.Conflicts {
 				fmt.Printf("  - %s: Remote modified (last updated: %s)\n",
@@ -159,13 +165,16 @@ func runJiraPush(cmd *cobra.Command, args []string) error {
 			fmt.Printf("\nRun 'takl jira pull' to fetch remote changes, then push again.\n")
 			return fmt.Errorf("push failed due to conflicts")
 		}
-		return fmt.Errorf("push request failed: %w", err)
+		return remoteError("push request", err)
 	}
 
 	// Display results
 	fmt.Printf("Jira Push Complete\n")
 	fmt.Printf("  Scanned: %d issues\n", result.Scanned)
 	fmt.Printf("  Pushed: %d issues\n", result.Pushed)
+	if result.Created > 0 {
+		fmt.Printf("  Created: %d issues\n", result.Created)
+	}
 	fmt.Printf("  Skipped: %d issues (no changes)\n", result.Skipped)
 
 	if len(result.Errors) > 0 {
@@ -179,9 +188,9 @@ func runJiraPush(cmd *cobra.Command, args []string) error {
 }
 
 func runJiraMembers(cmd *cobra.Command, args []string) error {
-	config, projectPath, err := jira.LoadConfigFromCwd()
+	projectPath, err := os.Getwd()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
 	// Create API client
@@ -190,13 +199,12 @@ func runJiraMembers(cmd *cobra.Command, args []string) error {
 	// Prepare request
 	reqBody := map[string]interface{}{
 		"project_path": projectPath,
-		"config":       config,
 	}
 
 	// Make API call to daemon
-	var members []*jira.Member
+	var members []*issue.Member
 	if err := client.PostJSON(cmd.Context(), "/api/jira/members", reqBody, &members); err != nil {
-		return fmt.Errorf("members request failed: %w", err)
+		return remoteError("members request", err)
 	}
 
 	// Output results
@@ -231,9 +239,9 @@ func runJiraMembers(cmd *cobra.Command, args []string) error {
 }
 
 func runJiraWorkflow(cmd *cobra.Command, args []string) error {
-	config, projectPath, err := jira.LoadConfigFromCwd()
+	projectPath, err := os.Getwd()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
 	// Create API client
@@ -242,13 +250,12 @@ func runJiraWorkflow(cmd *cobra.Command, args []string) error {
 	// Prepare request
 	reqBody := map[string]interface{}{
 		"project_path": projectPath,
-		"config":       config,
 	}
 
 	// Make API call to daemon
-	var statuses []*jira.StatusInfo
+	var statuses []*issue.StatusInfo
 	if err := client.PostJSON(cmd.Context(), "/api/jira/workflow", reqBody, &statuses); err != nil {
-		return fmt.Errorf("workflow request failed: %w", err)
+		return remoteError("workflow request", err)
 	}
 
 	// Output results
@@ -261,7 +268,7 @@ func runJiraWorkflow(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// Group by category for table output
-		categories := map[string][]*jira.StatusInfo{
+		categories := map[string][]*issue.StatusInfo{
 			"new":           {},
 			"indeterminate": {},
 			"done":          {},
@@ -0,0 +1,113 @@
+//go:build unix
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	credentialsEmail   string
+	credentialsCommand string
+)
+
+var jiraCredentialsCmd = &cobra.Command{
+	Use:   "credentials",
+	Short: "Manage stored Jira credentials",
+}
+
+var jiraCredentialsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Store the credentials for this project's Jira site",
+	Long: `Store the secret for the Jira site in .takl/jira.json in the per-user
+credential store ($XDG_STATE_HOME/takl/jira-credentials.json, mode 0600).
+
+The secret is read from stdin: the API token for basic auth, the personal
+access token for pat auth, or the client secret for oauth auth. Pipe it in to
+keep it out of your shell history:
+
+  pass show jira | takl jira credentials set --email you@example.com
+
+With --command, the credentials are fetched on demand by a helper instead,
+using the git credential helper protocol:
+
+  takl jira credentials set --command "git credential fill"
+
+Credentials are shared by every project on the same site. The daemon also
+reads TAKL_JIRA_EMAIL, TAKL_JIRA_API_TOKEN, TAKL_JIRA_TOKEN and
+TAKL_JIRA_CLIENT_SECRET from its environment, which take precedence.`,
+	RunE: runJiraCredentialsSet,
+}
+
+func init() {
+	jiraCmd.AddCommand(jiraCredentialsCmd)
+	jiraCredentialsCmd.AddCommand(jiraCredentialsSetCmd)
+	jiraCredentialsSetCmd.Flags().StringVar(&credentialsEmail, "email", "", "Atlassian account email (basic auth)")
+	jiraCredentialsSetCmd.Flags().StringVar(&credentialsCommand, "command", "", "credential helper to run instead of storing a secret")
+}
+
+func runJiraCredentialsSet(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	config, err := jira.ReadConfig(cwd)
+	if err != nil {
+		return err
+	}
+
+	creds := &jira.Credentials{Email: credentialsEmail, CredentialCommand: credentialsCommand}
+	if credentialsCommand == "" {
+		secret, err := readSecret(config.AuthMode())
+		if err != nil {
+			return err
+		}
+		switch config.AuthMode() {
+		case jira.AuthPAT:
+			creds.Token = secret
+		case jira.AuthOAuth:
+			creds.ClientSecret = secret
+		default:
+			if credentialsEmail == "" {
+				return errors.New("--email is required for basic auth")
+			}
+			creds.APIToken = secret
+		}
+	}
+
+	if err := jira.SaveCredentials(config.BaseURL, creds); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	fmt.Printf("Stored %s credentials for %s\n", config.AuthMode(), config.BaseURL)
+	return nil
+}
+
+// readSecret reads a single line from stdin, prompting when stdin is a terminal
+func readSecret(authMode string) (string, error) {
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		prompt := map[string]string{
+			jira.AuthBasic: "API token",
+			jira.AuthPAT:   "Personal access token",
+			jira.AuthOAuth: "OAuth client secret",
+		}[authMode]
+		fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+	secret := strings.TrimSpace(line)
+	if secret == "" {
+		return "", errors.New("no secret given on stdin")
+	}
+	return secret, nil
+}
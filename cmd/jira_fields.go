@@ -0,0 +1,106 @@
+//go:build unix
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fieldsJSONOutput bool
+	fieldsCustomOnly bool
+	fieldsSearch     string
+)
+
+var jiraFieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "List Jira fields and their types",
+	Long: `List the system and custom fields of the Jira site with their schema types.
+
+Use the ID or name of a field in the "custom_fields" mapping of .takl/jira.json
+to pull it into the issue's "custom" frontmatter and push edits back:
+
+  "custom_fields": {
+    "team": "customfield_10001",
+    "severity": "Severity"
+  }
+
+Supported types are number, string, date, datetime, option (select list),
+user, and arrays of option, string or user. The MAPPED column shows the name
+a field is mapped to.`,
+	RunE: runJiraFields,
+}
+
+func init() {
+	jiraCmd.AddCommand(jiraFieldsCmd)
+	jiraFieldsCmd.Flags().BoolVar(&fieldsJSONOutput, "json", false, "Output as JSON")
+	jiraFieldsCmd.Flags().BoolVar(&fieldsCustomOnly, "custom", false, "Only list custom fields")
+	jiraFieldsCmd.Flags().StringVar(&fieldsSearch, "search", "", "Only list fields whose name or ID contains this text")
+}
+
+func runJiraFields(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	client := apiclient.New()
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+	}
+
+	var fields []*jira.FieldInfo
+	if err := client.PostJSON(cmd.Context(), "/api/jira/fields", reqBody, &fields); err != nil {
+		return remoteError("fields request", err)
+	}
+
+	// Apply filters
+	search := strings.ToLower(fieldsSearch)
+	filtered := make([]*jira.FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		if fieldsCustomOnly && !f.Custom {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(f.Name), search) && !strings.Contains(strings.ToLower(f.ID), search) {
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+
+	if fieldsJSONOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(filtered); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tMAPPED")
+	for _, f := range filtered {
+		schema := f.Schema()
+		if schema == "" {
+			schema = "-"
+		} else if !f.Supported() {
+			schema += " (unsupported)"
+		}
+		mapped := f.Mapped
+		if mapped == "" {
+			mapped = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.ID, f.Name, schema, mapped)
+	}
+	w.Flush()
+	fmt.Printf("\nTotal: %d fields\n", len(filtered))
+
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build unix
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/spf13/cobra"
+)
+
+var loginNoBrowser bool
+
+var jiraLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to Jira with OAuth 2.0",
+	Long: `Authorize takl with Jira using OAuth 2.0 (3LO).
+
+Requires "auth": "oauth" in .takl/jira.json, with the client ID of an app
+created in the Atlassian developer console:
+{
+  "auth": "oauth",
+  "base_url": "https://your-domain.atlassian.net",
+  "project": "PROJ",
+  "oauth": {"client_id": "..."}
+}
+
+The client secret is read from TAKL_JIRA_CLIENT_SECRET or the credential store
+(see 'takl jira credentials set').
+
+The app's callback URL must be http://localhost:8765/callback (or the port set
+in oauth.redirect_port). The token is stored with 0600 permissions in the takl
+state directory and refreshed automatically.`,
+	RunE: runJiraLogin,
+}
+
+func init() {
+	jiraCmd.AddCommand(jiraLoginCmd)
+	jiraLoginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "print the authorization URL instead of opening a browser")
+}
+
+func runJiraLogin(cmd *cobra.Command, args []string) error {
+	config, _, err := jira.LoadConfigFromCwd()
+	if err != nil {
+		return err
+	}
+	if config.AuthMode() != jira.AuthOAuth {
+		return fmt.Errorf("jira login requires \"auth\": \"oauth\" in .takl/jira.json (current: %s)", config.AuthMode())
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	token, err := jira.Login(ctx, config, func(authURL string) error {
+		fmt.Printf("Open this URL to authorize takl:\n\n  %s\n\n", authURL)
+		if !loginNoBrowser {
+			if err := openBrowser(authURL); err != nil {
+				fmt.Printf("(could not open a browser: %v)\n", err)
+			}
+		}
+		fmt.Println("Waiting for authorization...")
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	fmt.Printf("Logged in to %s (cloud ID %s)\n", token.SiteURL, token.CloudID)
+	return nil
+}
+
+// openBrowser opens url in the user's default browser
+func openBrowser(url string) error {
+	name := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		name = "open"
+	}
+	return exec.Command(name, url).Start()
+}
@@ -0,0 +1,63 @@
+//go:build unix
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/spf13/cobra"
+)
+
+var linkRemove bool
+
+var linkCmd = &cobra.Command{
+	Use:   "link <issue-key> <relation> <target-key>",
+	Short: "Link two issues",
+	Long: `Add a link from one issue to another in the local issue file. The link is
+created in the tracker on the next push.
+
+Relations: blocks, is-blocked-by, relates-to, duplicates, is-duplicated-by,
+clones, is-cloned-by.
+
+Examples:
+  takl link PROJ-1 blocks PROJ-2
+  takl link PROJ-3 relates-to PROJ-1
+  takl link PROJ-1 blocks PROJ-2 --remove   # Remove the link (deleted on push)`,
+	Args: cobra.ExactArgs(3),
+	RunE: runLink,
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+	linkCmd.Flags().BoolVar(&linkRemove, "remove", false, "remove the link instead of adding it")
+}
+
+func runLink(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+		"issue_key":    args[0],
+		"relation":     args[1],
+		"target":       args[2],
+		"remove":       linkRemove,
+	}
+
+	client := apiclient.New()
+	if err := client.PostJSON(cmd.Context(), "/api/links", reqBody, nil); err != nil {
+		return fmt.Errorf("link failed: %w", err)
+	}
+
+	if linkRemove {
+		fmt.Printf("Removed link: %s %s %s\n", args[0], args[1], args[2])
+	} else {
+		fmt.Printf("Linked: %s %s %s\n", args[0], args[1], args[2])
+	}
+	fmt.Println("Run 'takl push' to sync the change.")
+	return nil
+}
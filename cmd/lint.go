@@ -0,0 +1,133 @@
+//go:build unix
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var lintFix bool
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [issue-key | file ...]",
+	Short: "Check issue files for problems",
+	Long: `Check issue files against the frontmatter schema and the bridge caches, the
+same checks as 'takl edit' plus: jira_key matching the file name, status and
+assignee spelled as cached, duplicate labels, labels with spaces (Jira) and
+repeated Description sections, of which only the last is read.
+
+Problems are reported as file:line: message. --fix normalizes those marked
+(fixable) in place: misspelled keys, status and assignee spelling, labels and
+Description sections. Fixes are local edits, sent to the tracker on push.
+
+Without arguments every issue file is checked. Arguments are issue keys or
+paths to issue files; other files are ignored, so staged files can be passed
+as they are. Exits non-zero if problems remain.
+
+Pre-commit hook (.git/hooks/pre-commit):
+  #!/bin/sh
+  git diff --cached --name-only --diff-filter=ACMR -- '.takl/issues/*.md' | xargs -r takl lint
+
+Examples:
+  takl lint
+  takl lint PROJ-1 PROJ-2
+  takl lint --fix`,
+	RunE: runLint,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().BoolVar(&lintFix, "fix", false, "fix the problems that can be normalized")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	keys := lintKeys(args)
+	if len(args) > 0 && len(keys) == 0 {
+		return nil
+	}
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+		"keys":         keys,
+		"fix":          lintFix,
+	}
+	var resp struct {
+		Checked int                 `json:"checked"`
+		Results []*issue.LintResult `json:"results"`
+	}
+	client := apiclient.New()
+	if err := client.PostJSON(cmd.Context(), "/api/lint", reqBody, &resp); err != nil {
+		return fmt.Errorf("lint failed: %w", err)
+	}
+
+	problems, fixable, fixed, files := 0, 0, 0, 0
+	for _, result := range resp.Results {
+		path := filepath.Join(".takl", "issues", result.Key+".md")
+		for _, p := range result.Fixed {
+			fmt.Printf("%s: fixed: %s\n", lintLocation(path, p), p.Message)
+			fixed++
+		}
+		for _, p := range result.Problems {
+			suffix := ""
+			if p.Fixable {
+				suffix = " (fixable)"
+				fixable++
+			}
+			fmt.Printf("%s: %s%s\n", lintLocation(path, p), p.Message, suffix)
+			problems++
+		}
+		if len(result.Problems) > 0 {
+			files++
+		}
+	}
+
+	if fixed > 0 {
+		fmt.Printf("Fixed %d problem(s). Run 'takl push' to sync the changes.\n", fixed)
+	}
+	if problems == 0 {
+		if fixed == 0 {
+			fmt.Printf("%d issue file(s) checked, no problems\n", resp.Checked)
+		}
+		return nil
+	}
+	if fixable > 0 && !lintFix {
+		fmt.Printf("Run 'takl lint --fix' to fix %d of them.\n", fixable)
+	}
+	return fmt.Errorf("%d problem(s) in %d of %d issue file(s)", problems, files, resp.Checked)
+}
+
+// lintKeys turns lint arguments into issue keys: paths to issue files by their
+// name, other paths dropped, anything else taken as a key
+func lintKeys(args []string) []string {
+	var keys []string
+	for _, arg := range args {
+		if !strings.HasSuffix(arg, ".md") {
+			keys = append(keys, arg)
+			continue
+		}
+		if strings.HasSuffix(filepath.ToSlash(filepath.Dir(filepath.Clean(arg))), ".takl/issues") {
+			keys = append(keys, strings.TrimSuffix(filepath.Base(arg), ".md"))
+		}
+	}
+	return keys
+}
+
+// lintLocation formats a problem's place as file:line, or file without a line
+func lintLocation(path string, p issue.Problem) string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d", path, p.Line)
+	}
+	return path
+}
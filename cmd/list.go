@@ -17,24 +17,36 @@ import (
 
 type listIssuesResp struct {
 	Issues []struct {
-		JiraKey  string    `json:"jira_key"`
-		Title    string    `json:"title"`
-		Status   string    `json:"status"`
-		Assignee string    `json:"assignee,omitempty"`
-		Reporter string    `json:"reporter"`
-		Created  time.Time `json:"created"`
-		Updated  time.Time `json:"updated"`
-		Labels   []string  `json:"labels,omitempty"`
+		Key       string    `json:"jira_key"`
+		Title     string    `json:"title"`
+		Status    string    `json:"status"`
+		Assignee  string    `json:"assignee,omitempty"`
+		Reporter  string    `json:"reporter"`
+		Created   time.Time `json:"created"`
+		Updated   time.Time `json:"updated"`
+		Labels    []string  `json:"labels,omitempty"`
+		Assignees []string  `json:"assignees,omitempty"`
+		Milestone string    `json:"milestone,omitempty"`
+		Type      string    `json:"type,omitempty"`
+		Priority  string    `json:"priority,omitempty"`
+		Sprint    string    `json:"sprint,omitempty"`
 	} `json:"issues"`
 	Count int `json:"count"`
 }
 
 var (
-	listStatus   string
-	listAssignee string
-	listLabels   []string
-	listSearch   string
-	listJSON     bool
+	listStatus     string
+	listAssignee   string
+	listLabels     []string
+	listSearch     string
+	listType       string
+	listPriority   string
+	listComponent  string
+	listFixVersion string
+	listSprint     string
+	listParent     string
+	listSort       string
+	listJSON       bool
 )
 
 var listCmd = &cobra.Command{
@@ -48,6 +60,9 @@ Examples:
   takl list --assignee "John Doe"        # Filter by assignee display name
   takl list --labels bug,urgent          # Filter by labels (must match all)
   takl list --search "database error"    # Search in title and description
+  takl list --type Bug --priority High   # Filter by issue type and priority
+  takl list --sprint "Sprint 12"         # Filter by sprint
+  takl list --sort priority              # Sort by priority (also: sprint, due, rank, key, updated)
   takl list --json                       # Output JSON for piping`,
 	RunE: runList,
 }
@@ -58,6 +73,13 @@ func init() {
 	listCmd.Flags().StringVar(&listAssignee, "assignee", "", "filter by assignee display name")
 	listCmd.Flags().StringSliceVar(&listLabels, "labels", nil, "filter by labels (comma-separated)")
 	listCmd.Flags().StringVar(&listSearch, "search", "", "search in title and description")
+	listCmd.Flags().StringVar(&listType, "type", "", "filter by issue type")
+	listCmd.Flags().StringVar(&listPriority, "priority", "", "filter by priority")
+	listCmd.Flags().StringVar(&listComponent, "component", "", "filter by component")
+	listCmd.Flags().StringVar(&listFixVersion, "fix-version", "", "filter by fix version")
+	listCmd.Flags().StringVar(&listSprint, "sprint", "", "filter by sprint")
+	listCmd.Flags().StringVar(&listParent, "parent", "", "filter by parent or epic key")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "sort by updated (default), priority, sprint, due, rank or key")
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "output JSON")
 }
 
@@ -83,17 +105,26 @@ func runList(cmd *cobra.Command, args []string) error {
 	if listSearch != "" {
 		params.Set("search", listSearch)
 	}
+	for name, value := range map[string]string{
+		"type":        listType,
+		"priority":    listPriority,
+		"component":   listComponent,
+		"fix_version": listFixVersion,
+		"sprint":      listSprint,
+		"parent":      listParent,
+		"sort":        listSort,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
 
 	// Make API call
 	client := apiclient.New()
 	var resp listIssuesResp
 	endpoint := "/api/issues?" + params.Encode()
 	if err := client.GetJSON(cmd.Context(), endpoint, &resp); err != nil {
-		// Provide helpful error message for common issues
-		if strings.Contains(err.Error(), "issues directory not found") {
-			return fmt.Errorf("no issues found in %s (have you run 'takl jira pull'?)", projectPath)
-		}
-		return err
+		return listError(projectPath, err)
 	}
 
 	// Output JSON if requested
@@ -109,11 +140,30 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Priority and sprint columns are shown only when some issue has them
+	var showPriority, showSprint bool
+	for _, issue := range resp.Issues {
+		showPriority = showPriority || issue.Priority != ""
+		showSprint = showSprint || issue.Sprint != ""
+	}
+	optional := func(show bool, value string) string {
+		if !show {
+			return ""
+		}
+		if value == "" {
+			value = "-"
+		}
+		return value + "\t"
+	}
+
 	// Output table
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "KEY\tSTATUS\tASSIGNEE\tTITLE")
+	fmt.Fprintf(w, "KEY\tSTATUS\t%s%sASSIGNEE\tTITLE\n", optional(showPriority, "PRIORITY"), optional(showSprint, "SPRINT"))
 	for _, issue := range resp.Issues {
 		assignee := issue.Assignee
+		if assignee == "" && len(issue.Assignees) > 0 {
+			assignee = strings.Join(issue.Assignees, ", ")
+		}
 		if assignee == "" {
 			assignee = "-"
 		}
@@ -122,9 +172,11 @@ func runList(cmd *cobra.Command, args []string) error {
 		if len(title) > 60 {
 			title = title[:57] + "..."
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			issue.JiraKey,
+		fmt.Fprintf(w, "%s\t%s\t%s%s%s\t%s\n",
+			issue.Key,
 			issue.Status,
+			optional(showPriority, issue.Priority),
+			optional(showSprint, issue.Sprint),
 			assignee,
 			title,
 		)
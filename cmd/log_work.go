@@ -0,0 +1,87 @@
+//go:build unix
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var logWorkStarted string
+
+var logWorkCmd = &cobra.Command{
+	Use:   "log-work <issue-key> <duration> [comment]",
+	Short: "Log time spent on an issue",
+	Long: `Record time spent on an issue in the local issue file. The worklog is
+sent to the tracker on the next push.
+
+Durations use w, d, h and m units (a day is 8 hours, a week 5 days).
+
+Examples:
+  takl log-work PROJ-1 1h30m "Reviewed the API changes"
+  takl log-work PROJ-1 45m --started yesterday
+  takl log-work PROJ-1 2h --started "2025-03-14 09:30"`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runLogWork,
+}
+
+func init() {
+	rootCmd.AddCommand(logWorkCmd)
+	logWorkCmd.Flags().StringVar(&logWorkStarted, "started", "", `when the work started: "YYYY-MM-DD HH:MM", or a day (today, yesterday, monday, YYYY-MM-DD) at the current time (default now)`)
+}
+
+func runLogWork(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	seconds, err := issue.ParseWorkDuration(args[1])
+	if err != nil {
+		return err
+	}
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+		"issue_key":    args[0],
+		"time_spent":   args[1],
+	}
+	if len(args) == 3 {
+		reqBody["comment"] = args[2]
+	}
+	if logWorkStarted != "" {
+		started, err := parseStarted(logWorkStarted, time.Now())
+		if err != nil {
+			return err
+		}
+		reqBody["started"] = started
+	}
+
+	client := apiclient.New()
+	if err := client.PostJSON(cmd.Context(), "/api/worklogs", reqBody, nil); err != nil {
+		return fmt.Errorf("log-work failed: %w", err)
+	}
+
+	fmt.Printf("Logged %s on %s\n", issue.FormatWorkDuration(seconds), args[0])
+	fmt.Println("Run 'takl push' to sync the change.")
+	return nil
+}
+
+// parseStarted parses a worklog start as "YYYY-MM-DD HH:MM", or as a day
+// accepted by issue.ParseDay at the current time of day
+func parseStarted(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", strings.TrimSpace(s), now.Location()); err == nil {
+		return t, nil
+	}
+	day, err := issue.ParseDay(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.Add(now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))), nil
+}
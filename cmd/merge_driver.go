@@ -0,0 +1,84 @@
+//go:build unix
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs> [marker-size] [path]",
+	Short: "Git merge driver for .takl issue files",
+	Long: `Three-way merge of .takl issue markdown files, intended to be invoked by git.
+
+Labels are merged as sets, comments are unioned, and scalar fields take the
+value from the side with the later "updated" timestamp. Only conflicting
+description edits are left with conflict markers.
+
+The merged result is written to <ours>. Exits non-zero when conflicts remain.
+
+Setup:
+  git config merge.takl.name "TAKL issue merge driver"
+  git config merge.takl.driver "takl merge-driver %O %A %B %L %P"
+  echo ".takl/issues/*.md merge=takl" >> .gitattributes`,
+	Args: cobra.RangeArgs(3, 5),
+	RunE: runMergeDriver,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeDriverCmd)
+}
+
+func runMergeDriver(cmd *cobra.Command, args []string) error {
+	basePath, oursPath, theirsPath := args[0], args[1], args[2]
+
+	markerSize := issue.DefaultConflictMarkerSize
+	if len(args) > 3 {
+		n, err := strconv.Atoi(args[3])
+		if err != nil {
+			return fmt.Errorf("invalid marker size %q: %w", args[3], err)
+		}
+		markerSize = n
+	}
+
+	// Use the repository path for messages when git provides it
+	displayPath := oursPath
+	if len(args) > 4 {
+		displayPath = args[4]
+	}
+
+	base, err := os.ReadFile(basePath)
+	if err != nil {
+		return fmt.Errorf("failed to read base: %w", err)
+	}
+	ours, err := os.ReadFile(oursPath)
+	if err != nil {
+		return fmt.Errorf("failed to read ours: %w", err)
+	}
+	theirs, err := os.ReadFile(theirsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read theirs: %w", err)
+	}
+
+	result, err := issue.MergeIssueFiles(string(base), string(ours), string(theirs), markerSize)
+	if err != nil {
+		return fmt.Errorf("%s: %w", displayPath, err)
+	}
+
+	// Write result in place of ours (os.WriteFile keeps existing permissions)
+	if err := os.WriteFile(oursPath, []byte(result.Content), 0600); err != nil {
+		return fmt.Errorf("failed to write merge result: %w", err)
+	}
+
+	if result.HasConflicts() {
+		return fmt.Errorf("%s: merge conflict in %s", displayPath, strings.Join(result.Conflicts, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+//go:build unix
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/spf13/cobra"
+)
+
+var migrateDryRun bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade issue files to the current format version",
+	Long: `Rewrite issue files written by older versions of takl in the current
+format, recorded in their format_version field. Older files are also read
+fine without migrating, and any write upgrades them; migrating upgrades all
+at once, e.g. in a single commit after upgrading takl.
+
+Only the layout changes: fields, descriptions and comments are kept, and the
+issues are not pushed again.
+
+Examples:
+  takl migrate --dry-run
+  takl migrate`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "list the files to migrate without changing them")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+		"dry_run":      migrateDryRun,
+	}
+	var resp struct {
+		Checked       int `json:"checked"`
+		FormatVersion int `json:"format_version"`
+		Migrated      []struct {
+			Key  string `json:"key"`
+			From int    `json:"from"`
+		} `json:"migrated"`
+		Failed []struct {
+			Key   string `json:"key"`
+			Error string `json:"error"`
+		} `json:"failed"`
+	}
+	client := apiclient.New()
+	if err := client.PostJSON(cmd.Context(), "/api/migrate", reqBody, &resp); err != nil {
+		return fmt.Errorf("migrate failed: %w", err)
+	}
+
+	verb, done := "Migrated", "migrated"
+	if migrateDryRun {
+		verb, done = "Would migrate", "to migrate"
+	}
+	for _, m := range resp.Migrated {
+		fmt.Printf("%s %s: format version %d -> %d\n", verb, m.Key, m.From, resp.FormatVersion)
+	}
+	for _, f := range resp.Failed {
+		fmt.Fprintf(os.Stderr, "Failed %s: %s\n", f.Key, f.Error)
+	}
+
+	fmt.Printf("%d issue file(s) checked, %d %s, format version %d\n", resp.Checked, len(resp.Migrated), done, resp.FormatVersion)
+	if len(resp.Failed) > 0 {
+		return fmt.Errorf("%d issue file(s) could not be migrated", len(resp.Failed))
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+//go:build unix
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newKey      string
+	newTemplate string
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Write a new issue in $EDITOR",
+	Long: `Open a new issue in $VISUAL or $EDITOR and save it as a local-only issue
+file once it passes the same checks as 'takl edit'. Bridges that create
+issues (Jira) do so on the next push, replacing the temporary key.
+
+The key defaults to the next free DRAFT-<n>; --key picks another, e.g.
+OPS-draft to create the issue in the OPS project.
+
+--template starts from .takl/templates/<name>.md instead of a blank issue.
+Templates are issue files whose frontmatter holds the defaults (type,
+labels, priority, ...) and whose body is the description skeleton. They may
+use {{.Today}} (YYYY-MM-DD), {{.Me}} (you, as an assignee) and {{.Key}}, and
+a template block with a description and the bridge they are for:
+
+  ---
+  template:
+    description: Bug report
+    bridge: jira
+  title: "Bug: "
+  type: Bug
+  labels: [bug]
+  ---
+
+Examples:
+  takl new
+  takl new --key OPS-draft
+  takl new --template bug`,
+	Args: cobra.NoArgs,
+	RunE: runNew,
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+	newCmd.Flags().StringVar(&newKey, "key", "", "temporary key of the new issue (default DRAFT-<n>)")
+	newCmd.Flags().StringVarP(&newTemplate, "template", "t", "", "template in .takl/templates to start from")
+	newCmd.RegisterFlagCompletionFunc("template", completeTemplates)
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	client := apiclient.New()
+	var resp struct {
+		IssueKey string `json:"issue_key"`
+		Markdown string `json:"markdown"`
+	}
+	query := url.Values{"project_path": {projectPath}, "new": {"true"}}
+	if newKey != "" {
+		query.Set("issue_key", newKey)
+	}
+	if newTemplate != "" {
+		query.Set("template", newTemplate)
+	}
+	if err := client.GetJSON(cmd.Context(), "/api/edit?"+query.Encode(), &resp); err != nil {
+		return fmt.Errorf("failed to start a new issue: %w", err)
+	}
+
+	saved, err := editIssue(cmd.Context(), client, projectPath, resp.IssueKey, resp.Markdown, "")
+	if err != nil || !saved {
+		return err
+	}
+	fmt.Printf("Created %s\n", resp.IssueKey)
+	fmt.Println("Run 'takl push' to create it in the tracker.")
+	return nil
+}
+
+// completeTemplates completes --template with the project's templates
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var resp struct {
+		Templates []issue.Template `json:"templates"`
+	}
+	query := url.Values{"project_path": {projectPath}}
+	if err := apiclient.New().GetJSON(cmd.Context(), "/api/templates?"+query.Encode(), &resp); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, 0, len(resp.Templates))
+	for _, t := range resp.Templates {
+		if t.Description != "" {
+			names = append(names, t.Name+"\t"+t.Description)
+		} else {
+			names = append(names, t.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -19,6 +19,7 @@ type listResp struct {
 		Name         string    `json:"name"`
 		Path         string    `json:"path"`
 		RegisteredAt time.Time `json:"registered_at"`
+		Bridge       string    `json:"bridge,omitempty"`
 	} `json:"projects"`
 }
 
@@ -43,9 +44,13 @@ var projectsListCmd = &cobra.Command{
 			return nil
 		}
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tPATH\tREGISTERED")
+		fmt.Fprintln(w, "ID\tNAME\tBRIDGE\tPATH\tREGISTERED")
 		for _, p := range out.Projects {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.ID, p.Name, p.Path, p.RegisteredAt.Format(time.RFC3339))
+			bridge := p.Bridge
+			if bridge == "" {
+				bridge = "auto"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.ID, p.Name, bridge, p.Path, p.RegisteredAt.Format(time.RFC3339))
 		}
 		return w.Flush()
 	},
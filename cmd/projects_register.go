@@ -15,18 +15,20 @@ import (
 )
 
 type registerReq struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Bridge string `json:"bridge,omitempty"`
 }
 type registerResp struct {
 	Project struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-		Path string `json:"path"`
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Path   string `json:"path"`
+		Bridge string `json:"bridge,omitempty"`
 	} `json:"project"`
 }
 
-var regName, regPath string
+var regName, regPath, regBridge string
 var regJSON bool
 
 var projectsRegisterCmd = &cobra.Command{
@@ -50,7 +52,7 @@ var projectsRegisterCmd = &cobra.Command{
 
 		c := apiclient.New()
 		var out registerResp
-		if err := c.PostJSON(cmd.Context(), "/api/projects", registerReq{Name: regName, Path: regPath}, &out); err != nil {
+		if err := c.PostJSON(cmd.Context(), "/api/projects", registerReq{Name: regName, Path: regPath, Bridge: strings.TrimSpace(regBridge)}, &out); err != nil {
 			return err
 		}
 		if regJSON {
@@ -67,6 +69,7 @@ func init() {
 	projectsCmd.AddCommand(projectsRegisterCmd)
 	projectsRegisterCmd.Flags().StringVarP(&regName, "name", "n", "", "project name (required)")
 	projectsRegisterCmd.Flags().StringVarP(&regPath, "path", "p", "", "project path (required)")
+	projectsRegisterCmd.Flags().StringVar(&regBridge, "bridge", "", "issue tracker bridge: jira, github, gitlab, linear or local (default: detect from .takl config)")
 	projectsRegisterCmd.Flags().BoolVar(&regJSON, "json", false, "print JSON")
 	_ = projectsRegisterCmd.MarkFlagRequired("name")
 	_ = projectsRegisterCmd.MarkFlagRequired("path")
@@ -7,30 +7,47 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
 	"github.com/spf13/cobra"
 )
 
 type showIssueResp struct {
 	Issue struct {
-		JiraKey     string    `json:"jira_key"`
-		JiraID      string    `json:"jira_id"`
-		Title       string    `json:"title"`
-		Status      string    `json:"status"`
-		Assignee    string    `json:"assignee,omitempty"`
-		Reporter    string    `json:"reporter"`
-		Created     time.Time `json:"created"`
-		Updated     time.Time `json:"updated"`
-		Labels      []string  `json:"labels,omitempty"`
-		Description string    `json:"description"`
-		Comments    []struct {
+		Key               string                 `json:"jira_key"`
+		RemoteID          string                 `json:"jira_id"`
+		Title             string                 `json:"title"`
+		Status            string                 `json:"status"`
+		Assignee          string                 `json:"assignee,omitempty"`
+		Reporter          string                 `json:"reporter"`
+		Created           time.Time              `json:"created"`
+		Updated           time.Time              `json:"updated"`
+		Labels            []string               `json:"labels,omitempty"`
+		Assignees         []string               `json:"assignees,omitempty"`
+		Milestone         string                 `json:"milestone,omitempty"`
+		Type              string                 `json:"type,omitempty"`
+		Priority          string                 `json:"priority,omitempty"`
+		Components        []string               `json:"components,omitempty"`
+		FixVersions       []string               `json:"fix_versions,omitempty"`
+		Sprint            string                 `json:"sprint,omitempty"`
+		DueDate           string                 `json:"due_date,omitempty"`
+		Parent            string                 `json:"parent,omitempty"`
+		StoryPoints       float64                `json:"story_points,omitempty"`
+		OriginalEstimate  string                 `json:"original_estimate,omitempty"`
+		RemainingEstimate string                 `json:"remaining_estimate,omitempty"`
+		Custom            map[string]interface{} `json:"custom,omitempty"`
+		Links             []issue.Link           `json:"links,omitempty"`
+		Description       string                 `json:"description"`
+		Comments          []struct {
 			Author  string    `json:"author"`
 			Body    string    `json:"body"`
 			Created time.Time `json:"created"`
 		} `json:"comments"`
+		Worklogs    []issue.Worklog `json:"worklogs"`
 		Attachments []struct {
 			Filename string `json:"filename"`
 			URL      string `json:"url"`
@@ -88,33 +105,96 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return enc.Encode(resp)
 	}
 
-	issue := resp.Issue
+	shown := resp.Issue
 
 	// Print header
-	fmt.Printf("# %s: %s\n\n", issue.JiraKey, issue.Title)
+	fmt.Printf("# %s: %s\n\n", shown.Key, shown.Title)
 
 	// Print metadata
-	fmt.Printf("Status:   %s\n", issue.Status)
-	if issue.Assignee != "" {
-		fmt.Printf("Assignee: %s\n", issue.Assignee)
+	fmt.Printf("Status:   %s\n", shown.Status)
+	if shown.Assignee != "" {
+		fmt.Printf("Assignee: %s\n", shown.Assignee)
+	}
+	if len(shown.Assignees) > 0 {
+		fmt.Printf("Assignees: %s\n", strings.Join(shown.Assignees, ", "))
+	}
+	fmt.Printf("Reporter: %s\n", shown.Reporter)
+	fmt.Printf("Created:  %s\n", shown.Created.Format(time.RFC3339))
+	fmt.Printf("Updated:  %s\n", shown.Updated.Format(time.RFC3339))
+
+	if len(shown.Labels) > 0 {
+		fmt.Printf("Labels:   %s\n", strings.Join(shown.Labels, ", "))
+	}
+	if shown.Milestone != "" {
+		fmt.Printf("Milestone: %s\n", shown.Milestone)
+	}
+	if shown.Type != "" {
+		fmt.Printf("Type:     %s\n", shown.Type)
+	}
+	if shown.Priority != "" {
+		fmt.Printf("Priority: %s\n", shown.Priority)
+	}
+	if shown.Parent != "" {
+		fmt.Printf("Parent:   %s\n", shown.Parent)
+	}
+	if shown.Sprint != "" {
+		fmt.Printf("Sprint:   %s\n", shown.Sprint)
+	}
+	if shown.StoryPoints != 0 {
+		fmt.Printf("Points:   %g\n", shown.StoryPoints)
+	}
+	if shown.DueDate != "" {
+		fmt.Printf("Due:      %s\n", shown.DueDate)
+	}
+	if shown.OriginalEstimate != "" || shown.RemainingEstimate != "" {
+		fmt.Printf("Estimate: %s (remaining %s)\n", orDash(shown.OriginalEstimate), orDash(shown.RemainingEstimate))
+	}
+	if len(shown.Components) > 0 {
+		fmt.Printf("Components: %s\n", strings.Join(shown.Components, ", "))
+	}
+	if len(shown.FixVersions) > 0 {
+		fmt.Printf("Fix versions: %s\n", strings.Join(shown.FixVersions, ", "))
+	}
+	if len(shown.Custom) > 0 {
+		names := make([]string, 0, len(shown.Custom))
+		for name := range shown.Custom {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := shown.Custom[name]
+			if list, ok := value.([]interface{}); ok {
+				parts := make([]string, 0, len(list))
+				for _, item := range list {
+					parts = append(parts, fmt.Sprint(item))
+				}
+				value = strings.Join(parts, ", ")
+			}
+			fmt.Printf("%s: %v\n", name, value)
+		}
 	}
-	fmt.Printf("Reporter: %s\n", issue.Reporter)
-	fmt.Printf("Created:  %s\n", issue.Created.Format(time.RFC3339))
-	fmt.Printf("Updated:  %s\n", issue.Updated.Format(time.RFC3339))
 
-	if len(issue.Labels) > 0 {
-		fmt.Printf("Labels:   %s\n", strings.Join(issue.Labels, ", "))
+	// Print links (stored sorted by type and direction)
+	if len(shown.Links) > 0 {
+		fmt.Printf("\n## Links\n\n")
+		for _, link := range shown.Links {
+			pending := ""
+			if link.ID == "" && link.Type != issue.LinkTypeSubtask {
+				pending = " (not pushed)"
+			}
+			fmt.Printf("- %s %s%s\n", link.Relation(), link.Key, pending)
+		}
 	}
 
 	// Print description
-	if issue.Description != "" {
-		fmt.Printf("\n## Description\n\n%s\n", issue.Description)
+	if shown.Description != "" {
+		fmt.Printf("\n## Description\n\n%s\n", shown.Description)
 	}
 
 	// Print comments
-	if len(issue.Comments) > 0 {
-		fmt.Printf("\n## Comments (%d)\n\n", len(issue.Comments))
-		for i, comment := range issue.Comments {
+	if len(shown.Comments) > 0 {
+		fmt.Printf("\n## Comments (%d)\n\n", len(shown.Comments))
+		for i, comment := range shown.Comments {
 			if i > 0 {
 				fmt.Println("\n---")
 			}
@@ -125,13 +205,41 @@ func runShow(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Print worklogs
+	if len(shown.Worklogs) > 0 {
+		total := 0
+		for _, w := range shown.Worklogs {
+			total += w.TimeSpent
+		}
+		fmt.Printf("\n## Worklog (%s)\n\n", issue.FormatWorkDuration(total))
+		for _, w := range shown.Worklogs {
+			author := w.Author
+			if author == "" {
+				author = "(not pushed)"
+			}
+			line := fmt.Sprintf("- %s  %-7s %s", w.Started.Format("2006-01-02 15:04"), issue.FormatWorkDuration(w.TimeSpent), author)
+			if w.Comment != "" {
+				line += ": " + strings.ReplaceAll(w.Comment, "\n", " ")
+			}
+			fmt.Println(line)
+		}
+	}
+
 	// Print attachments
-	if len(issue.Attachments) > 0 {
-		fmt.Printf("\n## Attachments (%d)\n\n", len(issue.Attachments))
-		for _, att := range issue.Attachments {
+	if len(shown.Attachments) > 0 {
+		fmt.Printf("\n## Attachments (%d)\n\n", len(shown.Attachments))
+		for _, att := range shown.Attachments {
 			fmt.Printf("- [%s](%s)\n", att.Filename, att.URL)
 		}
 	}
 
 	return nil
 }
+
+// orDash returns s, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
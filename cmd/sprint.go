@@ -0,0 +1,200 @@
+//go:build unix
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+type sprintInfo struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	State     string    `json:"state"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Goal      string    `json:"goal,omitempty"`
+}
+
+type sprintResp struct {
+	Sprint  *sprintInfo          `json:"sprint"`
+	Columns []*issue.BoardColumn `json:"columns"`
+}
+
+var (
+	sprintJSON       bool
+	sprintRankBefore string
+	sprintRankAfter  string
+)
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Show the active Jira sprint",
+	Long: `Show the active sprint of the project's Jira board, with its issues grouped
+by status category (To Do, In Progress, Done) in board rank order.
+
+The board is set with board_id in .takl/jira.json; by default the first scrum
+board of the project is used. Issues are read from the local files, so run
+'takl pull' first to see the latest changes. Status categories come from the
+workflow cache (see 'takl jira workflow').
+
+Examples:
+  takl sprint
+  takl sprint move PROJ-1 next
+  takl sprint rank PROJ-3 --before PROJ-1`,
+	Args: cobra.NoArgs,
+	RunE: runSprint,
+}
+
+var sprintMoveCmd = &cobra.Command{
+	Use:   "move <issue-key>... <sprint>",
+	Short: "Move issues to another sprint",
+	Long: `Move issues to a sprint of the board in Jira and record the sprint in the
+issue files. The sprint is "active" (or "current"), "next" (the first future
+sprint), "backlog", or a sprint name or ID.
+
+Examples:
+  takl sprint move PROJ-1 next
+  takl sprint move PROJ-1 PROJ-2 "Sprint 14"
+  takl sprint move PROJ-3 backlog`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runSprintMove,
+}
+
+var sprintRankCmd = &cobra.Command{
+	Use:   "rank <issue-key>... (--before <issue-key> | --after <issue-key>)",
+	Short: "Rank issues on the board",
+	Long: `Rank issues, in the given order, before or after another issue on the board
+and record their new rank in the issue files.
+
+Examples:
+  takl sprint rank PROJ-3 --before PROJ-1
+  takl sprint rank PROJ-4 PROJ-5 --after PROJ-1`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSprintRank,
+}
+
+func init() {
+	rootCmd.AddCommand(sprintCmd)
+	sprintCmd.AddCommand(sprintMoveCmd)
+	sprintCmd.AddCommand(sprintRankCmd)
+	sprintCmd.Flags().BoolVar(&sprintJSON, "json", false, "output JSON")
+	sprintRankCmd.Flags().StringVar(&sprintRankBefore, "before", "", "rank before this issue")
+	sprintRankCmd.Flags().StringVar(&sprintRankAfter, "after", "", "rank after this issue")
+	sprintRankCmd.MarkFlagsMutuallyExclusive("before", "after")
+	sprintRankCmd.MarkFlagsOneRequired("before", "after")
+}
+
+func runSprint(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	client := apiclient.New()
+	var resp sprintResp
+	if err := client.PostJSON(cmd.Context(), "/api/jira/sprint", map[string]interface{}{"project_path": projectPath}, &resp); err != nil {
+		return remoteError("sprint request", err)
+	}
+
+	if sprintJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp)
+	}
+
+	fmt.Print(resp.Sprint.Name)
+	if !resp.Sprint.EndDate.IsZero() {
+		days := int(time.Until(resp.Sprint.EndDate).Hours() / 24)
+		fmt.Printf(" (ends %s, %d day(s) left)", resp.Sprint.EndDate.Local().Format("Mon 2006-01-02"), max(days, 0))
+	}
+	fmt.Println()
+	if resp.Sprint.Goal != "" {
+		fmt.Printf("Goal: %s\n", resp.Sprint.Goal)
+	}
+
+	total := 0
+	for _, column := range resp.Columns {
+		fmt.Printf("\n%s (%d)\n", column.Name, len(column.Issues))
+		if len(column.Issues) == 0 {
+			continue
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, i := range column.Issues {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", i.Key, i.Status, orDash(i.Assignee), i.Title)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		total += len(column.Issues)
+	}
+
+	if total == 0 {
+		fmt.Println("\nNo local issues in this sprint (have you run 'takl pull'?)")
+	}
+	return nil
+}
+
+func runSprintMove(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	keys, target := args[:len(args)-1], args[len(args)-1]
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+		"issue_keys":   keys,
+		"target":       target,
+	}
+
+	client := apiclient.New()
+	var resp struct {
+		Sprint *sprintInfo `json:"sprint"`
+	}
+	if err := client.PostJSON(cmd.Context(), "/api/jira/sprint/move", reqBody, &resp); err != nil {
+		return remoteError("sprint move", err)
+	}
+
+	if resp.Sprint == nil {
+		fmt.Printf("Moved %s to the backlog\n", strings.Join(keys, ", "))
+	} else {
+		fmt.Printf("Moved %s to %s (%s)\n", strings.Join(keys, ", "), resp.Sprint.Name, resp.Sprint.State)
+	}
+	return nil
+}
+
+func runSprintRank(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+		"issue_keys":   args,
+	}
+	anchor := "before " + sprintRankBefore
+	if sprintRankBefore != "" {
+		reqBody["before"] = sprintRankBefore
+	} else {
+		reqBody["after"] = sprintRankAfter
+		anchor = "after " + sprintRankAfter
+	}
+
+	client := apiclient.New()
+	if err := client.PostJSON(cmd.Context(), "/api/jira/sprint/rank", reqBody, nil); err != nil {
+		return remoteError("sprint rank", err)
+	}
+
+	fmt.Printf("Ranked %s %s\n", strings.Join(args, ", "), anchor)
+	return nil
+}
@@ -0,0 +1,143 @@
+//go:build unix
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Pull issues from the project's tracker",
+	Long: `Fetch issues from the issue tracker configured for this project.
+
+The daemon picks the bridge registered for the project (see 'takl projects
+register --bridge'). Otherwise the bridge is detected from the config file in
+.takl/ (jira.json, github.json, gitlab.json or linear.json). Local-only
+projects have nothing to pull.`,
+	RunE: runPull,
+}
+
+var pushCmd = &cobra.Command{
+	Use:   "push [issue-key]",
+	Short: "Push local changes to the project's tracker",
+	Long: `Upload modified issues to the issue tracker configured for this project.
+
+If any issue has been modified remotely since the last pull, push will fail
+with a conflict error. If an issue key is provided, only that issue will be pushed.`,
+	RunE: runPush,
+}
+
+func init() {
+	rootCmd.AddCommand(pullCmd)
+	rootCmd.AddCommand(pushCmd)
+}
+
+func runPull(cmd *cobra.Command, args []string) error {
+	return pullBridge(cmd, "")
+}
+
+func runPush(cmd *cobra.Command, args []string) error {
+	return pushBridge(cmd, args, "")
+}
+
+// pullBridge pulls through the named bridge, or the project's bridge when
+// bridgeName is empty
+func pullBridge(cmd *cobra.Command, bridgeName string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	client := apiclient.New()
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+	}
+	if bridgeName != "" {
+		reqBody["bridge"] = bridgeName
+	}
+
+	var result issue.PullResult
+	if err := client.PostJSON(cmd.Context(), "/api/bridge/pull", reqBody, &result); err != nil {
+		return remoteError("pull request", err)
+	}
+
+	fmt.Printf("Pull Complete\n")
+	fmt.Printf("  Fetched: %d issues\n", result.Fetched)
+	fmt.Printf("  Created: %d new issues\n", result.Created)
+	fmt.Printf("  Updated: %d existing issues\n", result.Updated)
+	if result.Deleted > 0 {
+		fmt.Printf("  Deleted: %d removed issues\n", result.Deleted)
+	}
+	if len(result.Kept) > 0 {
+		fmt.Printf("  Kept: %d out-of-scope issues with local edits (%s); push or discard them\n", len(result.Kept), strings.Join(result.Kept, ", "))
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Printf("\nErrors:\n")
+		for _, err := range result.Errors {
+			fmt.Printf("  - %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// pushBridge pushes through the named bridge, or the project's bridge when
+// bridgeName is empty
+func pushBridge(cmd *cobra.Command, args []string, bridgeName string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	client := apiclient.New()
+
+	reqBody := map[string]interface{}{
+		"project_path": projectPath,
+	}
+	if len(args) > 0 {
+		reqBody["issue_key"] = args[0]
+	}
+	if bridgeName != "" {
+		reqBody["bridge"] = bridgeName
+	}
+
+	var result issue.PushResult
+	if err := client.PostJSON(cmd.Context(), "/api/bridge/push", reqBody, &result); err != nil {
+		var apiErr *apiclient.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 409 {
+			// Conflict responses carry the push result in the body
+			_ = json.Unmarshal(apiErr.Body, &result)
+			fmt.Println("Error: " + issue.FormatConflictError(result.Conflicts))
+			return fmt.Errorf("push failed due to conflicts")
+		}
+		return remoteError("push request", err)
+	}
+
+	fmt.Printf("Push Complete\n")
+	fmt.Printf("  Scanned: %d issues\n", result.Scanned)
+	fmt.Printf("  Pushed: %d issues\n", result.Pushed)
+	if result.Created > 0 {
+		fmt.Printf("  Created: %d issues\n", result.Created)
+	}
+	fmt.Printf("  Skipped: %d issues (no changes)\n", result.Skipped)
+
+	if len(result.Errors) > 0 {
+		fmt.Printf("\nErrors:\n")
+		for _, err := range result.Errors {
+			fmt.Printf("  - %v\n", err)
+		}
+	}
+
+	return nil
+}
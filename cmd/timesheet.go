@@ -0,0 +1,121 @@
+//go:build unix
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/spf13/cobra"
+)
+
+type timesheetResp struct {
+	Entries []struct {
+		Project   string    `json:"project"`
+		IssueKey  string    `json:"issue_key"`
+		Title     string    `json:"title"`
+		Author    string    `json:"author,omitempty"`
+		Started   time.Time `json:"started"`
+		TimeSpent int       `json:"time_spent"`
+		Comment   string    `json:"comment,omitempty"`
+	} `json:"entries"`
+	TotalSeconds int `json:"total_seconds"`
+}
+
+var (
+	timesheetSince  string
+	timesheetUntil  string
+	timesheetAuthor string
+	timesheetJSON   bool
+)
+
+var timesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Report time logged across registered projects",
+	Long: `List the worklogs of all registered projects in a date range, with daily
+and overall totals. Days are today, yesterday, a weekday (the latest one,
+today included) or a date as YYYY-MM-DD.
+
+Examples:
+  takl timesheet                              # Since Monday
+  takl timesheet --since 2025-03-01 --until 2025-03-31
+  takl timesheet --since yesterday --author ann`,
+	Args: cobra.NoArgs,
+	RunE: runTimesheet,
+}
+
+func init() {
+	rootCmd.AddCommand(timesheetCmd)
+	timesheetCmd.Flags().StringVar(&timesheetSince, "since", "monday", "first day of the report")
+	timesheetCmd.Flags().StringVar(&timesheetUntil, "until", "", "last day of the report (default today)")
+	timesheetCmd.Flags().StringVar(&timesheetAuthor, "author", "", "only worklogs whose author contains this text")
+	timesheetCmd.Flags().BoolVar(&timesheetJSON, "json", false, "output JSON")
+}
+
+func runTimesheet(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := issue.ParseDay(timesheetSince, now)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("since", since.Format(time.RFC3339))
+	if timesheetUntil != "" {
+		until, err := issue.ParseDay(timesheetUntil, now)
+		if err != nil {
+			return err
+		}
+		// The last day is included
+		params.Set("until", until.AddDate(0, 0, 1).Format(time.RFC3339))
+	}
+	if timesheetAuthor != "" {
+		params.Set("author", timesheetAuthor)
+	}
+
+	client := apiclient.New()
+	var resp timesheetResp
+	if err := client.GetJSON(cmd.Context(), "/api/timesheet?"+params.Encode(), &resp); err != nil {
+		return remoteError("timesheet request", err)
+	}
+
+	if timesheetJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp)
+	}
+
+	if len(resp.Entries) == 0 {
+		fmt.Printf("No time logged since %s\n", since.Format("Mon 2006-01-02"))
+		return nil
+	}
+
+	// Entries are sorted by start time; days are in local time
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tPROJECT\tKEY\tTIME\tTITLE")
+	var days []string
+	dayTotals := make(map[string]int)
+	for _, e := range resp.Entries {
+		day := e.Started.Local().Format("Mon 2006-01-02")
+		if _, ok := dayTotals[day]; !ok {
+			days = append(days, day)
+		}
+		dayTotals[day] += e.TimeSpent
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", day, e.Project, e.IssueKey, issue.FormatWorkDuration(e.TimeSpent), e.Title)
+	}
+	w.Flush()
+
+	fmt.Println()
+	for _, day := range days {
+		fmt.Printf("%s  %s\n", day, issue.FormatWorkDuration(dayTotals[day]))
+	}
+	fmt.Printf("\nTotal: %s\n", issue.FormatWorkDuration(resp.TotalSeconds))
+
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:     "tui",
+	Aliases: []string{"ui"},
+	Short:   "Browse and edit issues in a full-screen terminal UI",
+	Long: `Browse the project's issues in a full-screen terminal UI: a filterable list
+next to the selected issue's fields, description and comments.
+
+Status changes, assignments and comments are saved to the issue files and
+sent to the tracker with P (push). Statuses are limited to the workflow cache
+and assignees to the member cache where the bridge has them (see
+'takl jira workflow' and 'takl jira members'). The UI reloads when the issue
+files change, e.g. after a pull.
+
+Keys:
+  ↑/↓ j/k    move             /      filter
+  enter tab  details          esc    back, clear filter
+  s          change status    a      assign
+  c          comment          P      push
+  r          reload           q      quit`,
+	Args: cobra.NoArgs,
+	RunE: runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	if err := tui.Run(cmd.Context(), apiclient.New(), projectPath); err != nil {
+		return listError(projectPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Bridge implements issue.Bridge for a GitHub repository
+type Bridge struct {
+	client *Client
+	config *GitHubConfig
+}
+
+// NewBridge creates a GitHub bridge from the given configuration
+func NewBridge(config *GitHubConfig) *Bridge {
+	return &Bridge{
+		client: NewClient(config.APIBaseURL(), config.Token, config.Owner, config.Repo),
+		config: config,
+	}
+}
+
+// Pull fetches issues from GitHub and saves them to storage
+func (b *Bridge) Pull(ctx context.Context, storage *issue.Storage) (*issue.PullResult, error) {
+	return Pull(ctx, b.client, storage, b.config)
+}
+
+// Push pushes local changes to GitHub
+func (b *Bridge) Push(ctx context.Context, storage *issue.Storage, key string) (*issue.PushResult, error) {
+	return Push(ctx, b.client, storage, b.config, key)
+}
+
+// Members returns the repository's assignable users.
+// GitHub logins are used as both account ID and display name.
+func (b *Bridge) Members(ctx context.Context, projectPath string) ([]*issue.Member, error) {
+	users, err := b.client.ListAssignees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*issue.Member, 0, len(users))
+	for _, u := range users {
+		members = append(members, &issue.Member{
+			AccountID:   u.Login,
+			DisplayName: u.Login,
+			Active:      true,
+		})
+	}
+	return members, nil
+}
+
+// Workflow returns the two GitHub issue states; GitHub has no configurable workflow
+func (b *Bridge) Workflow(ctx context.Context, projectPath string) ([]*issue.StatusInfo, error) {
+	return []*issue.StatusInfo{
+		{ID: StateOpen, Name: StateOpen, Category: "new"},
+		{ID: StateClosed, Name: StateClosed, Category: "done"},
+	}, nil
+}
@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/gurisko/takl/internal/bridge/rest"
+)
+
+// Client is a lightweight GitHub REST API client scoped to a single repository
+type Client struct {
+	api   *rest.Client
+	owner string
+	repo  string
+}
+
+// NewClient creates a new GitHub API client for the given repository
+func NewClient(baseURL, token, owner, repo string) *Client {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	return &Client{
+		api:   rest.NewClient("GitHub", baseURL, header),
+		owner: owner,
+		repo:  repo,
+	}
+}
+
+// repoPath returns the API path prefix for the configured repository
+func (c *Client) repoPath() string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(c.owner), url.PathEscape(c.repo))
+}
+
+// nextPage follows the Link header to the next page
+func nextPage(resp *http.Response, _ string) string {
+	return rest.NextLink(resp)
+}
+
+// ListIssues fetches all issues (open and closed) in the repository, excluding pull requests
+func (c *Client) ListIssues(ctx context.Context, maxResults int) ([]ghIssue, error) {
+	var all []ghIssue
+	path := fmt.Sprintf("%s/issues?state=all&sort=updated&direction=desc&per_page=%d", c.repoPath(), PageSize)
+
+	err := c.api.GetPaginated(ctx, path, maxResults, nextPage, func(r io.Reader) (int, error) {
+		var page []ghIssue
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode issues response: %w", err)
+		}
+		for _, issue := range page {
+			// The issues endpoint also returns pull requests
+			if len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null" {
+				continue
+			}
+			all = append(all, issue)
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	if maxResults > 0 && len(all) > maxResults {
+		all = all[:maxResults]
+	}
+
+	log.Printf("[DEBUG] ListIssues: Complete - fetched %d issues", len(all))
+	return all, nil
+}
+
+// ListComments fetches all comments on an issue
+func (c *Client) ListComments(ctx context.Context, number int) ([]ghComment, error) {
+	var all []ghComment
+	path := fmt.Sprintf("%s/issues/%d/comments?per_page=%d", c.repoPath(), number, PageSize)
+
+	err := c.api.GetPaginated(ctx, path, 0, nextPage, func(r io.Reader) (int, error) {
+		var page []ghComment
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode comments response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	return all, nil
+}
+
+// GetIssue fetches a single issue by number
+func (c *Client) GetIssue(ctx context.Context, number int) (*ghIssue, error) {
+	path := fmt.Sprintf("%s/issues/%d", c.repoPath(), number)
+
+	resp, err := c.api.Do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var issue ghIssue
+	if err := json.NewDecoder(io.LimitReader(resp.Body, rest.MaxResponseSize)).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode issue response: %w", err)
+	}
+
+	return &issue, nil
+}
+
+// UpdateIssue updates an issue's fields (title, body, state, labels, assignees, milestone)
+func (c *Client) UpdateIssue(ctx context.Context, number int, updates map[string]interface{}) error {
+	path := fmt.Sprintf("%s/issues/%d", c.repoPath(), number)
+
+	log.Printf("[DEBUG] UpdateIssue: Updating issue #%d", number)
+
+	resp, err := c.api.Do(ctx, http.MethodPatch, path, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// AddComment adds a comment to an issue
+func (c *Client) AddComment(ctx context.Context, number int, body string) error {
+	path := fmt.Sprintf("%s/issues/%d/comments", c.repoPath(), number)
+
+	log.Printf("[DEBUG] AddComment: Adding comment to issue #%d", number)
+
+	resp, err := c.api.Do(ctx, http.MethodPost, path, map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// ListMilestones fetches all milestones (open and closed) in the repository
+func (c *Client) ListMilestones(ctx context.Context) ([]ghMilestone, error) {
+	var all []ghMilestone
+	path := fmt.Sprintf("%s/milestones?state=all&per_page=%d", c.repoPath(), PageSize)
+
+	err := c.api.GetPaginated(ctx, path, 0, nextPage, func(r io.Reader) (int, error) {
+		var page []ghMilestone
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode milestones response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	return all, nil
+}
+
+// ListAssignees fetches all users that can be assigned issues in the repository
+func (c *Client) ListAssignees(ctx context.Context) ([]ghUser, error) {
+	var all []ghUser
+	path := fmt.Sprintf("%s/assignees?per_page=%d", c.repoPath(), PageSize)
+
+	err := c.api.GetPaginated(ctx, path, 0, nextPage, func(r io.Reader) (int, error) {
+		var page []ghUser
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode assignees response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignees: %w", err)
+	}
+
+	return all, nil
+}
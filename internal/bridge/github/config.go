@@ -0,0 +1,53 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadConfig loads and validates GitHub configuration from .takl/github.json
+// in the specified project directory.
+func LoadConfig(projectPath string) (*GitHubConfig, error) {
+	configPath := filepath.Join(projectPath, ".takl", "github.json")
+
+	// Check file permissions (should be 0600 to protect the token)
+	fi, statErr := os.Stat(configPath)
+	if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
+		return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", configPath, configPath)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read github config at %s: %w\nPlease create .takl/github.json with your GitHub credentials", configPath, err)
+	}
+
+	var config GitHubConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse github config: %w", err)
+	}
+
+	// Validate required fields
+	if config.Token == "" || config.Owner == "" || config.Repo == "" {
+		return nil, fmt.Errorf("github config is incomplete: token, owner, and repo are required")
+	}
+
+	return &config, nil
+}
+
+// LoadConfigFromCwd loads GitHub configuration from the current working directory.
+// Returns the config and the current working directory path.
+func LoadConfigFromCwd() (*GitHubConfig, string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	config, err := LoadConfig(cwd)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return config, cwd, nil
+}
@@ -0,0 +1,9 @@
+package github
+
+const (
+	// PageSize is the number of items to fetch per API request (GitHub maximum)
+	PageSize = 100
+
+	// MaxIssues is the maximum total number of issues to fetch
+	MaxIssues = 1000
+)
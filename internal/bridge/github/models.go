@@ -0,0 +1,77 @@
+package github
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ghIssue represents an issue from the GitHub REST API
+//
+// Example response from GET /repos/{owner}/{repo}/issues:
+//
+//	{
+//	  "id": 1296269,
+//	  "number": 1347,
+//	  "title": "Found a bug",
+//	  "body": "I'm having a problem with this.",
+//	  "state": "open",
+//	  "user": {"login": "octocat"},
+//	  "labels": [{"name": "bug"}],
+//	  "assignees": [{"login": "octocat"}],
+//	  "milestone": {"number": 1, "title": "v1.0"},
+//	  "comments": 2,
+//	  "created_at": "2011-04-22T13:33:48Z",
+//	  "updated_at": "2011-04-22T13:33:48Z"
+//	}
+//
+// Note: body and milestone can be null. Pull requests are returned by the
+// issues endpoint too and carry a non-null "pull_request" field.
+type ghIssue struct {
+	ID          int64           `json:"id"`
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        *string         `json:"body"`
+	State       string          `json:"state"`
+	User        ghUser          `json:"user"`
+	Labels      []ghLabel       `json:"labels"`
+	Assignees   []ghUser        `json:"assignees"`
+	Milestone   *ghMilestone    `json:"milestone"`
+	Comments    int             `json:"comments"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// ghComment represents an issue comment
+//
+// Example response from GET /repos/{owner}/{repo}/issues/{number}/comments:
+//
+//	{
+//	  "id": 1,
+//	  "body": "Me too",
+//	  "user": {"login": "octocat"},
+//	  "created_at": "2011-04-14T16:00:49Z",
+//	  "updated_at": "2011-04-14T16:00:49Z"
+//	}
+type ghComment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      ghUser    `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ghUser struct {
+	Login string `json:"login"`
+	ID    int64  `json:"id"`
+}
+
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+type ghMilestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// issueKey returns the local key for a GitHub issue number (e.g. GH-123)
+func issueKey(prefix string, number int) string {
+	return fmt.Sprintf("%s-%d", prefix, number)
+}
+
+// issueNumber parses the GitHub issue number from a local key
+func issueNumber(prefix, key string) (int, error) {
+	numStr, ok := strings.CutPrefix(key, prefix+"-")
+	if !ok {
+		return 0, fmt.Errorf("issue key %q does not have prefix %q", key, prefix)
+	}
+	n, err := strconv.Atoi(numStr)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid issue number in key %q", key)
+	}
+	return n, nil
+}
+
+// convertIssue converts a GitHub issue and its comments to the shared issue model
+func convertIssue(prefix string, gi ghIssue, comments []ghComment) issue.Issue {
+	out := issue.Issue{
+		Key:      issueKey(prefix, gi.Number),
+		RemoteID: strconv.FormatInt(gi.ID, 10),
+		Title:    gi.Title,
+		Status:   gi.State,
+		Reporter: gi.User.Login,
+		Created:  gi.CreatedAt,
+		Updated:  gi.UpdatedAt,
+	}
+
+	if gi.Body != nil {
+		// GitHub bodies are already markdown; normalize CRLF from web edits
+		out.Description = strings.TrimSpace(strings.ReplaceAll(*gi.Body, "\r\n", "\n"))
+	}
+
+	for _, l := range gi.Labels {
+		out.Labels = append(out.Labels, l.Name)
+	}
+	sort.Strings(out.Labels)
+
+	for _, a := range gi.Assignees {
+		out.Assignees = append(out.Assignees, a.Login)
+	}
+	sort.Strings(out.Assignees)
+
+	if gi.Milestone != nil {
+		out.Milestone = gi.Milestone.Title
+	}
+
+	out.Comments = make([]issue.Comment, 0, len(comments))
+	for _, gc := range comments {
+		out.Comments = append(out.Comments, issue.Comment{
+			ID:       strconv.FormatInt(gc.ID, 10),
+			Author:   gc.User.Login,
+			AuthorID: strconv.FormatInt(gc.User.ID, 10),
+			Body:     strings.TrimSpace(strings.ReplaceAll(gc.Body, "\r\n", "\n")),
+			Created:  gc.CreatedAt,
+			Updated:  gc.UpdatedAt,
+		})
+	}
+
+	return out
+}
+
+// fetchIssue fetches a single issue with its comments and converts it
+func fetchIssue(ctx context.Context, client *Client, prefix string, number int) (*issue.Issue, error) {
+	gi, err := client.GetIssue(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []ghComment
+	if gi.Comments > 0 {
+		comments, err = client.ListComments(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := convertIssue(prefix, *gi, comments)
+	return &out, nil
+}
+
+// Pull fetches issues from GitHub and saves them to local storage
+func Pull(ctx context.Context, client *Client, storage *issue.Storage, config *GitHubConfig) (*issue.PullResult, error) {
+	result := &issue.PullResult{
+		Errors: make([]string, 0),
+	}
+	prefix := config.Prefix()
+
+	ghIssues, err := client.ListIssues(ctx, MaxIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	log.Printf("[DEBUG] Pull: Fetched %d issues from GitHub", len(ghIssues))
+	result.Fetched = len(ghIssues)
+
+	// Get list of existing local issues
+	localIssues, err := storage.ListIssues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local issues: %w", err)
+	}
+
+	localMap := make(map[string]bool)
+	for _, key := range localIssues {
+		localMap[key] = true
+	}
+
+	fetchedKeys := make(map[string]bool)
+	for _, gi := range ghIssues {
+		fetchedKeys[issueKey(prefix, gi.Number)] = true
+	}
+
+	// Delete local issues that are no longer on GitHub (deleted or transferred).
+	// Only keys owned by this bridge are considered.
+	for _, localKey := range localIssues {
+		if _, err := issueNumber(prefix, localKey); err != nil {
+			continue
+		}
+		if !fetchedKeys[localKey] {
+			log.Printf("[DEBUG] Pull: Deleting removed issue %s", localKey)
+			if err := storage.DeleteIssue(localKey); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to delete %s: %v", localKey, err))
+			} else {
+				result.Deleted++
+			}
+		}
+	}
+
+	for _, gi := range ghIssues {
+		key := issueKey(prefix, gi.Number)
+		isNew := !localMap[key]
+
+		var comments []ghComment
+		if gi.Comments > 0 {
+			comments, err = client.ListComments(ctx, gi.Number)
+			if err != nil {
+				log.Printf("[ERROR] Pull: Failed to fetch comments for %s: %v", key, err)
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to fetch comments for %s: %v", key, err))
+				continue
+			}
+		}
+
+		converted := convertIssue(prefix, gi, comments)
+
+		// Skip unchanged issues
+		if !isNew {
+			newHash := storage.ComputeHash(&converted)
+			if oldHash, ok := storage.ReadExistingHash(key); ok && oldHash == newHash {
+				log.Printf("[DEBUG] Pull: Skipping %s (unchanged)", key)
+				continue
+			}
+		}
+
+		if err := storage.SaveIssue(&converted); err != nil {
+			log.Printf("[ERROR] Pull: Failed to save %s: %v", key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to save %s: %v", key, err))
+			continue
+		}
+
+		if isNew {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	log.Printf("[DEBUG] Pull: Complete - Created: %d, Updated: %d, Deleted: %d, Errors: %d", result.Created, result.Updated, result.Deleted, len(result.Errors))
+
+	// Return error if all issues failed to save
+	if len(result.Errors) > 0 && result.Created == 0 && result.Updated == 0 {
+		return result, fmt.Errorf("failed to save any issues (%d errors)", len(result.Errors))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,327 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// fakeGitHub is an in-memory stand-in for the GitHub REST API issue endpoints
+type fakeGitHub struct {
+	mu         sync.Mutex
+	t          *testing.T
+	issues     map[int]*ghIssue
+	comments   map[int][]ghComment
+	milestones []ghMilestone
+	pageSize   int
+	nextID     int64
+	patches    []map[string]interface{}
+	server     *httptest.Server
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+	f := &fakeGitHub{
+		t:        t,
+		issues:   make(map[int]*ghIssue),
+		comments: make(map[int][]ghComment),
+		pageSize: 2,
+		nextID:   1000,
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGitHub) client() *Client {
+	return NewClient(f.server.URL, "test-token", "octo", "repo")
+}
+
+func (f *fakeGitHub) addIssue(number int, title string, mutate func(*ghIssue)) {
+	body := "Body of " + title
+	issue := &ghIssue{
+		ID:        int64(number * 10),
+		Number:    number,
+		Title:     title,
+		Body:      &body,
+		State:     StateOpen,
+		User:      ghUser{Login: "octocat"},
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, number, 0, 0, 0, 0, time.UTC),
+	}
+	if mutate != nil {
+		mutate(issue)
+	}
+	f.issues[number] = issue
+}
+
+func (f *fakeGitHub) addComment(number int, author, body string) {
+	f.nextID++
+	f.comments[number] = append(f.comments[number], ghComment{
+		ID:        f.nextID,
+		Body:      body,
+		User:      ghUser{Login: author},
+		CreatedAt: time.Date(2025, 2, len(f.comments[number])+1, 0, 0, 0, 0, time.UTC),
+	})
+	f.issues[number].Comments = len(f.comments[number])
+}
+
+func (f *fakeGitHub) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/repos/octo/repo")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "issues" && r.Method == http.MethodGet:
+		numbers := make([]int, 0, len(f.issues))
+		for n := range f.issues {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+		list := make([]*ghIssue, 0, len(numbers))
+		for _, n := range numbers {
+			list = append(list, f.issues[n])
+		}
+		writePage(f, w, r, list)
+
+	case len(parts) == 1 && parts[0] == "milestones":
+		f.writeJSON(w, f.milestones)
+
+	case len(parts) >= 2 && parts[0] == "issues":
+		n, _ := strconv.Atoi(parts[1])
+		issue, ok := f.issues[n]
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		if len(parts) == 3 && parts[2] == "comments" {
+			f.handleComments(w, r, n)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			f.writeJSON(w, issue)
+		case http.MethodPatch:
+			f.applyPatch(w, r, issue)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+	default:
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitHub) handleComments(w http.ResponseWriter, r *http.Request, n int) {
+	switch r.Method {
+	case http.MethodGet:
+		writePage(f, w, r, f.comments[n])
+	case http.MethodPost:
+		var req struct {
+			Body string `json:"body"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f.addComment(n, "octocat", req.Body)
+		w.WriteHeader(http.StatusCreated)
+		f.writeJSON(w, f.comments[n][len(f.comments[n])-1])
+	}
+}
+
+func (f *fakeGitHub) applyPatch(w http.ResponseWriter, r *http.Request, issue *ghIssue) {
+	var patch map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	f.patches = append(f.patches, patch)
+
+	if v, ok := patch["title"].(string); ok {
+		issue.Title = v
+	}
+	if v, ok := patch["body"].(string); ok {
+		issue.Body = &v
+	}
+	if v, ok := patch["state"].(string); ok {
+		issue.State = v
+	}
+	if v, ok := patch["labels"].([]interface{}); ok {
+		issue.Labels = nil
+		for _, l := range v {
+			issue.Labels = append(issue.Labels, ghLabel{Name: l.(string)})
+		}
+	}
+	if v, ok := patch["assignees"].([]interface{}); ok {
+		issue.Assignees = nil
+		for _, a := range v {
+			issue.Assignees = append(issue.Assignees, ghUser{Login: a.(string)})
+		}
+	}
+	if v, ok := patch["milestone"]; ok {
+		issue.Milestone = nil
+		if num, ok := v.(float64); ok {
+			for _, m := range f.milestones {
+				if m.Number == int(num) {
+					m := m
+					issue.Milestone = &m
+				}
+			}
+		}
+	}
+	issue.UpdatedAt = issue.UpdatedAt.Add(time.Hour)
+	f.writeJSON(w, issue)
+}
+
+// writePage writes one page of a list using page/per_page query params and a Link header
+func writePage[T any](f *fakeGitHub, w http.ResponseWriter, r *http.Request, items []T) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * f.pageSize
+	end := start + f.pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+	if end < len(items) {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(page+1))
+		next := fmt.Sprintf("%s%s?%s", f.server.URL, r.URL.Path, q.Encode())
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next, next))
+	}
+	f.writeJSON(w, items[start:end])
+}
+
+func (f *fakeGitHub) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("failed to encode fake response: %v", err)
+	}
+}
+
+// TestListIssues_Pagination tests that Link-header pagination fetches every page
+// and that pull requests are skipped
+func TestListIssues_Pagination(t *testing.T) {
+	f := newFakeGitHub(t)
+	for i := 1; i <= 5; i++ {
+		f.addIssue(i, fmt.Sprintf("Issue %d", i), nil)
+	}
+	f.issues[3].PullRequest = json.RawMessage(`{"url":"https://example.com/pr/3"}`)
+
+	issues, err := f.client().ListIssues(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+
+	if len(issues) != 4 {
+		t.Fatalf("Expected 4 issues (PR excluded), got %d", len(issues))
+	}
+	for _, issue := range issues {
+		if issue.Number == 3 {
+			t.Errorf("Pull request #3 should have been skipped")
+		}
+	}
+}
+
+// TestPull_MapsFields tests that pull maps GitHub fields into the markdown model
+func TestPull_MapsFields(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.milestones = []ghMilestone{{Number: 1, Title: "v1.0"}}
+	f.addIssue(1, "First", func(i *ghIssue) {
+		i.Labels = []ghLabel{{Name: "bug"}, {Name: "api"}}
+		i.Assignees = []ghUser{{Login: "zoe"}, {Login: "adam"}}
+		i.Milestone = &ghMilestone{Number: 1, Title: "v1.0"}
+		i.State = StateClosed
+	})
+	for c := 1; c <= 3; c++ {
+		f.addComment(1, "reviewer", fmt.Sprintf("Comment %d", c))
+	}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &GitHubConfig{Owner: "octo", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("Expected 1 created got, got %d", result.Created)
+	}
+
+	got, err := storage.ReadIssue("GH-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if got.Status != StateClosed {
+		t.Errorf("Expected status %q, got %q", StateClosed, got.Status)
+	}
+	if strings.Join(got.Labels, ",") != "api,bug" {
+		t.Errorf("Expected labels [api bug], got %v", got.Labels)
+	}
+	if strings.Join(got.Assignees, ",") != "adam,zoe" {
+		t.Errorf("Expected assignees [adam zoe], got %v", got.Assignees)
+	}
+	if got.Milestone != "v1.0" {
+		t.Errorf("Expected milestone v1.0, got %q", got.Milestone)
+	}
+	if len(got.Comments) != 3 {
+		t.Fatalf("Expected 3 comments (across pages), got %d", len(got.Comments))
+	}
+	if got.Comments[2].Body != "Comment 3" {
+		t.Errorf("Expected last comment body %q, got %q", "Comment 3", got.Comments[2].Body)
+	}
+}
+
+// TestPull_DeletesOnlyOwnKeys tests that pull removes vanished GitHub issues
+// but leaves issues from other bridges alone
+func TestPull_DeletesOnlyOwnKeys(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.addIssue(1, "Keep", nil)
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	for _, key := range []string{"GH-2", "PROJ-7"} {
+		if err := storage.SaveIssue(&issue.Issue{Key: key, Title: key}); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &GitHubConfig{Owner: "octo", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Deleted != 1 {
+		t.Errorf("Expected 1 deleted issue, got %d", result.Deleted)
+	}
+
+	keys, _ := storage.ListIssues()
+	if strings.Join(keys, ",") != "GH-1,PROJ-7" {
+		t.Errorf("Expected keys [GH-1 PROJ-7], got %v", keys)
+	}
+}
@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gurisko/takl/internal/bridge/rest"
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Valid GitHub issue states
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// Push pushes local changes to GitHub with strict conflict detection.
+// Fails for an issue if the remote has ANY changes since the last pull.
+// If issueKey is provided, only that issue will be pushed.
+func Push(ctx context.Context, client *Client, storage *issue.Storage, config *GitHubConfig, issueKey string) (*issue.PushResult, error) {
+	result := &issue.PushResult{
+		Conflicts: make([]issue.ConflictInfo, 0),
+		Errors:    make([]string, 0),
+	}
+	prefix := config.Prefix()
+
+	// Get local issues (all or specific one)
+	var localIssues []*issue.Issue
+	if issueKey != "" {
+		single, err := storage.ReadIssue(issueKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issue %s: %w", issueKey, err)
+		}
+		localIssues = []*issue.Issue{single}
+	} else {
+		all, err := storage.ListAllIssues()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list local issues: %w", err)
+		}
+		// Only issues owned by this bridge
+		for _, local := range all {
+			if _, err := issueNumber(prefix, local.Key); err == nil {
+				localIssues = append(localIssues, local)
+			}
+		}
+	}
+
+	result.Scanned = len(localIssues)
+
+	// Milestones are resolved lazily, only if some issue changes its milestone
+	var milestones map[string]int
+
+	for _, localIssue := range localIssues {
+		number, err := issueNumber(prefix, localIssue.Key)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", localIssue.Key, err))
+			continue
+		}
+
+		localHash := storage.ComputeHash(localIssue)
+		baseHash := localIssue.Hash
+		if localHash == baseHash {
+			log.Printf("[DEBUG] Push: Skipping %s (no local changes)", localIssue.Key)
+			result.Skipped++
+			continue
+		}
+
+		// Fetch current remote version for conflict detection
+		remoteIssue, err := fetchIssue(ctx, client, prefix, number)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to fetch remote: %v", localIssue.Key, err))
+			continue
+		}
+
+		if storage.ComputeHash(remoteIssue) != baseHash {
+			log.Printf("[WARN] Push: Conflict detected for %s (remote modified)", localIssue.Key)
+			result.Conflicts = append(result.Conflicts, issue.ConflictInfo{
+				IssueKey: localIssue.Key,
+				Updated:  remoteIssue.Updated,
+			})
+			continue
+		}
+
+		if localIssue.Milestone != remoteIssue.Milestone && localIssue.Milestone != "" && milestones == nil {
+			milestones, err = loadMilestones(ctx, client)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", localIssue.Key, err))
+				continue
+			}
+		}
+
+		if err := pushIssue(ctx, client, storage, prefix, number, localIssue, remoteIssue, milestones); err != nil {
+			log.Printf("[ERROR] Push: Failed to push %s: %v", localIssue.Key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", localIssue.Key, err))
+			continue
+		}
+
+		result.Pushed++
+	}
+
+	log.Printf("[DEBUG] Push: Complete - Scanned: %d, Pushed: %d, Skipped: %d, Conflicts: %d, Errors: %d",
+		result.Scanned, result.Pushed, result.Skipped, len(result.Conflicts), len(result.Errors))
+
+	if len(result.Conflicts) > 0 {
+		return result, fmt.Errorf("cannot push - %d issue(s) have conflicts", len(result.Conflicts))
+	}
+
+	return result, nil
+}
+
+// loadMilestones returns a map of milestone title to number
+func loadMilestones(ctx context.Context, client *Client) (map[string]int, error) {
+	list, err := client.ListMilestones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	milestones := make(map[string]int, len(list))
+	for _, m := range list {
+		milestones[m.Title] = m.Number
+	}
+	return milestones, nil
+}
+
+// pushIssue pushes changes for a single issue to GitHub.
+// Compares local vs remote and updates only what changed.
+func pushIssue(ctx context.Context, client *Client, storage *issue.Storage, prefix string, number int, local, remote *issue.Issue, milestones map[string]int) error {
+	updates := make(map[string]interface{})
+
+	if local.Title != remote.Title {
+		updates["title"] = local.Title
+	}
+
+	if local.Description != remote.Description {
+		updates["body"] = local.Description
+	}
+
+	if local.Status != remote.Status {
+		state := strings.ToLower(local.Status)
+		if state != StateOpen && state != StateClosed {
+			return fmt.Errorf("invalid status %q: GitHub issues must be %q or %q", local.Status, StateOpen, StateClosed)
+		}
+		updates["state"] = state
+	}
+
+	if !rest.SameSet(local.Labels, remote.Labels) {
+		updates["labels"] = rest.SortedCopy(local.Labels)
+	}
+
+	if !rest.SameSet(local.Assignees, remote.Assignees) {
+		updates["assignees"] = rest.SortedCopy(local.Assignees)
+	}
+
+	if local.Milestone != remote.Milestone {
+		if local.Milestone == "" {
+			updates["milestone"] = nil
+		} else {
+			n, ok := milestones[local.Milestone]
+			if !ok {
+				return fmt.Errorf("milestone %q not found in repository", local.Milestone)
+			}
+			updates["milestone"] = n
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := client.UpdateIssue(ctx, number, updates); err != nil {
+			return err
+		}
+	}
+
+	// We only support adding new comments, not editing existing ones
+	for i := len(remote.Comments); i < len(local.Comments); i++ {
+		if err := client.AddComment(ctx, number, local.Comments[i].Body); err != nil {
+			return fmt.Errorf("failed to add comment #%d: %w", i+1, err)
+		}
+	}
+
+	// Refresh the local file so the stored hash matches the new remote state
+	updated, err := fetchIssue(ctx, client, prefix, number)
+	if err != nil {
+		log.Printf("[WARN] pushIssue: Failed to fetch updated issue, keeping local hash: %v", err)
+		return nil
+	}
+	if err := storage.SaveIssue(updated); err != nil {
+		log.Printf("[WARN] pushIssue: Failed to save updated issue: %v", err)
+	}
+
+	return nil
+}
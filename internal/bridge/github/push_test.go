@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// pullOne pulls the fake repository into fresh storage and returns the storage
+func pullOne(t *testing.T, f *fakeGitHub) *issue.Storage {
+	t.Helper()
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	if _, err := Pull(context.Background(), f.client(), storage, &GitHubConfig{Owner: "octo", Repo: "repo"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	return storage
+}
+
+// TestPush_UpdatesFields tests that local edits are sent as a single PATCH
+// and new comments are posted
+func TestPush_UpdatesFields(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.milestones = []ghMilestone{{Number: 1, Title: "v1.0"}, {Number: 2, Title: "v2.0"}}
+	f.addIssue(1, "First", func(i *ghIssue) {
+		i.Labels = []ghLabel{{Name: "bug"}}
+		i.Milestone = &ghMilestone{Number: 1, Title: "v1.0"}
+	})
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("GH-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Title = "Renamed"
+	edited.Status = "closed"
+	edited.Labels = []string{"bug", "wontfix"}
+	edited.Assignees = []string{"octocat"}
+	edited.Milestone = "v2.0"
+	edited.Comments = append(edited.Comments, issue.Comment{Author: "me", Body: "Closing this"})
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &GitHubConfig{Owner: "octo", Repo: "repo"}, "")
+	if err != nil {
+		t.Fatalf("Push failed: %v (errors: %v)", err, result.Errors)
+	}
+	if result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %d (errors: %v)", result.Pushed, result.Errors)
+	}
+
+	if len(f.patches) != 1 {
+		t.Fatalf("Expected 1 PATCH request, got %d", len(f.patches))
+	}
+	patch := f.patches[0]
+	if patch["title"] != "Renamed" || patch["state"] != "closed" {
+		t.Errorf("Unexpected patch: %v", patch)
+	}
+	if patch["milestone"] != float64(2) {
+		t.Errorf("Expected milestone number 2, got %v", patch["milestone"])
+	}
+	if _, ok := patch["body"]; ok {
+		t.Errorf("Unchanged body should not be sent")
+	}
+
+	if len(f.comments[1]) != 1 || f.comments[1][0].Body != "Closing this" {
+		t.Errorf("Expected new comment to be posted, got %v", f.comments[1])
+	}
+
+	// Local file should now match remote and have a fresh hash
+	refreshed, err := storage.ReadIssue("GH-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if storage.ComputeHash(refreshed) != refreshed.Hash {
+		t.Errorf("Expected refreshed file to have no pending local changes")
+	}
+}
+
+// TestPush_Conflict tests that remote edits since the last pull block the push
+func TestPush_Conflict(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("GH-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Title = "Local title"
+	writeLocalEdit(t, storage, edited)
+
+	// Remote changes after pull
+	f.issues[1].Title = "Remote title"
+
+	result, err := Push(context.Background(), f.client(), storage, &GitHubConfig{Owner: "octo", Repo: "repo"}, "")
+	if err == nil {
+		t.Fatalf("Expected conflict error")
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].IssueKey != "GH-1" {
+		t.Errorf("Expected conflict for GH-1, got %v", result.Conflicts)
+	}
+	if len(f.patches) != 0 {
+		t.Errorf("Expected no PATCH requests on conflict, got %d", len(f.patches))
+	}
+}
+
+// TestPush_InvalidState tests that statuses other than open/closed are rejected
+func TestPush_InvalidState(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("GH-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Status = "In Progress"
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &GitHubConfig{Owner: "octo", Repo: "repo"}, "GH-1")
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Expected 1 error for invalid state, got %v", result.Errors)
+	}
+}
+
+// writeLocalEdit saves an edited issue while keeping its base hash, the way
+// a hand edit of the markdown file would
+func writeLocalEdit(t *testing.T, storage *issue.Storage, edited *issue.Issue) {
+	t.Helper()
+	if err := storage.WriteIssue(edited); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+}
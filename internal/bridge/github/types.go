@@ -0,0 +1,44 @@
+package github
+
+import "fmt"
+
+// DefaultBaseURL is the GitHub REST API endpoint used when base_url is not configured
+const DefaultBaseURL = "https://api.github.com"
+
+// DefaultKeyPrefix is the issue key prefix used when key_prefix is not configured
+const DefaultKeyPrefix = "GH"
+
+// GitHubConfig holds GitHub connection configuration
+type GitHubConfig struct {
+	BaseURL   string `yaml:"base_url,omitempty" json:"base_url,omitempty"`     // API URL (GitHub Enterprise: https://host/api/v3)
+	Token     string `yaml:"token" json:"token"`                               // Personal access token
+	Owner     string `yaml:"owner" json:"owner"`                               // Repository owner (user or organization)
+	Repo      string `yaml:"repo" json:"repo"`                                 // Repository name
+	KeyPrefix string `yaml:"key_prefix,omitempty" json:"key_prefix,omitempty"` // Local issue key prefix (e.g. "GH" → GH-123)
+}
+
+// String returns a sanitized string representation (hides token)
+func (c GitHubConfig) String() string {
+	token := "***REDACTED***"
+	if len(c.Token) > 4 {
+		token = c.Token[:4] + "***"
+	}
+	return fmt.Sprintf("GitHubConfig{BaseURL: %s, Owner: %s, Repo: %s, Token: %s, KeyPrefix: %s}",
+		c.BaseURL, c.Owner, c.Repo, token, c.KeyPrefix)
+}
+
+// APIBaseURL returns the configured API URL or the public GitHub API
+func (c GitHubConfig) APIBaseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return DefaultBaseURL
+}
+
+// Prefix returns the configured key prefix or the default
+func (c GitHubConfig) Prefix() string {
+	if c.KeyPrefix != "" {
+		return c.KeyPrefix
+	}
+	return DefaultKeyPrefix
+}
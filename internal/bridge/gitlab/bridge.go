@@ -0,0 +1,57 @@
+package gitlab
+
+import (
+	"context"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Bridge implements issue.Bridge for a GitLab project
+type Bridge struct {
+	client *Client
+	config *GitLabConfig
+}
+
+// NewBridge creates a GitLab bridge from the given configuration
+func NewBridge(config *GitLabConfig) *Bridge {
+	return &Bridge{
+		client: NewClient(config.APIBaseURL(), config.Token, config.Project),
+		config: config,
+	}
+}
+
+// Pull fetches issues from GitLab and saves them to storage
+func (b *Bridge) Pull(ctx context.Context, storage *issue.Storage) (*issue.PullResult, error) {
+	return Pull(ctx, b.client, storage, b.config)
+}
+
+// Push pushes local changes to GitLab
+func (b *Bridge) Push(ctx context.Context, storage *issue.Storage, key string) (*issue.PushResult, error) {
+	return Push(ctx, b.client, storage, b.config, key)
+}
+
+// Members returns the project's members, keyed by username
+func (b *Bridge) Members(ctx context.Context, projectPath string) ([]*issue.Member, error) {
+	users, err := b.client.ListMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*issue.Member, 0, len(users))
+	for _, u := range users {
+		members = append(members, &issue.Member{
+			AccountID:   u.Username,
+			DisplayName: u.Name,
+			Active:      u.State == "active",
+		})
+	}
+	return members, nil
+}
+
+// Workflow returns the two GitLab issue states; GitLab has no configurable workflow
+func (b *Bridge) Workflow(ctx context.Context, projectPath string) ([]*issue.StatusInfo, error) {
+	return []*issue.StatusInfo{
+		{ID: StateOpened, Name: StateOpened, Category: "new"},
+		{ID: StateClosed, Name: StateClosed, Category: "done"},
+	}, nil
+}
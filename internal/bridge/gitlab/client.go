@@ -0,0 +1,197 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gurisko/takl/internal/bridge/rest"
+)
+
+// Client is a lightweight GitLab REST API v4 client scoped to a single project
+type Client struct {
+	api     *rest.Client
+	project string
+}
+
+// NewClient creates a new GitLab API client for the given project.
+// baseURL is the API URL (e.g. https://gitlab.com/api/v4) and project is
+// the project path (group/project) or numeric ID.
+func NewClient(baseURL, token, project string) *Client {
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", token)
+	header.Set("Accept", "application/json")
+	return &Client{
+		api:     rest.NewClient("GitLab", baseURL, header),
+		project: project,
+	}
+}
+
+// projectPath returns the API path prefix for the configured project.
+// Namespaced paths must be URL-encoded as a single segment (group%2Fproject).
+func (c *Client) projectPath() string {
+	return "/projects/" + url.PathEscape(c.project)
+}
+
+// nextPage returns the path or URL of the page after the one requested at
+// path, or "" on the last page. The Link header is preferred since it
+// carries the cursor of keyset pagination; X-Next-Page covers offset
+// pagination on instances behind proxies that rewrite the host in Link URLs.
+func (c *Client) nextPage(resp *http.Response, path string) string {
+	if next := rest.NextLink(resp); next != "" && strings.HasPrefix(next, c.api.BaseURL()+"/") {
+		return next
+	}
+
+	next := resp.Header.Get("X-Next-Page")
+	if next == "" {
+		return ""
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set("page", next)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// ListIssues fetches all issues (open and closed) in the project, newest
+// first. Keyset pagination keeps deep pages cheap and stable while issues
+// change; instances without it fall back to offset pagination.
+func (c *Client) ListIssues(ctx context.Context, maxResults int) ([]glIssue, error) {
+	var all []glIssue
+	path := fmt.Sprintf("%s/issues?scope=all&state=all&pagination=keyset&order_by=id&sort=desc&per_page=%d", c.projectPath(), PageSize)
+
+	err := c.api.GetPaginated(ctx, path, maxResults, c.nextPage, func(r io.Reader) (int, error) {
+		var page []glIssue
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode issues response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	if maxResults > 0 && len(all) > maxResults {
+		all = all[:maxResults]
+	}
+
+	log.Printf("[DEBUG] ListIssues: Complete - fetched %d issues", len(all))
+	return all, nil
+}
+
+// ListNotes fetches all notes on an issue in creation order, including system notes
+func (c *Client) ListNotes(ctx context.Context, iid int) ([]glNote, error) {
+	var all []glNote
+	path := fmt.Sprintf("%s/issues/%d/notes?order_by=created_at&sort=asc&per_page=%d", c.projectPath(), iid, PageSize)
+
+	err := c.api.GetPaginated(ctx, path, 0, c.nextPage, func(r io.Reader) (int, error) {
+		var page []glNote
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode notes response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	return all, nil
+}
+
+// GetIssue fetches a single issue by its project-scoped IID
+func (c *Client) GetIssue(ctx context.Context, iid int) (*glIssue, error) {
+	path := fmt.Sprintf("%s/issues/%d", c.projectPath(), iid)
+
+	resp, err := c.api.Do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var issue glIssue
+	if err := json.NewDecoder(io.LimitReader(resp.Body, rest.MaxResponseSize)).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode issue response: %w", err)
+	}
+
+	return &issue, nil
+}
+
+// UpdateIssue updates an issue's fields (title, description, state_event, labels, assignee_ids, milestone_id)
+func (c *Client) UpdateIssue(ctx context.Context, iid int, updates map[string]interface{}) error {
+	path := fmt.Sprintf("%s/issues/%d", c.projectPath(), iid)
+
+	log.Printf("[DEBUG] UpdateIssue: Updating issue #%d", iid)
+
+	resp, err := c.api.Do(ctx, http.MethodPut, path, updates)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// AddNote adds a comment to an issue
+func (c *Client) AddNote(ctx context.Context, iid int, body string) error {
+	path := fmt.Sprintf("%s/issues/%d/notes", c.projectPath(), iid)
+
+	log.Printf("[DEBUG] AddNote: Adding note to issue #%d", iid)
+
+	resp, err := c.api.Do(ctx, http.MethodPost, path, map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to add note: %w", err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// ListMilestones fetches all milestones (active and closed) in the project
+func (c *Client) ListMilestones(ctx context.Context) ([]glMilestone, error) {
+	var all []glMilestone
+	path := fmt.Sprintf("%s/milestones?per_page=%d", c.projectPath(), PageSize)
+
+	err := c.api.GetPaginated(ctx, path, 0, c.nextPage, func(r io.Reader) (int, error) {
+		var page []glMilestone
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode milestones response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	return all, nil
+}
+
+// ListMembers fetches all project members, including those inherited from parent groups
+func (c *Client) ListMembers(ctx context.Context) ([]glUser, error) {
+	var all []glUser
+	path := fmt.Sprintf("%s/members/all?per_page=%d", c.projectPath(), PageSize)
+
+	err := c.api.GetPaginated(ctx, path, 0, c.nextPage, func(r io.Reader) (int, error) {
+		var page []glUser
+		if err := json.NewDecoder(r).Decode(&page); err != nil {
+			return 0, fmt.Errorf("failed to decode members response: %w", err)
+		}
+		all = append(all, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	return all, nil
+}
@@ -0,0 +1,37 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadConfig loads and validates GitLab configuration from .takl/gitlab.json
+// in the specified project directory.
+func LoadConfig(projectPath string) (*GitLabConfig, error) {
+	configPath := filepath.Join(projectPath, ".takl", "gitlab.json")
+
+	// Check file permissions (should be 0600 to protect the token)
+	fi, statErr := os.Stat(configPath)
+	if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
+		return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", configPath, configPath)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gitlab config at %s: %w\nPlease create .takl/gitlab.json with your GitLab credentials", configPath, err)
+	}
+
+	var config GitLabConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse gitlab config: %w", err)
+	}
+
+	// Validate required fields
+	if config.Token == "" || config.Project == "" {
+		return nil, fmt.Errorf("gitlab config is incomplete: token and project are required")
+	}
+
+	return &config, nil
+}
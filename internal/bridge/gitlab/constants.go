@@ -0,0 +1,9 @@
+package gitlab
+
+const (
+	// PageSize is the number of items to fetch per API request (GitLab maximum)
+	PageSize = 100
+
+	// MaxIssues is the maximum total number of issues to fetch
+	MaxIssues = 1000
+)
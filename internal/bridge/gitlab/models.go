@@ -0,0 +1,75 @@
+package gitlab
+
+import "time"
+
+// glIssue represents an issue from the GitLab REST API v4
+//
+// Example response from GET /projects/:id/issues:
+//
+//	{
+//	  "id": 76,
+//	  "iid": 6,
+//	  "title": "Consequatur vero maxime deserunt",
+//	  "description": "Ratione dolores corrupti mollitia.",
+//	  "state": "opened",
+//	  "author": {"id": 1, "username": "root", "name": "Administrator"},
+//	  "assignees": [{"id": 2, "username": "jdoe", "name": "John Doe"}],
+//	  "labels": ["bug", "critical"],
+//	  "milestone": {"id": 5, "iid": 1, "title": "v1.0", "state": "active"},
+//	  "user_notes_count": 1,
+//	  "created_at": "2016-01-04T15:31:51.081Z",
+//	  "updated_at": "2016-01-04T15:31:51.081Z"
+//	}
+//
+// Note: description and milestone can be null. State is "opened" or "closed".
+type glIssue struct {
+	ID             int64        `json:"id"`
+	IID            int          `json:"iid"`
+	Title          string       `json:"title"`
+	Description    *string      `json:"description"`
+	State          string       `json:"state"`
+	Author         glUser       `json:"author"`
+	Assignees      []glUser     `json:"assignees"`
+	Labels         []string     `json:"labels"`
+	Milestone      *glMilestone `json:"milestone"`
+	UserNotesCount int          `json:"user_notes_count"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// glNote represents an issue note (comment)
+//
+// Example response from GET /projects/:id/issues/:iid/notes:
+//
+//	{
+//	  "id": 302,
+//	  "body": "closed",
+//	  "author": {"id": 1, "username": "pipin", "name": "Pip"},
+//	  "system": true,
+//	  "created_at": "2013-10-02T09:22:45Z",
+//	  "updated_at": "2013-10-02T10:22:45Z"
+//	}
+//
+// System notes record activity (state changes, label edits) and are not comments.
+type glNote struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	Author    glUser    `json:"author"`
+	System    bool      `json:"system"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type glUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	State    string `json:"state"`
+}
+
+type glMilestone struct {
+	ID    int    `json:"id"`
+	IID   int    `json:"iid"`
+	Title string `json:"title"`
+	State string `json:"state"`
+}
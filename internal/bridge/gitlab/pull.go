@@ -0,0 +1,192 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// issueKey returns the local key for a GitLab issue IID (e.g. GL-123)
+func issueKey(prefix string, iid int) string {
+	return fmt.Sprintf("%s-%d", prefix, iid)
+}
+
+// issueIID parses the GitLab issue IID from a local key
+func issueIID(prefix, key string) (int, error) {
+	numStr, ok := strings.CutPrefix(key, prefix+"-")
+	if !ok {
+		return 0, fmt.Errorf("issue key %q does not have prefix %q", key, prefix)
+	}
+	n, err := strconv.Atoi(numStr)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid issue number in key %q", key)
+	}
+	return n, nil
+}
+
+// convertIssue converts a GitLab issue and its notes to the shared issue model.
+// System notes are dropped; only user comments are kept.
+func convertIssue(prefix string, gi glIssue, notes []glNote) issue.Issue {
+	out := issue.Issue{
+		Key:      issueKey(prefix, gi.IID),
+		RemoteID: strconv.FormatInt(gi.ID, 10),
+		Title:    gi.Title,
+		Status:   gi.State,
+		Reporter: gi.Author.Username,
+		Created:  gi.CreatedAt,
+		Updated:  gi.UpdatedAt,
+	}
+
+	if gi.Description != nil {
+		// GitLab descriptions are already markdown; normalize CRLF from web edits
+		out.Description = strings.TrimSpace(strings.ReplaceAll(*gi.Description, "\r\n", "\n"))
+	}
+
+	out.Labels = append(out.Labels, gi.Labels...)
+	sort.Strings(out.Labels)
+
+	for _, a := range gi.Assignees {
+		out.Assignees = append(out.Assignees, a.Username)
+	}
+	sort.Strings(out.Assignees)
+
+	if gi.Milestone != nil {
+		out.Milestone = gi.Milestone.Title
+	}
+
+	out.Comments = make([]issue.Comment, 0, len(notes))
+	for _, n := range notes {
+		if n.System {
+			continue
+		}
+		out.Comments = append(out.Comments, issue.Comment{
+			ID:       strconv.FormatInt(n.ID, 10),
+			Author:   n.Author.Username,
+			AuthorID: strconv.FormatInt(n.Author.ID, 10),
+			Body:     strings.TrimSpace(strings.ReplaceAll(n.Body, "\r\n", "\n")),
+			Created:  n.CreatedAt,
+			Updated:  n.UpdatedAt,
+		})
+	}
+
+	return out
+}
+
+// fetchIssue fetches a single issue with its notes and converts it
+func fetchIssue(ctx context.Context, client *Client, prefix string, iid int) (*issue.Issue, error) {
+	gi, err := client.GetIssue(ctx, iid)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []glNote
+	if gi.UserNotesCount > 0 {
+		notes, err = client.ListNotes(ctx, iid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := convertIssue(prefix, *gi, notes)
+	return &out, nil
+}
+
+// Pull fetches issues from GitLab and saves them to local storage
+func Pull(ctx context.Context, client *Client, storage *issue.Storage, config *GitLabConfig) (*issue.PullResult, error) {
+	result := &issue.PullResult{
+		Errors: make([]string, 0),
+	}
+	prefix := config.Prefix()
+
+	glIssues, err := client.ListIssues(ctx, MaxIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	log.Printf("[DEBUG] Pull: Fetched %d issues from GitLab", len(glIssues))
+	result.Fetched = len(glIssues)
+
+	// Get list of existing local issues
+	localIssues, err := storage.ListIssues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local issues: %w", err)
+	}
+
+	localMap := make(map[string]bool)
+	for _, key := range localIssues {
+		localMap[key] = true
+	}
+
+	fetchedKeys := make(map[string]bool)
+	for _, gi := range glIssues {
+		fetchedKeys[issueKey(prefix, gi.IID)] = true
+	}
+
+	// Delete local issues that are no longer on GitLab (deleted or transferred).
+	// Only keys owned by this bridge are considered.
+	for _, localKey := range localIssues {
+		if _, err := issueIID(prefix, localKey); err != nil {
+			continue
+		}
+		if !fetchedKeys[localKey] {
+			log.Printf("[DEBUG] Pull: Deleting removed issue %s", localKey)
+			if err := storage.DeleteIssue(localKey); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to delete %s: %v", localKey, err))
+			} else {
+				result.Deleted++
+			}
+		}
+	}
+
+	for _, gi := range glIssues {
+		key := issueKey(prefix, gi.IID)
+		isNew := !localMap[key]
+
+		var notes []glNote
+		if gi.UserNotesCount > 0 {
+			notes, err = client.ListNotes(ctx, gi.IID)
+			if err != nil {
+				log.Printf("[ERROR] Pull: Failed to fetch notes for %s: %v", key, err)
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to fetch notes for %s: %v", key, err))
+				continue
+			}
+		}
+
+		converted := convertIssue(prefix, gi, notes)
+
+		// Skip unchanged issues
+		if !isNew {
+			newHash := storage.ComputeHash(&converted)
+			if oldHash, ok := storage.ReadExistingHash(key); ok && oldHash == newHash {
+				log.Printf("[DEBUG] Pull: Skipping %s (unchanged)", key)
+				continue
+			}
+		}
+
+		if err := storage.SaveIssue(&converted); err != nil {
+			log.Printf("[ERROR] Pull: Failed to save %s: %v", key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to save %s: %v", key, err))
+			continue
+		}
+
+		if isNew {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	log.Printf("[DEBUG] Pull: Complete - Created: %d, Updated: %d, Deleted: %d, Errors: %d", result.Created, result.Updated, result.Deleted, len(result.Errors))
+
+	// Return error if all issues failed to save
+	if len(result.Errors) > 0 && result.Created == 0 && result.Updated == 0 {
+		return result, fmt.Errorf("failed to save any issues (%d errors)", len(result.Errors))
+	}
+
+	return result, nil
+}
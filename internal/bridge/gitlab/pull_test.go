@@ -0,0 +1,395 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// fakeGitLab is an in-memory stand-in for the GitLab REST API v4 issue endpoints
+type fakeGitLab struct {
+	mu         sync.Mutex
+	t          *testing.T
+	issues     map[int]*glIssue
+	notes      map[int][]glNote
+	milestones []glMilestone
+	members    []glUser
+	pageSize   int
+	linkHeader bool // Send Link headers in addition to X-Next-Page
+	keyset     bool // Honor pagination=keyset, else fall back to offset pagination
+	keysetUsed bool // A page was served with keyset pagination
+	nextID     int64
+	updates    []map[string]interface{}
+	server     *httptest.Server
+}
+
+func newFakeGitLab(t *testing.T) *fakeGitLab {
+	t.Helper()
+	f := &fakeGitLab{
+		t:          t,
+		issues:     make(map[int]*glIssue),
+		notes:      make(map[int][]glNote),
+		pageSize:   2,
+		linkHeader: true,
+		keyset:     true,
+		nextID:     1000,
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGitLab) client() *Client {
+	return NewClient(f.server.URL+"/api/v4", "test-token", "grp/proj")
+}
+
+func (f *fakeGitLab) addIssue(iid int, title string, mutate func(*glIssue)) {
+	description := "Description of " + title
+	gi := &glIssue{
+		ID:          int64(iid * 10),
+		IID:         iid,
+		Title:       title,
+		Description: &description,
+		State:       StateOpened,
+		Author:      glUser{ID: 1, Username: "root"},
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 1, iid, 0, 0, 0, 0, time.UTC),
+	}
+	if mutate != nil {
+		mutate(gi)
+	}
+	f.issues[iid] = gi
+}
+
+func (f *fakeGitLab) addNote(iid int, author, body string, system bool) {
+	f.nextID++
+	f.notes[iid] = append(f.notes[iid], glNote{
+		ID:        f.nextID,
+		Body:      body,
+		Author:    glUser{Username: author},
+		System:    system,
+		CreatedAt: time.Date(2025, 2, len(f.notes[iid])+1, 0, 0, 0, 0, time.UTC),
+	})
+	if !system {
+		f.issues[iid].UserNotesCount++
+	}
+}
+
+func (f *fakeGitLab) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("PRIVATE-TOKEN") != "test-token" {
+		http.Error(w, `{"message":"401 Unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	// Namespaced project paths must arrive URL-encoded as one segment
+	path, ok := strings.CutPrefix(r.URL.EscapedPath(), "/api/v4/projects/grp%2Fproj/")
+	if !ok {
+		http.Error(w, `{"message":"404 Project Not Found"}`, http.StatusNotFound)
+		return
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "issues" && r.Method == http.MethodGet:
+		iids := make([]int, 0, len(f.issues))
+		for n := range f.issues {
+			iids = append(iids, n)
+		}
+		sort.Ints(iids)
+		if r.URL.Query().Get("sort") == "desc" {
+			slices.Reverse(iids)
+		}
+		list := make([]*glIssue, 0, len(iids))
+		for _, n := range iids {
+			list = append(list, f.issues[n])
+		}
+		writePage(f, w, r, list)
+
+	case len(parts) == 1 && parts[0] == "milestones":
+		writePage(f, w, r, f.milestones)
+
+	case len(parts) == 2 && parts[0] == "members" && parts[1] == "all":
+		writePage(f, w, r, f.members)
+
+	case len(parts) >= 2 && parts[0] == "issues":
+		n, _ := strconv.Atoi(parts[1])
+		gi, ok := f.issues[n]
+		if !ok {
+			http.Error(w, `{"message":"404 Not found"}`, http.StatusNotFound)
+			return
+		}
+		if len(parts) == 3 && parts[2] == "notes" {
+			f.handleNotes(w, r, n)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			f.writeJSON(w, gi)
+		case http.MethodPut:
+			f.applyUpdate(w, r, gi)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+
+	default:
+		http.Error(w, `{"message":"404 Not found"}`, http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitLab) handleNotes(w http.ResponseWriter, r *http.Request, iid int) {
+	switch r.Method {
+	case http.MethodGet:
+		writePage(f, w, r, f.notes[iid])
+	case http.MethodPost:
+		var req struct {
+			Body string `json:"body"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f.addNote(iid, "root", req.Body, false)
+		w.WriteHeader(http.StatusCreated)
+		f.writeJSON(w, f.notes[iid][len(f.notes[iid])-1])
+	}
+}
+
+func (f *fakeGitLab) applyUpdate(w http.ResponseWriter, r *http.Request, gi *glIssue) {
+	var update map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	f.updates = append(f.updates, update)
+
+	if v, ok := update["title"].(string); ok {
+		gi.Title = v
+	}
+	if v, ok := update["description"].(string); ok {
+		gi.Description = &v
+	}
+	switch update["state_event"] {
+	case "close":
+		gi.State = StateClosed
+		f.addNote(gi.IID, "root", "closed", true)
+	case "reopen":
+		gi.State = StateOpened
+		f.addNote(gi.IID, "root", "reopened", true)
+	}
+	if v, ok := update["labels"].(string); ok {
+		gi.Labels = nil
+		if v != "" {
+			gi.Labels = strings.Split(v, ",")
+		}
+	}
+	if v, ok := update["assignee_ids"].([]interface{}); ok {
+		gi.Assignees = nil
+		for _, id := range v {
+			for _, m := range f.members {
+				if m.ID == int64(id.(float64)) {
+					gi.Assignees = append(gi.Assignees, m)
+				}
+			}
+		}
+	}
+	if v, ok := update["milestone_id"].(float64); ok {
+		gi.Milestone = nil
+		for _, m := range f.milestones {
+			if m.ID == int(v) {
+				m := m
+				gi.Milestone = &m
+			}
+		}
+	}
+	gi.UpdatedAt = gi.UpdatedAt.Add(time.Hour)
+	f.writeJSON(w, gi)
+}
+
+// writePage writes one page of a list using page/per_page query params, the
+// X-Next-Page header, and optionally a Link header
+func writePage[T any](f *fakeGitLab, w http.ResponseWriter, r *http.Request, items []T) {
+	if f.keyset && r.URL.Query().Get("pagination") == "keyset" {
+		writeKeysetPage(f, w, r, items)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * f.pageSize
+	end := start + f.pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+	w.Header().Set("X-Next-Page", "")
+	if end < len(items) {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		if f.linkHeader {
+			q := r.URL.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next := fmt.Sprintf("%s%s?%s", f.server.URL, r.URL.EscapedPath(), q.Encode())
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+		}
+	}
+	f.writeJSON(w, items[start:end])
+}
+
+// writeKeysetPage writes the page after the cursor. Keyset pages link to the
+// next one only through the Link header, with an opaque cursor.
+func writeKeysetPage[T any](f *fakeGitLab, w http.ResponseWriter, r *http.Request, items []T) {
+	f.keysetUsed = true
+	start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	start = min(start, len(items))
+	end := min(start+f.pageSize, len(items))
+	if end < len(items) {
+		q := r.URL.Query()
+		q.Set("cursor", strconv.Itoa(end))
+		next := fmt.Sprintf("%s%s?%s", f.server.URL, r.URL.EscapedPath(), q.Encode())
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+	}
+	f.writeJSON(w, items[start:end])
+}
+
+func (f *fakeGitLab) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("failed to encode fake response: %v", err)
+	}
+}
+
+// TestListIssues_Pagination tests that every page is fetched with keyset
+// pagination, and with offset pagination whether the server sends Link
+// headers or only X-Next-Page
+func TestListIssues_Pagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyset     bool
+		linkHeader bool
+	}{
+		{"keyset", true, true},
+		{"offset link header", false, true},
+		{"offset x-next-page only", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGitLab(t)
+			f.keyset = tt.keyset
+			f.linkHeader = tt.linkHeader
+			for i := 1; i <= 5; i++ {
+				f.addIssue(i, fmt.Sprintf("Issue %d", i), nil)
+			}
+
+			issues, err := f.client().ListIssues(context.Background(), 0)
+			if err != nil {
+				t.Fatalf("ListIssues failed: %v", err)
+			}
+			if len(issues) != 5 || issues[0].IID != 5 || issues[4].IID != 1 {
+				t.Errorf("Expected 5 issues newest first, got %d", len(issues))
+			}
+			if f.keysetUsed != tt.keyset {
+				t.Errorf("Expected keyset pagination used: %v", tt.keyset)
+			}
+		})
+	}
+}
+
+// TestPull_MapsFields tests that pull maps GitLab fields into the markdown
+// model and drops system notes
+func TestPull_MapsFields(t *testing.T) {
+	f := newFakeGitLab(t)
+	f.addIssue(1, "First", func(gi *glIssue) {
+		gi.Labels = []string{"bug", "api"}
+		gi.Assignees = []glUser{{ID: 3, Username: "zoe"}, {ID: 2, Username: "adam"}}
+		gi.Milestone = &glMilestone{ID: 7, Title: "v1.0"}
+		gi.State = StateClosed
+	})
+	f.addNote(1, "reviewer", "Comment 1", false)
+	f.addNote(1, "root", "added ~bug label", true)
+	f.addNote(1, "reviewer", "Comment 2", false)
+	f.addNote(1, "reviewer", "Comment 3", false)
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &GitLabConfig{Project: "grp/proj"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("Expected 1 created issue, got %d", result.Created)
+	}
+
+	got, err := storage.ReadIssue("GL-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if got.Status != StateClosed {
+		t.Errorf("Expected status %q, got %q", StateClosed, got.Status)
+	}
+	if strings.Join(got.Labels, ",") != "api,bug" {
+		t.Errorf("Expected labels [api bug], got %v", got.Labels)
+	}
+	if strings.Join(got.Assignees, ",") != "adam,zoe" {
+		t.Errorf("Expected assignees [adam zoe], got %v", got.Assignees)
+	}
+	if got.Milestone != "v1.0" {
+		t.Errorf("Expected milestone v1.0, got %q", got.Milestone)
+	}
+	if len(got.Comments) != 3 {
+		t.Fatalf("Expected 3 comments (system note dropped), got %d", len(got.Comments))
+	}
+	if got.Comments[2].Body != "Comment 3" {
+		t.Errorf("Expected last comment body %q, got %q", "Comment 3", got.Comments[2].Body)
+	}
+}
+
+// TestPull_DeletesOnlyOwnKeys tests that pull removes vanished GitLab issues
+// but leaves issues from other bridges alone
+func TestPull_DeletesOnlyOwnKeys(t *testing.T) {
+	f := newFakeGitLab(t)
+	f.addIssue(1, "Keep", nil)
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	for _, key := range []string{"GL-2", "PROJ-7"} {
+		if err := storage.SaveIssue(&issue.Issue{Key: key, Title: key}); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &GitLabConfig{Project: "grp/proj"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Deleted != 1 {
+		t.Errorf("Expected 1 deleted issue, got %d", result.Deleted)
+	}
+
+	keys, _ := storage.ListIssues()
+	if strings.Join(keys, ",") != "GL-1,PROJ-7" {
+		t.Errorf("Expected keys [GL-1 PROJ-7], got %v", keys)
+	}
+}
@@ -0,0 +1,150 @@
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// pullOne pulls the fake project into fresh storage and returns the storage
+func pullOne(t *testing.T, f *fakeGitLab) *issue.Storage {
+	t.Helper()
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	if _, err := Pull(context.Background(), f.client(), storage, &GitLabConfig{Project: "grp/proj"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	return storage
+}
+
+// TestPush_UpdatesFields tests that local edits are sent as a single PUT,
+// usernames are resolved to IDs, and new comments are posted as notes
+func TestPush_UpdatesFields(t *testing.T) {
+	f := newFakeGitLab(t)
+	f.milestones = []glMilestone{{ID: 11, Title: "v1.0"}, {ID: 12, Title: "v2.0"}}
+	f.members = []glUser{{ID: 1, Username: "root"}, {ID: 2, Username: "adam"}, {ID: 3, Username: "zoe"}}
+	f.addIssue(1, "First", func(gi *glIssue) {
+		gi.Labels = []string{"bug"}
+		gi.Milestone = &glMilestone{ID: 11, Title: "v1.0"}
+	})
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("GL-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Title = "Renamed"
+	edited.Status = StateClosed
+	edited.Labels = []string{"bug", "wontfix"}
+	edited.Assignees = []string{"zoe", "adam"}
+	edited.Milestone = "v2.0"
+	edited.Comments = append(edited.Comments, issue.Comment{Author: "me", Body: "Closing this"})
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &GitLabConfig{Project: "grp/proj"}, "")
+	if err != nil {
+		t.Fatalf("Push failed: %v (errors: %v)", err, result.Errors)
+	}
+	if result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %d (errors: %v)", result.Pushed, result.Errors)
+	}
+
+	if len(f.updates) != 1 {
+		t.Fatalf("Expected 1 PUT request, got %d", len(f.updates))
+	}
+	update := f.updates[0]
+	if update["title"] != "Renamed" || update["state_event"] != "close" {
+		t.Errorf("Unexpected update: %v", update)
+	}
+	if update["labels"] != "bug,wontfix" {
+		t.Errorf("Expected labels %q, got %v", "bug,wontfix", update["labels"])
+	}
+	ids, _ := update["assignee_ids"].([]interface{})
+	if len(ids) != 2 || ids[0] != float64(2) || ids[1] != float64(3) {
+		t.Errorf("Expected assignee_ids [2 3], got %v", update["assignee_ids"])
+	}
+	if update["milestone_id"] != float64(12) {
+		t.Errorf("Expected milestone_id 12, got %v", update["milestone_id"])
+	}
+	if _, ok := update["description"]; ok {
+		t.Errorf("Unchanged description should not be sent")
+	}
+
+	// The close adds a system note, which must not show up as a comment
+	refreshed, err := storage.ReadIssue("GL-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if len(refreshed.Comments) != 1 || refreshed.Comments[0].Body != "Closing this" {
+		t.Errorf("Expected only the posted comment, got %v", refreshed.Comments)
+	}
+	if storage.ComputeHash(refreshed) != refreshed.Hash {
+		t.Errorf("Expected refreshed file to have no pending local changes")
+	}
+}
+
+// TestPush_Conflict tests that remote edits since the last pull block the push
+func TestPush_Conflict(t *testing.T) {
+	f := newFakeGitLab(t)
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("GL-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Title = "Local title"
+	writeLocalEdit(t, storage, edited)
+
+	// Remote changes after pull
+	f.issues[1].Title = "Remote title"
+
+	result, err := Push(context.Background(), f.client(), storage, &GitLabConfig{Project: "grp/proj"}, "")
+	if err == nil {
+		t.Fatalf("Expected conflict error")
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].IssueKey != "GL-1" {
+		t.Errorf("Expected conflict for GL-1, got %v", result.Conflicts)
+	}
+	if len(f.updates) != 0 {
+		t.Errorf("Expected no PUT requests on conflict, got %d", len(f.updates))
+	}
+}
+
+// TestPush_UnknownAssignee tests that assignees who are not project members are rejected
+func TestPush_UnknownAssignee(t *testing.T) {
+	f := newFakeGitLab(t)
+	f.members = []glUser{{ID: 1, Username: "root"}}
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("GL-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Assignees = []string{"stranger"}
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &GitLabConfig{Project: "grp/proj"}, "GL-1")
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Expected 1 error for unknown assignee, got %v", result.Errors)
+	}
+	if len(f.updates) != 0 {
+		t.Errorf("Expected no PUT requests, got %d", len(f.updates))
+	}
+}
+
+// writeLocalEdit saves an edited issue while keeping its base hash, the way
+// a hand edit of the markdown file would
+func writeLocalEdit(t *testing.T, storage *issue.Storage, edited *issue.Issue) {
+	t.Helper()
+	if err := storage.WriteIssue(edited); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBaseURL is the GitLab instance used when base_url is not configured
+const DefaultBaseURL = "https://gitlab.com"
+
+// DefaultKeyPrefix is the issue key prefix used when key_prefix is not configured
+const DefaultKeyPrefix = "GL"
+
+// GitLabConfig holds GitLab connection configuration
+type GitLabConfig struct {
+	BaseURL   string `yaml:"base_url,omitempty" json:"base_url,omitempty"`     // Instance URL (self-hosted: https://gitlab.example.com)
+	Token     string `yaml:"token" json:"token"`                               // Personal or project access token (api scope)
+	Project   string `yaml:"project" json:"project"`                           // Project path (group/project) or numeric ID
+	KeyPrefix string `yaml:"key_prefix,omitempty" json:"key_prefix,omitempty"` // Local issue key prefix (e.g. "GL" → GL-123)
+}
+
+// String returns a sanitized string representation (hides token)
+func (c GitLabConfig) String() string {
+	token := "***REDACTED***"
+	if len(c.Token) > 4 {
+		token = c.Token[:4] + "***"
+	}
+	return fmt.Sprintf("GitLabConfig{BaseURL: %s, Project: %s, Token: %s, KeyPrefix: %s}",
+		c.BaseURL, c.Project, token, c.KeyPrefix)
+}
+
+// APIBaseURL returns the REST API v4 URL of the configured instance
+func (c GitLabConfig) APIBaseURL() string {
+	base := DefaultBaseURL
+	if c.BaseURL != "" {
+		base = strings.TrimRight(c.BaseURL, "/")
+	}
+	return base + "/api/v4"
+}
+
+// Prefix returns the configured key prefix or the default
+func (c GitLabConfig) Prefix() string {
+	if c.KeyPrefix != "" {
+		return c.KeyPrefix
+	}
+	return DefaultKeyPrefix
+}
@@ -0,0 +1,282 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Sprint states reported by the Agile API
+const (
+	SprintActive = "active"
+	SprintFuture = "future"
+	SprintClosed = "closed"
+)
+
+// ErrNoBoard is returned when no scrum board is configured or found for the project
+var ErrNoBoard = errors.New("no scrum board found; set board_id in .takl/jira.json")
+
+// ErrNoSprint is returned when a sprint cannot be resolved on the board
+var ErrNoSprint = errors.New("sprint not found")
+
+// Board is an Agile board
+type Board struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // scrum, kanban or simple
+}
+
+// Sprint is a sprint of an Agile board
+type Sprint struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	State     string    `json:"state"` // active, future or closed
+	StartDate time.Time `json:"startDate,omitempty"`
+	EndDate   time.Time `json:"endDate,omitempty"`
+	Goal      string    `json:"goal,omitempty"`
+}
+
+// agilePage is a page of boards or sprints; the Agile API pages with startAt and isLast
+type agilePage[T any] struct {
+	Values []T  `json:"values"`
+	IsLast bool `json:"isLast"`
+}
+
+// agilePath returns the Agile API path; it is versioned separately from the
+// REST API and is the same on Cloud and Data Center/Server
+func (c *Client) agilePath(path string) string {
+	return "/rest/agile/1.0" + path
+}
+
+// getAgile fetches an Agile API resource and decodes it into out
+func (c *Client) getAgile(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.doRequest(ctx, "GET", c.agilePath(path), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// ListBoards fetches the boards of a project, optionally of one type (e.g. "scrum")
+func (c *Client) ListBoards(ctx context.Context, projectKey, boardType string) ([]Board, error) {
+	var all []Board
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("projectKeyOrId", projectKey)
+		if boardType != "" {
+			params.Set("type", boardType)
+		}
+		params.Set("startAt", fmt.Sprint(startAt))
+		params.Set("maxResults", fmt.Sprint(AgilePageSize))
+
+		var page agilePage[Board]
+		if err := c.getAgile(ctx, "/board?"+params.Encode(), &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch boards: %w", err)
+		}
+		all = append(all, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] ListBoards: Fetched %d boards for %s", len(all), projectKey)
+	return all, nil
+}
+
+// ListSprints fetches the sprints of a board in board order. states is a
+// comma-separated list of sprint states (empty for all).
+func (c *Client) ListSprints(ctx context.Context, boardID int, states string) ([]Sprint, error) {
+	var all []Sprint
+	for startAt := 0; ; {
+		params := url.Values{}
+		if states != "" {
+			params.Set("state", states)
+		}
+		params.Set("startAt", fmt.Sprint(startAt))
+		params.Set("maxResults", fmt.Sprint(AgilePageSize))
+
+		var page agilePage[Sprint]
+		if err := c.getAgile(ctx, fmt.Sprintf("/board/%d/sprint?%s", boardID, params.Encode()), &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch sprints: %w", err)
+		}
+		all = append(all, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] ListSprints: Fetched %d sprints of board %d", len(all), boardID)
+	return all, nil
+}
+
+// SprintIssueKeys fetches the keys of all issues in a sprint
+func (c *Client) SprintIssueKeys(ctx context.Context, sprintID int) ([]string, error) {
+	var keys []string
+	for startAt := 0; ; {
+		path := fmt.Sprintf("/sprint/%d/issue?fields=summary&startAt=%d&maxResults=%d", sprintID, startAt, AgilePageSize)
+
+		var page struct {
+			Issues []struct {
+				Key string `json:"key"`
+			} `json:"issues"`
+			Total int `json:"total"`
+		}
+		if err := c.getAgile(ctx, path, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch sprint issues: %w", err)
+		}
+		for _, i := range page.Issues {
+			keys = append(keys, i.Key)
+		}
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] SprintIssueKeys: Fetched %d issues of sprint %d", len(keys), sprintID)
+	return keys, nil
+}
+
+// MoveIssuesToSprint moves issues to a sprint, removing them from their current one
+func (c *Client) MoveIssuesToSprint(ctx context.Context, sprintID int, keys []string) error {
+	log.Printf("[DEBUG] MoveIssuesToSprint: Moving %s to sprint %d", strings.Join(keys, ", "), sprintID)
+	return c.postIssueBatches(ctx, c.agilePath(fmt.Sprintf("/sprint/%d/issue", sprintID)), keys)
+}
+
+// MoveIssuesToBacklog moves issues out of their sprint to the backlog
+func (c *Client) MoveIssuesToBacklog(ctx context.Context, keys []string) error {
+	log.Printf("[DEBUG] MoveIssuesToBacklog: Moving %s to the backlog", strings.Join(keys, ", "))
+	return c.postIssueBatches(ctx, c.agilePath("/backlog/issue"), keys)
+}
+
+// postIssueBatches posts {"issues": [...]} in batches of MaxAgileIssues
+func (c *Client) postIssueBatches(ctx context.Context, path string, keys []string) error {
+	for start := 0; start < len(keys); start += MaxAgileIssues {
+		batch := keys[start:min(start+MaxAgileIssues, len(keys))]
+		resp, err := c.doRequest(ctx, "POST", path, map[string]interface{}{"issues": batch})
+		if err != nil {
+			return fmt.Errorf("failed to move issues: %w", err)
+		}
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// RankIssues ranks issues, in the given order, before or after another issue
+// on the board. Exactly one of before and after must be set.
+func (c *Client) RankIssues(ctx context.Context, keys []string, before, after string) error {
+	if (before == "") == (after == "") {
+		return errors.New("rank needs either an issue to rank before or one to rank after")
+	}
+
+	log.Printf("[DEBUG] RankIssues: Ranking %s", strings.Join(keys, ", "))
+
+	for start := 0; start < len(keys); start += MaxAgileIssues {
+		batch := keys[start:min(start+MaxAgileIssues, len(keys))]
+		body := map[string]interface{}{"issues": batch}
+		if before != "" {
+			body["rankBeforeIssue"] = before
+		} else {
+			body["rankAfterIssue"] = after
+			// Later batches follow this one
+			after = batch[len(batch)-1]
+		}
+
+		resp, err := c.doRequest(ctx, "PUT", c.agilePath("/issue/rank"), body)
+		if err != nil {
+			return fmt.Errorf("failed to rank issues: %w", err)
+		}
+
+		// A partial failure is reported per issue with 207 Multi-Status
+		if resp.StatusCode == http.StatusMultiStatus {
+			var result struct {
+				Entries []struct {
+					IssueKey string   `json:"issueKey"`
+					Status   int      `json:"status"`
+					Errors   []string `json:"errors"`
+				} `json:"entries"`
+			}
+			err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&result)
+			resp.Body.Close()
+			if err != nil {
+				return fmt.Errorf("failed to decode rank response: %w", err)
+			}
+			var failed []string
+			for _, e := range result.Entries {
+				if e.Status >= 400 {
+					failed = append(failed, fmt.Sprintf("%s: %s", e.IssueKey, strings.Join(e.Errors, "; ")))
+				}
+			}
+			if len(failed) > 0 {
+				return fmt.Errorf("failed to rank %s", strings.Join(failed, ", "))
+			}
+			continue
+		}
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// FetchRank fetches the rank of an issue
+func (c *Client) FetchRank(ctx context.Context, issueKey string) (string, error) {
+	path := c.apiPath(fmt.Sprintf("/issue/%s?fields=%s", url.QueryEscape(issueKey), url.QueryEscape(c.rankField)))
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch rank: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var jr struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&jr); err != nil {
+		return "", fmt.Errorf("failed to decode issue response: %w", err)
+	}
+	return parseRank(jr.Fields[c.rankField]), nil
+}
+
+// resolveSprint finds a sprint among the board's active and future sprints:
+// "active" (or "current") is the first active sprint, "next" the first future
+// one, and anything else a sprint name (case-insensitive) or ID.
+func resolveSprint(sprints []Sprint, target string) (*Sprint, error) {
+	target = strings.TrimSpace(target)
+	for i := range sprints {
+		s := &sprints[i]
+		switch strings.ToLower(target) {
+		case "active", "current":
+			if s.State == SprintActive {
+				return s, nil
+			}
+		case "next":
+			if s.State == SprintFuture {
+				return s, nil
+			}
+		default:
+			if strings.EqualFold(s.Name, target) || fmt.Sprint(s.ID) == target {
+				return s, nil
+			}
+		}
+	}
+	switch strings.ToLower(target) {
+	case "active", "current":
+		return nil, fmt.Errorf("%w: the board has no active sprint", ErrNoSprint)
+	case "next":
+		return nil, fmt.Errorf("%w: the board has no future sprint", ErrNoSprint)
+	}
+	return nil, fmt.Errorf("%w: %q is not an active or future sprint of the board", ErrNoSprint, target)
+}
@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// newSprintFixture returns a fake with an active sprint holding PROJ-1 and
+// PROJ-2, two future sprints, and a storage pulled from it
+func newSprintFixture(t *testing.T) (*fakeJira, *issue.Storage, *JiraConfig) {
+	t.Helper()
+	f := newFakeJira(t)
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3"} {
+		f.issues[key] = &fakeIssue{summary: "Issue " + key}
+	}
+	f.sprints = []*fakeSprint{
+		{Sprint: Sprint{ID: 11, Name: "Sprint 11", State: SprintClosed}},
+		{Sprint: Sprint{ID: 12, Name: "Sprint 12", State: SprintActive}, keys: []string{"PROJ-1", "PROJ-2"}},
+		{Sprint: Sprint{ID: 13, Name: "Sprint 13", State: SprintFuture}},
+		{Sprint: Sprint{ID: 14, Name: "Sprint 14", State: SprintFuture}},
+	}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ", BoardID: 7}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	return f, storage, config
+}
+
+// sprintOf reads the sprint recorded in an issue file
+func sprintOf(t *testing.T, storage *issue.Storage, key string) string {
+	t.Helper()
+	i, err := storage.ReadIssue(key)
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	return i.Sprint
+}
+
+// TestResolveSprint tests sprint targets against a board's open sprints
+func TestResolveSprint(t *testing.T) {
+	sprints := []Sprint{
+		{ID: 12, Name: "Sprint 12", State: SprintActive},
+		{ID: 13, Name: "Sprint 13", State: SprintFuture},
+		{ID: 14, Name: "Sprint 14", State: SprintFuture},
+	}
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"active", 12},
+		{"current", 12},
+		{"next", 13},
+		{"sprint 14", 14},
+		{"14", 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got, err := resolveSprint(sprints, tt.target)
+			if err != nil {
+				t.Fatalf("resolveSprint failed: %v", err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("Expected sprint %d, got %d", tt.want, got.ID)
+			}
+		})
+	}
+
+	if _, err := resolveSprint(sprints[:1], "next"); !errors.Is(err, ErrNoSprint) {
+		t.Errorf("Expected ErrNoSprint without a future sprint, got %v", err)
+	}
+	if _, err := resolveSprint(sprints, "Sprint 9"); !errors.Is(err, ErrNoSprint) {
+		t.Errorf("Expected ErrNoSprint for an unknown sprint, got %v", err)
+	}
+}
+
+// TestPull_BoardSprints tests that pull records sprints from the board, and
+// records sprint moves although the sprint is not part of the hash
+func TestPull_BoardSprints(t *testing.T) {
+	f, storage, config := newSprintFixture(t)
+
+	if got := sprintOf(t, storage, "PROJ-1"); got != "Sprint 12" {
+		t.Errorf("Expected PROJ-1 in Sprint 12, got %q", got)
+	}
+	if got := sprintOf(t, storage, "PROJ-3"); got != "" {
+		t.Errorf("Expected PROJ-3 in the backlog, got %q", got)
+	}
+
+	f.sprints[1].keys = []string{"PROJ-1"}
+	f.sprints[2].keys = []string{"PROJ-2"}
+	result, err := Pull(context.Background(), f.client(), storage, config)
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Expected 1 updated issue, got %d", result.Updated)
+	}
+	if got := sprintOf(t, storage, "PROJ-2"); got != "Sprint 13" {
+		t.Errorf("Expected PROJ-2 in Sprint 13, got %q", got)
+	}
+}
+
+// TestBridge_MoveToSprint tests moving issues to the next sprint and the
+// backlog, and that the move is not mistaken for a local edit
+func TestBridge_MoveToSprint(t *testing.T) {
+	f, storage, config := newSprintFixture(t)
+	bridge := &Bridge{client: f.client(), config: config}
+
+	sprint, err := bridge.MoveToSprint(context.Background(), storage, []string{"PROJ-1", "PROJ-3"}, "next")
+	if err != nil {
+		t.Fatalf("MoveToSprint failed: %v", err)
+	}
+	if sprint.Name != "Sprint 13" {
+		t.Errorf("Expected Sprint 13, got %q", sprint.Name)
+	}
+	if got := sprintOf(t, storage, "PROJ-3"); got != "Sprint 13" {
+		t.Errorf("Expected PROJ-3 in Sprint 13, got %q", got)
+	}
+
+	sprint, err = bridge.MoveToSprint(context.Background(), storage, []string{"PROJ-2"}, "backlog")
+	if err != nil {
+		t.Fatalf("MoveToSprint failed: %v", err)
+	}
+	if sprint != nil {
+		t.Errorf("Expected no sprint for the backlog, got %q", sprint.Name)
+	}
+	if got := sprintOf(t, storage, "PROJ-2"); got != "" {
+		t.Errorf("Expected PROJ-2 in the backlog, got %q", got)
+	}
+
+	want := "move PROJ-1 to 13,move PROJ-3 to 13,move PROJ-2 to backlog"
+	if got := strings.Join(f.agileCalls, ","); got != want {
+		t.Errorf("Expected calls %q, got %q", want, got)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "")
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if result.Pushed != 0 {
+		t.Errorf("Expected nothing to push after a sprint move, got %d", result.Pushed)
+	}
+
+	if _, err := bridge.MoveToSprint(context.Background(), storage, []string{"PROJ-1"}, "Sprint 11"); !errors.Is(err, ErrNoSprint) {
+		t.Errorf("Expected ErrNoSprint for a closed sprint, got %v", err)
+	}
+}
+
+// TestBridge_Rank tests ranking issues after another and recording their rank
+func TestBridge_Rank(t *testing.T) {
+	f, storage, config := newSprintFixture(t)
+	bridge := &Bridge{client: f.client(), config: config}
+
+	ranked, err := bridge.Rank(context.Background(), storage, []string{"PROJ-3", "PROJ-2"}, "", "PROJ-1")
+	if err != nil {
+		t.Fatalf("Rank failed: %v", err)
+	}
+	if len(ranked) != 2 {
+		t.Fatalf("Expected 2 ranked issues, got %d", len(ranked))
+	}
+
+	want := "rank PROJ-3 after PROJ-1,rank PROJ-2 after PROJ-1"
+	if got := strings.Join(f.agileCalls, ","); got != want {
+		t.Errorf("Expected calls %q, got %q", want, got)
+	}
+
+	local, err := storage.ReadIssue("PROJ-2")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if local.Rank != "0|r1:" {
+		t.Errorf("Expected rank 0|r1:, got %q", local.Rank)
+	}
+
+	if _, err := bridge.Rank(context.Background(), storage, []string{"PROJ-2"}, "PROJ-1", "PROJ-3"); err == nil {
+		t.Errorf("Expected error when ranking both before and after")
+	}
+}
@@ -0,0 +1,83 @@
+package jira
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+)
+
+// Authentication modes for JiraConfig.Auth
+const (
+	AuthBasic = "basic" // Email and API token (Jira Cloud)
+	AuthPAT   = "pat"   // Personal access token sent as a Bearer token (Data Center/Server)
+	AuthOAuth = "oauth" // OAuth 2.0 (3LO) with refresh tokens, set up by 'takl jira login'
+)
+
+// Authenticator adds credentials to outgoing Jira requests
+type Authenticator interface {
+	Authorize(ctx context.Context, req *http.Request) error
+}
+
+// BasicAuth authenticates with an Atlassian account email and API token
+type BasicAuth struct {
+	Email    string
+	APIToken string
+}
+
+// Authorize implements Authenticator
+func (a *BasicAuth) Authorize(ctx context.Context, req *http.Request) error {
+	auth := base64.StdEncoding.EncodeToString([]byte(a.Email + ":" + a.APIToken))
+	req.Header.Set("Authorization", "Basic "+auth)
+	return nil
+}
+
+// BearerAuth authenticates with a personal access token
+type BearerAuth struct {
+	Token string
+}
+
+// Authorize implements Authenticator
+func (a *BearerAuth) Authorize(ctx context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// failedAuth fails every request with the error met while setting up credentials,
+// so a missing OAuth login surfaces on the first request instead of at startup
+type failedAuth struct {
+	err error
+}
+
+// Authorize implements Authenticator
+func (a failedAuth) Authorize(ctx context.Context, req *http.Request) error {
+	return a.err
+}
+
+// newClientFromConfig creates a client using the config's authentication mode and API version
+func newClientFromConfig(config *JiraConfig) *Client {
+	var client *Client
+	switch config.AuthMode() {
+	case AuthPAT:
+		client = NewClientWithAuth(config.BaseURL, &BearerAuth{Token: config.Token})
+	case AuthOAuth:
+		token, err := LoadOAuthToken(config.BaseURL)
+		if err != nil {
+			client = NewClientWithAuth(config.BaseURL, failedAuth{err: err})
+		} else {
+			client = NewClientWithAuth(oauthEndpoints.Gateway+token.CloudID, newOAuthAuth(config.OAuth, token))
+		}
+	default:
+		client = NewClient(config.BaseURL, config.Email, config.APIToken)
+	}
+	client.apiVersion = config.RESTVersion()
+	if config.SprintField != "" {
+		client.sprintField = config.SprintField
+	}
+	if config.StoryPointsField != "" {
+		client.storyPointsField = config.StoryPointsField
+	}
+	if config.RankField != "" {
+		client.rankField = config.RankField
+	}
+	return client
+}
@@ -0,0 +1,354 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// headerServer records the Authorization header of every request
+type headerServer struct {
+	mu      sync.Mutex
+	headers []string
+	server  *httptest.Server
+}
+
+func newHeaderServer(t *testing.T) *headerServer {
+	t.Helper()
+	s := &headerServer{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.headers = append(s.headers, r.Header.Get("Authorization"))
+		s.mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// fakeAtlassian serves the OAuth token and accessible-resources endpoints
+type fakeAtlassian struct {
+	mu     sync.Mutex
+	grants []map[string]string
+	issued int
+	server *httptest.Server
+}
+
+func newFakeAtlassian(t *testing.T) *fakeAtlassian {
+	t.Helper()
+	f := &fakeAtlassian{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.URL.Path {
+		case "/oauth/token":
+			var grant map[string]string
+			json.NewDecoder(r.Body).Decode(&grant)
+			f.grants = append(f.grants, grant)
+			f.issued++
+			n := string(rune('0' + f.issued))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  "access-" + n,
+				"refresh_token": "refresh-" + n,
+				"expires_in":    3600,
+			})
+		case "/resources":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"id": "other-cloud", "url": "https://other.atlassian.net"},
+				{"id": "cloud-123", "url": "https://example.atlassian.net"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(f.server.Close)
+
+	saved := oauthEndpoints
+	oauthEndpoints.Authorize = f.server.URL + "/authorize"
+	oauthEndpoints.Token = f.server.URL + "/oauth/token"
+	oauthEndpoints.Resources = f.server.URL + "/resources"
+	t.Cleanup(func() { oauthEndpoints = saved })
+
+	// Keep tokens out of the real state directory
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	return f
+}
+
+var oauthTestConfig = &JiraConfig{
+	Auth:    AuthOAuth,
+	BaseURL: "https://example.atlassian.net",
+	Project: "PROJ",
+	OAuth:   &OAuthConfig{ClientID: "client", ClientSecret: "secret"},
+}
+
+// TestConfig_Validate tests the required fields of each auth mode
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  JiraConfig
+		wantErr bool
+	}{
+		{"basic", JiraConfig{BaseURL: "u", Project: "P", Email: "e", APIToken: "t"}, false},
+		{"basic missing token", JiraConfig{BaseURL: "u", Project: "P", Email: "e"}, true},
+		{"pat", JiraConfig{BaseURL: "u", Project: "P", Auth: AuthPAT, Token: "t"}, false},
+		{"pat missing token", JiraConfig{BaseURL: "u", Project: "P", Auth: AuthPAT, APIToken: "t"}, true},
+		{"oauth", *oauthTestConfig, false},
+		{"oauth missing secret", JiraConfig{BaseURL: "u", Project: "P", Auth: AuthOAuth, OAuth: &OAuthConfig{ClientID: "c"}}, true},
+		{"unknown mode", JiraConfig{BaseURL: "u", Project: "P", Auth: "kerberos"}, true},
+		{"missing project", JiraConfig{BaseURL: "u", Email: "e", APIToken: "t"}, true},
+		{"server pat", JiraConfig{BaseURL: "u", Project: "P", Auth: AuthPAT, Token: "t", APIVersion: APIVersionServer}, false},
+		{"server oauth", JiraConfig{BaseURL: "u", Project: "P", Auth: AuthOAuth, OAuth: &OAuthConfig{ClientID: "c", ClientSecret: "s"}, APIVersion: APIVersionServer}, true},
+		{"unknown api version", JiraConfig{BaseURL: "u", Project: "P", Email: "e", APIToken: "t", APIVersion: "4"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestClient_AuthModes tests the Authorization header sent for basic and PAT auth
+func TestClient_AuthModes(t *testing.T) {
+	s := newHeaderServer(t)
+
+	tests := []struct {
+		name   string
+		config JiraConfig
+		want   string
+	}{
+		{"basic", JiraConfig{BaseURL: s.server.URL, Email: "me@example.com", APIToken: "token"}, "Basic bWVAZXhhbXBsZS5jb206dG9rZW4="},
+		{"pat", JiraConfig{BaseURL: s.server.URL, Auth: AuthPAT, Token: "pat-123"}, "Bearer pat-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := newClientFromConfig(&tt.config).doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+			if err != nil {
+				t.Fatalf("doRequest failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if got := s.headers[len(s.headers)-1]; got != tt.want {
+				t.Errorf("Expected Authorization %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestOAuth_NotLoggedIn tests that requests fail with ErrNotLoggedIn before login
+func TestOAuth_NotLoggedIn(t *testing.T) {
+	newFakeAtlassian(t)
+
+	_, err := newClientFromConfig(oauthTestConfig).doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn, got %v", err)
+	}
+}
+
+// TestOAuth_RefreshesExpiredToken tests that an expired access token is
+// refreshed before the request and the rotated refresh token is persisted
+func TestOAuth_RefreshesExpiredToken(t *testing.T) {
+	f := newFakeAtlassian(t)
+	s := newHeaderServer(t)
+	oauthEndpoints.Gateway = s.server.URL + "/ex/jira/"
+
+	err := SaveOAuthToken(&OAuthToken{
+		AccessToken:  "stale",
+		RefreshToken: "refresh-0",
+		Expiry:       time.Now().Add(-time.Hour),
+		CloudID:      "cloud-123",
+		SiteURL:      oauthTestConfig.BaseURL,
+	})
+	if err != nil {
+		t.Fatalf("SaveOAuthToken failed: %v", err)
+	}
+
+	client := newClientFromConfig(oauthTestConfig)
+	for i := 0; i < 2; i++ {
+		resp, err := client.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+		if err != nil {
+			t.Fatalf("doRequest failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if len(f.grants) != 1 || f.grants[0]["grant_type"] != "refresh_token" || f.grants[0]["refresh_token"] != "refresh-0" {
+		t.Errorf("Expected a single refresh grant, got %v", f.grants)
+	}
+	for _, h := range s.headers {
+		if h != "Bearer access-1" {
+			t.Errorf("Expected refreshed access token, got %q", h)
+		}
+	}
+
+	stored, err := LoadOAuthToken(oauthTestConfig.BaseURL)
+	if err != nil {
+		t.Fatalf("LoadOAuthToken failed: %v", err)
+	}
+	if stored.RefreshToken != "refresh-1" || stored.CloudID != "cloud-123" {
+		t.Errorf("Expected rotated refresh token to be stored, got %+v", stored)
+	}
+}
+
+// TestLoadOAuthToken_InsecurePermissions tests that group/world-readable tokens are rejected
+func TestLoadOAuthToken_InsecurePermissions(t *testing.T) {
+	newFakeAtlassian(t)
+	token := &OAuthToken{AccessToken: "a", RefreshToken: "r", CloudID: "c", SiteURL: oauthTestConfig.BaseURL}
+	if err := SaveOAuthToken(token); err != nil {
+		t.Fatalf("SaveOAuthToken failed: %v", err)
+	}
+
+	path, _ := tokenPath(token.SiteURL)
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Errorf("Expected token file mode 0600, got %o", fi.Mode().Perm())
+	}
+
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	if _, err := LoadOAuthToken(token.SiteURL); err == nil || !strings.Contains(err.Error(), "insecure permissions") {
+		t.Errorf("Expected insecure permissions error, got %v", err)
+	}
+}
+
+// TestLogin_LoopbackFlow tests the authorization code flow end to end,
+// with the "browser" following the authorization URL back to the callback
+func TestLogin_LoopbackFlow(t *testing.T) {
+	f := newFakeAtlassian(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+
+	browser := func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		q := u.Query()
+		if q.Get("client_id") != "client" || !strings.Contains(q.Get("scope"), "offline_access") {
+			t.Errorf("Unexpected authorization URL: %s", authURL)
+		}
+		callback := q.Get("redirect_uri") + "?code=the-code&state=" + url.QueryEscape(q.Get("state"))
+		callback = strings.Replace(callback, "localhost", "127.0.0.1", 1)
+		resp, err := http.Get(callback)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	token, err := loginWithListener(ctx, oauthTestConfig, listener, browser)
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if token.CloudID != "cloud-123" {
+		t.Errorf("Expected cloud ID cloud-123, got %q", token.CloudID)
+	}
+	if len(f.grants) != 1 || f.grants[0]["grant_type"] != "authorization_code" || f.grants[0]["code"] != "the-code" {
+		t.Errorf("Expected an authorization_code grant, got %v", f.grants)
+	}
+
+	stored, err := LoadOAuthToken(oauthTestConfig.BaseURL)
+	if err != nil {
+		t.Fatalf("LoadOAuthToken failed: %v", err)
+	}
+	if stored.AccessToken != token.AccessToken {
+		t.Errorf("Expected the token to be stored")
+	}
+}
+
+// TestLogin_StateMismatch tests that callbacks with a foreign state are
+// refused and the flow keeps waiting for the real one
+func TestLogin_StateMismatch(t *testing.T) {
+	f := newFakeAtlassian(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	browser := func(authURL string) error {
+		resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/callback?code=forged&state=forged")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected 400 for a forged state, got %d", resp.StatusCode)
+		}
+
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		resp, err = http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/callback?code=the-code&state=" + url.QueryEscape(u.Query().Get("state")))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := loginWithListener(ctx, oauthTestConfig, listener, browser); err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if len(f.grants) != 1 || f.grants[0]["code"] != "the-code" {
+		t.Errorf("Expected a grant for the real code only, got %v", f.grants)
+	}
+}
+
+// TestLogin_StateMismatchTimeout tests that a flow that only sees forged
+// callbacks ends when its context does
+func TestLogin_StateMismatchTimeout(t *testing.T) {
+	newFakeAtlassian(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	browser := func(authURL string) error {
+		resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/callback?code=x&state=forged")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := loginWithListener(ctx, oauthTestConfig, listener, browser); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the login to time out, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Bridge implements issue.Bridge for a Jira project
+type Bridge struct {
+	client *Client
+	config *JiraConfig
+}
+
+// NewBridge creates a Jira bridge from the given configuration
+func NewBridge(config *JiraConfig) *Bridge {
+	return &Bridge{
+		client: newClientFromConfig(config),
+		config: config,
+	}
+}
+
+// Pull fetches issues from Jira and saves them to storage
+func (b *Bridge) Pull(ctx context.Context, storage *issue.Storage) (*issue.PullResult, error) {
+	return Pull(ctx, b.client, storage, b.config)
+}
+
+// Push pushes local changes to Jira
+func (b *Bridge) Push(ctx context.Context, storage *issue.Storage, key string) (*issue.PushResult, error) {
+	return Push(ctx, b.client, storage, b.config, key)
+}
+
+// Members refreshes the member cache and returns all cached members
+func (b *Bridge) Members(ctx context.Context, projectPath string) ([]*issue.Member, error) {
+	cache, err := RefreshMemberCache(ctx, b.client, projectPath, b.config.ProjectKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*issue.Member, 0, len(cache.Members))
+	for _, member := range cache.Members {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// Fields returns the metadata of all Jira fields, marking the ones mapped in custom_fields
+func (b *Bridge) Fields(ctx context.Context) ([]*FieldInfo, error) {
+	fields, err := b.client.FetchFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		for name, ref := range b.config.CustomFields {
+			if ref == f.ID || strings.EqualFold(ref, f.Name) {
+				f.Mapped = name
+			}
+		}
+	}
+	return fields, nil
+}
+
+// Workflow refreshes the workflow cache and returns all cached statuses
+func (b *Bridge) Workflow(ctx context.Context, projectPath string) ([]*issue.StatusInfo, error) {
+	cache, err := RefreshWorkflowCache(ctx, b.client, projectPath, b.config.ProjectKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*issue.StatusInfo, 0, len(cache.Statuses))
+	for _, status := range cache.Statuses {
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// boardID returns the configured board, or the first scrum board of the project
+func (b *Bridge) boardID(ctx context.Context) (int, error) {
+	if b.config.BoardID != 0 {
+		return b.config.BoardID, nil
+	}
+	boards, err := b.client.ListBoards(ctx, b.config.ProjectKeys()[0], "scrum")
+	if err != nil {
+		return 0, err
+	}
+	if len(boards) == 0 {
+		return 0, ErrNoBoard
+	}
+	return boards[0].ID, nil
+}
+
+// ActiveSprint returns the active sprint of the board
+func (b *Bridge) ActiveSprint(ctx context.Context) (*Sprint, error) {
+	boardID, err := b.boardID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sprints, err := b.client.ListSprints(ctx, boardID, SprintActive)
+	if err != nil {
+		return nil, err
+	}
+	return resolveSprint(sprints, "active")
+}
+
+// MoveToSprint moves issues to the board's sprint named by target ("active",
+// "next", a sprint name or ID; see resolveSprint), or to the backlog if target
+// is "backlog", and records the new sprint in the issue files. Returns the
+// sprint, or nil for the backlog.
+func (b *Bridge) MoveToSprint(ctx context.Context, storage *issue.Storage, keys []string, target string) (*Sprint, error) {
+	issues, err := readPushedIssues(storage, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var sprint *Sprint
+	if strings.EqualFold(strings.TrimSpace(target), "backlog") {
+		if err := b.client.MoveIssuesToBacklog(ctx, keys); err != nil {
+			return nil, err
+		}
+	} else {
+		boardID, err := b.boardID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sprints, err := b.client.ListSprints(ctx, boardID, SprintActive+","+SprintFuture)
+		if err != nil {
+			return nil, err
+		}
+		if sprint, err = resolveSprint(sprints, target); err != nil {
+			return nil, err
+		}
+		if err := b.client.MoveIssuesToSprint(ctx, sprint.ID, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sprint is excluded from the hash, so WriteIssue keeps the files in sync
+	for _, local := range issues {
+		local.Sprint = ""
+		if sprint != nil {
+			local.Sprint = sprint.Name
+		}
+		if err := storage.WriteIssue(local); err != nil {
+			return nil, fmt.Errorf("failed to save %s: %w", local.Key, err)
+		}
+	}
+	return sprint, nil
+}
+
+// Rank ranks issues, in the given order, before or after another issue on the
+// board and records their new rank in the issue files. Returns the issues.
+func (b *Bridge) Rank(ctx context.Context, storage *issue.Storage, keys []string, before, after string) ([]*issue.Issue, error) {
+	issues, err := readPushedIssues(storage, keys)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.client.RankIssues(ctx, keys, before, after); err != nil {
+		return nil, err
+	}
+
+	// Rank is excluded from the hash, like sprint
+	for _, local := range issues {
+		if local.Rank, err = b.client.FetchRank(ctx, local.Key); err != nil {
+			return nil, err
+		}
+		if err := storage.WriteIssue(local); err != nil {
+			return nil, fmt.Errorf("failed to save %s: %w", local.Key, err)
+		}
+	}
+	return issues, nil
+}
+
+// readPushedIssues reads the issues to move or rank, which must exist in Jira
+func readPushedIssues(storage *issue.Storage, keys []string) ([]*issue.Issue, error) {
+	issues := make([]*issue.Issue, 0, len(keys))
+	for _, key := range keys {
+		local, err := storage.ReadIssue(key)
+		if err != nil {
+			return nil, err
+		}
+		if local.RemoteID == "" {
+			return nil, fmt.Errorf("%s has not been created in Jira yet; run 'takl push' first", key)
+		}
+		issues = append(issues, local)
+	}
+	return issues, nil
+}
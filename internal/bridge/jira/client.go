@@ -3,32 +3,100 @@ package jira
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/gurisko/takl/internal/issue"
 )
 
 // Client is a lightweight Jira REST API client
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
-	email      string
-	apiToken   string
+	auth       Authenticator
+	// apiVersion is the REST API version: "3" for Cloud, "2" for Data Center/Server
+	apiVersion string
+	// sprintField, storyPointsField and rankField are the site's custom field IDs
+	sprintField      string
+	storyPointsField string
+	rankField        string
+	// customFields are the resolved custom_fields mappings (see resolveCustomFields)
+	customFields []customField
 }
 
-// NewClient creates a new Jira API client
+// NewClient creates a new Jira API client using Basic auth with email and API token
 func NewClient(baseURL, email, apiToken string) *Client {
+	return NewClientWithAuth(baseURL, &BasicAuth{Email: email, APIToken: apiToken})
+}
+
+// NewClientWithAuth creates a new Jira API client with the given authentication.
+// Requests are rate-limited client-side, and 429 and transient 5xx responses
+// are retried with backoff (see retryTransport).
+func NewClientWithAuth(baseURL string, auth Authenticator) *Client {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	// Bound each attempt; the client timeout also covers retries and backoff
+	base.ResponseHeaderTimeout = 30 * time.Second
+
 	return &Client{
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{Transport: newRetryTransport(base), Timeout: 2 * time.Minute},
 		baseURL:    baseURL,
-		email:      email,
-		apiToken:   apiToken,
+		auth:       auth,
+		apiVersion: APIVersionCloud,
+
+		sprintField:      DefaultSprintField,
+		storyPointsField: DefaultStoryPointsField,
+		rankField:        DefaultRankField,
+	}
+}
+
+// apiPath returns the REST API path for the client's API version
+func (c *Client) apiPath(path string) string {
+	return "/rest/api/" + c.apiVersion + path
+}
+
+// isServer reports whether the client talks to Data Center/Server (REST v2),
+// which paginates searches with startAt and uses wiki markup for rich text
+func (c *Client) isServer() bool {
+	return c.apiVersion == APIVersionServer
+}
+
+// toMarkdown converts a rich text field (ADF on Cloud, wiki markup on Server) to Markdown
+func (c *Client) toMarkdown(raw json.RawMessage) (string, error) {
+	if !c.isServer() {
+		return ADFToMarkdown(raw)
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var wiki string
+	if err := json.Unmarshal(raw, &wiki); err != nil {
+		return "", fmt.Errorf("failed to parse wiki markup: %w", err)
 	}
+	return WikiToMarkdown(wiki), nil
+}
+
+// fromMarkdown converts Markdown to the rich text representation of the client's API version
+func (c *Client) fromMarkdown(markdown string) (interface{}, error) {
+	if c.isServer() {
+		return MarkdownToWiki(markdown), nil
+	}
+
+	adf, err := MarkdownToADF(markdown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to ADF: %w", err)
+	}
+	// Unmarshal the ADF JSON into a map so it serializes correctly
+	var adfDoc map[string]interface{}
+	if err := json.Unmarshal(adf, &adfDoc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ADF: %w", err)
+	}
+	return adfDoc, nil
 }
 
 // doRequest executes an HTTP request with authentication
@@ -49,9 +117,9 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Basic auth
-	auth := base64.StdEncoding.EncodeToString([]byte(c.email + ":" + c.apiToken))
-	req.Header.Set("Authorization", "Basic "+auth)
+	if err := c.auth.Authorize(ctx, req); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -63,7 +131,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodySize))
 		resp.Body.Close()
-		return nil, fmt.Errorf("jira API error %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp.StatusCode, body)
 	}
 
 	return resp, nil
@@ -71,8 +139,39 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 // SearchIssues searches for issues using JQL with pagination
 // If cache is provided, formats users as "Display Name <email>", otherwise uses display name only
-func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int, cache *MemberCache) ([]Issue, error) {
-	var allIssues []Issue
+func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int, cache *issue.MemberCache) ([]issue.Issue, error) {
+	found, err := c.searchJiraIssues(ctx, jql, maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	allIssues := make([]issue.Issue, 0, len(found))
+	for _, jiraIssue := range found {
+		allIssues = append(allIssues, c.convertJiraIssue(jiraIssue, cache))
+	}
+	return allIssues, nil
+}
+
+// searchFields returns the issue fields requested by searches and GetIssue
+func (c *Client) searchFields() []string {
+	fields := []string{
+		"summary", "description", "status", "assignee", "reporter", "created", "updated",
+		"labels", "comment", "attachment", "issuetype", "priority", "components",
+		"fixVersions", "duedate", "parent", "issuelinks", "subtasks", "worklog", "timetracking", c.sprintField, c.storyPointsField, c.rankField,
+	}
+	for _, cf := range c.customFields {
+		fields = append(fields, cf.info.ID)
+	}
+	return fields
+}
+
+// searchJiraIssues runs a paginated JQL search and returns the raw, unarchived issues
+func (c *Client) searchJiraIssues(ctx context.Context, jql string, maxResults int) ([]jiraIssueResponse, error) {
+	if c.isServer() {
+		return c.searchJiraIssuesV2(ctx, jql, maxResults)
+	}
+
+	var allIssues []jiraIssueResponse
 	nextPageToken := ""
 	pageNum := 1
 
@@ -90,7 +189,7 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int, c
 		reqBody := map[string]interface{}{
 			"jql":        jql,
 			"maxResults": want,
-			"fields":     []string{"summary", "description", "status", "assignee", "reporter", "created", "updated", "labels", "comment", "attachment"},
+			"fields":     c.searchFields(),
 		}
 
 		if nextPageToken != "" {
@@ -129,7 +228,7 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int, c
 				log.Printf("[DEBUG] SearchIssues: Skipping archived issue %s", jiraIssue.Key)
 				continue
 			}
-			allIssues = append(allIssues, convertJiraIssue(jiraIssue, cache))
+			allIssues = append(allIssues, jiraIssue)
 		}
 
 		// Check if we have more pages
@@ -146,66 +245,188 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int, c
 	return allIssues, nil
 }
 
+// searchJiraIssuesV2 runs a JQL search against Data Center/Server, which
+// paginates /rest/api/2/search with startAt instead of page tokens
+func (c *Client) searchJiraIssuesV2(ctx context.Context, jql string, maxResults int) ([]jiraIssueResponse, error) {
+	var allIssues []jiraIssueResponse
+	startAt := 0
+
+	for {
+		want := SearchPageSize
+		if maxResults > 0 && maxResults-len(allIssues) < want {
+			want = maxResults - len(allIssues)
+			if want <= 0 {
+				break
+			}
+		}
+
+		reqBody := map[string]interface{}{
+			"jql":        jql,
+			"startAt":    startAt,
+			"maxResults": want,
+			"fields":     c.searchFields(),
+		}
+
+		log.Printf("[DEBUG] SearchIssues: Fetching page at startAt=%d", startAt)
+
+		resp, err := c.doRequest(ctx, "POST", c.apiPath("/search"), reqBody)
+		if err != nil {
+			return nil, err
+		}
+
+		var searchResp jiraSearchResponse
+		if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&searchResp); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode search response: %w", err)
+		}
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] SearchIssues: startAt=%d returned %d issues (total: %d)",
+			startAt, len(searchResp.Issues), searchResp.Total)
+
+		for _, jiraIssue := range searchResp.Issues {
+			if jiraIssue.Archived {
+				log.Printf("[DEBUG] SearchIssues: Skipping archived issue %s", jiraIssue.Key)
+				continue
+			}
+			allIssues = append(allIssues, jiraIssue)
+		}
+
+		// The server may cap maxResults below what we asked for, so advance by what came back
+		startAt += len(searchResp.Issues)
+		if len(searchResp.Issues) == 0 || startAt >= searchResp.Total || (maxResults > 0 && len(allIssues) >= maxResults) {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] SearchIssues: Complete - fetched %d total issues", len(allIssues))
+	return allIssues, nil
+}
+
 // convertJiraIssue converts Jira API response to our Issue type
 // If cache is provided, formats users as "Display Name <email>", otherwise uses display name only
-func convertJiraIssue(jr jiraIssueResponse, cache *MemberCache) Issue {
-	// Convert description from ADF to Markdown
-	description, err := ADFToMarkdown(jr.Fields.Description)
+func (c *Client) convertJiraIssue(jr jiraIssueResponse, cache *issue.MemberCache) issue.Issue {
+	// Convert description from ADF (or wiki markup) to Markdown
+	description, err := c.toMarkdown(jr.Fields.Description)
 	if err != nil {
 		log.Printf("[WARN] Failed to convert description for %s: %v", jr.Key, err)
 		description = "" // Fallback to empty string
 	}
 
-	// Helper to format user from accountId
-	formatUser := func(accountID, displayName string) string {
-		if cache != nil {
-			if member := cache.FindByAccountID(accountID); member != nil {
-				return member.FormatMember()
-			}
-		}
-		// Fallback to display name only
-		return displayName
+	// Helper to format user from accountId (or username on Server)
+	formatUser := func(user jiraUser) string {
+		return formatMember(user, cache)
 	}
 
-	issue := Issue{
-		JiraKey:     jr.Key,
-		JiraID:      jr.ID,
+	out := issue.Issue{
+		Key:         jr.Key,
+		RemoteID:    jr.ID,
 		Title:       jr.Fields.Summary,
 		Description: description,
 		Status:      jr.Fields.Status.Name,
-		Reporter:    formatUser(jr.Fields.Reporter.AccountID, jr.Fields.Reporter.DisplayName),
+		Reporter:    formatUser(jr.Fields.Reporter),
 		Created:     jr.Fields.Created.Time,
 		Updated:     jr.Fields.Updated.Time,
 		Labels:      jr.Fields.Labels,
+		Components:  names(jr.Fields.Components),
+		FixVersions: names(jr.Fields.FixVersions),
+		DueDate:     jr.Fields.DueDate,
+		Sprint:      parseSprint(jr.Fields.Custom[c.sprintField]),
+		StoryPoints: parseStoryPoints(jr.Fields.Custom[c.storyPointsField]),
+		Rank:        parseRank(jr.Fields.Custom[c.rankField]),
 	}
 
 	if jr.Fields.Assignee != nil {
-		issue.Assignee = formatUser(jr.Fields.Assignee.AccountID, jr.Fields.Assignee.DisplayName)
+		out.Assignee = formatUser(*jr.Fields.Assignee)
+	}
+	if jr.Fields.IssueType != nil {
+		out.Type = jr.Fields.IssueType.Name
+	}
+	if jr.Fields.Priority != nil {
+		out.Priority = jr.Fields.Priority.Name
+	}
+	if jr.Fields.Parent != nil {
+		out.Parent = jr.Fields.Parent.Key
+	}
+	if jr.Fields.TimeTracking != nil {
+		out.OriginalEstimate = jr.Fields.TimeTracking.OriginalEstimate
+		out.RemainingEstimate = jr.Fields.TimeTracking.RemainingEstimate
+	}
+
+	// Convert links and subtasks
+	for _, jl := range jr.Fields.IssueLinks {
+		link := issue.Link{Type: jl.Type.Name, ID: jl.ID}
+		switch {
+		case jl.OutwardIssue != nil:
+			link.Direction, link.Key = issue.LinkOutward, jl.OutwardIssue.Key
+		case jl.InwardIssue != nil:
+			link.Direction, link.Key = issue.LinkInward, jl.InwardIssue.Key
+		default:
+			continue
+		}
+		out.Links = append(out.Links, link)
+	}
+	for _, subtask := range jr.Fields.Subtasks {
+		out.Links = append(out.Links, issue.Link{Type: issue.LinkTypeSubtask, Direction: issue.LinkOutward, Key: subtask.Key})
+	}
+
+	// Convert mapped custom fields
+	for _, cf := range c.customFields {
+		value, err := c.decodeCustomField(cf.info, jr.Fields.Custom[cf.info.ID], cache)
+		if err != nil {
+			log.Printf("[WARN] Failed to convert custom field %s of %s: %v", cf.name, jr.Key, err)
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if out.Custom == nil {
+			out.Custom = make(map[string]interface{})
+		}
+		out.Custom[cf.name] = value
 	}
 
 	// Convert comments
-	issue.Comments = make([]Comment, 0, len(jr.Fields.Comment.Comments))
+	out.Comments = make([]issue.Comment, 0, len(jr.Fields.Comment.Comments))
 	for _, jc := range jr.Fields.Comment.Comments {
-		// Convert comment body from ADF to Markdown
-		body, err := ADFToMarkdown(jc.Body)
+		// Convert comment body from ADF (or wiki markup) to Markdown
+		body, err := c.toMarkdown(jc.Body)
 		if err != nil {
 			log.Printf("[WARN] Failed to convert comment body for %s: %v", jr.Key, err)
 			body = "" // Fallback to empty string
 		}
 
-		issue.Comments = append(issue.Comments, Comment{
-			ID:      jc.ID,
-			Author:  formatUser(jc.Author.AccountID, jc.Author.DisplayName),
-			Body:    body,
-			Created: jc.Created.Time,
-			Updated: jc.Updated.Time,
+		out.Comments = append(out.Comments, issue.Comment{
+			ID:       jc.ID,
+			Author:   formatUser(jc.Author),
+			AuthorID: jc.Author.id(),
+			Body:     body,
+			Created:  jc.Created.Time,
+			Updated:  jc.Updated.Time,
+		})
+	}
+
+	// Convert worklogs
+	for _, jw := range jr.Fields.Worklog.Worklogs {
+		comment, err := c.toMarkdown(jw.Comment)
+		if err != nil {
+			log.Printf("[WARN] Failed to convert worklog comment for %s: %v", jr.Key, err)
+			comment = "" // Fallback to empty string
+		}
+
+		out.Worklogs = append(out.Worklogs, issue.Worklog{
+			ID:        jw.ID,
+			Author:    formatUser(jw.Author),
+			Started:   jw.Started.Time,
+			TimeSpent: jw.TimeSpentSeconds,
+			Comment:   comment,
 		})
 	}
 
 	// Convert attachments
-	issue.Attachments = make([]Attachment, 0, len(jr.Fields.Attachment))
+	out.Attachments = make([]issue.Attachment, 0, len(jr.Fields.Attachment))
 	for _, ja := range jr.Fields.Attachment {
-		issue.Attachments = append(issue.Attachments, Attachment{
+		out.Attachments = append(out.Attachments, issue.Attachment{
 			ID:       ja.ID,
 			Filename: ja.Filename,
 			URL:      ja.Content,
@@ -215,13 +436,13 @@ func convertJiraIssue(jr jiraIssueResponse, cache *MemberCache) Issue {
 		})
 	}
 
-	return issue
+	return out
 }
 
 // FetchProjectMembers fetches all assignable users for a project with pagination
 // The Jira API limits results per request, so we paginate using startAt to get all users
-func (c *Client) FetchProjectMembers(ctx context.Context, projectKey string) ([]*Member, error) {
-	var allMembers []*Member
+func (c *Client) FetchProjectMembers(ctx context.Context, projectKey string) ([]*issue.Member, error) {
+	var allMembers []*issue.Member
 	startAt := 0
 	pageSize := 1_000
 	pageNum := 1
@@ -231,8 +452,8 @@ func (c *Client) FetchProjectMembers(ctx context.Context, projectKey string) ([]
 
 	for {
 		// Build paginated request path
-		path := fmt.Sprintf("/rest/api/3/user/assignable/search?project=%s&maxResults=%d&startAt=%d",
-			escapedProjectKey, pageSize, startAt)
+		path := fmt.Sprintf("%s?project=%s&maxResults=%d&startAt=%d",
+			c.apiPath("/user/assignable/search"), escapedProjectKey, pageSize, startAt)
 
 		log.Printf("[DEBUG] FetchProjectMembers: Fetching page %d (startAt=%d)", pageNum, startAt)
 
@@ -253,8 +474,8 @@ func (c *Client) FetchProjectMembers(ctx context.Context, projectKey string) ([]
 
 		// Convert and append users
 		for _, user := range users {
-			allMembers = append(allMembers, &Member{
-				AccountID:    user.AccountID,
+			allMembers = append(allMembers, &issue.Member{
+				AccountID:    user.id(),
 				DisplayName:  user.DisplayName,
 				EmailAddress: user.EmailAddress,
 				Active:       user.Active,
@@ -277,10 +498,10 @@ func (c *Client) FetchProjectMembers(ctx context.Context, projectKey string) ([]
 
 // FetchProjectStatuses fetches all statuses for a project grouped by issue type
 // Returns a deduplicated list of all unique statuses across all issue types
-func (c *Client) FetchProjectStatuses(ctx context.Context, projectKey string) ([]*StatusInfo, error) {
+func (c *Client) FetchProjectStatuses(ctx context.Context, projectKey string) ([]*issue.StatusInfo, error) {
 	// URL-escape project key for safety
 	escapedProjectKey := url.QueryEscape(projectKey)
-	path := fmt.Sprintf("/rest/api/3/project/%s/statuses", escapedProjectKey)
+	path := c.apiPath(fmt.Sprintf("/project/%s/statuses", escapedProjectKey))
 
 	log.Printf("[DEBUG] FetchProjectStatuses: Fetching statuses for project %s", projectKey)
 
@@ -296,12 +517,12 @@ func (c *Client) FetchProjectStatuses(ctx context.Context, projectKey string) ([
 	}
 
 	// Deduplicate statuses across issue types (same status can appear in multiple issue types)
-	statusMap := make(map[string]*StatusInfo)
+	statusMap := make(map[string]*issue.StatusInfo)
 	for _, issueType := range issueTypes {
 		for _, jiraStatus := range issueType.Statuses {
 			// Use status ID as key for deduplication (more stable than name)
 			if _, exists := statusMap[jiraStatus.ID]; !exists {
-				statusMap[jiraStatus.ID] = &StatusInfo{
+				statusMap[jiraStatus.ID] = &issue.StatusInfo{
 					ID:       jiraStatus.ID,
 					Name:     jiraStatus.Name,
 					Category: jiraStatus.StatusCategory.Key,
@@ -311,7 +532,7 @@ func (c *Client) FetchProjectStatuses(ctx context.Context, projectKey string) ([
 	}
 
 	// Convert map to slice
-	statuses := make([]*StatusInfo, 0, len(statusMap))
+	statuses := make([]*issue.StatusInfo, 0, len(statusMap))
 	for _, status := range statusMap {
 		statuses = append(statuses, status)
 	}
@@ -322,10 +543,10 @@ func (c *Client) FetchProjectStatuses(ctx context.Context, projectKey string) ([
 
 // GetIssue fetches a single issue by key from Jira
 // Used for conflict detection when pushing changes
-func (c *Client) GetIssue(ctx context.Context, issueKey string, cache *MemberCache) (*Issue, error) {
+func (c *Client) GetIssue(ctx context.Context, issueKey string, cache *issue.MemberCache) (*issue.Issue, error) {
 	// URL-escape issue key for safety
 	escapedKey := url.QueryEscape(issueKey)
-	path := fmt.Sprintf("/rest/api/3/issue/%s?fields=summary,description,status,assignee,reporter,created,updated,labels,comment,attachment", escapedKey)
+	path := c.apiPath(fmt.Sprintf("/issue/%s?fields=%s", escapedKey, strings.Join(c.searchFields(), ",")))
 
 	log.Printf("[DEBUG] GetIssue: Fetching issue %s", issueKey)
 
@@ -340,31 +561,125 @@ func (c *Client) GetIssue(ctx context.Context, issueKey string, cache *MemberCac
 		return nil, fmt.Errorf("failed to decode issue response: %w", err)
 	}
 
-	issue := convertJiraIssue(jiraIssue, cache)
+	if err := c.completeComments(ctx, &jiraIssue); err != nil {
+		return nil, err
+	}
+	if err := c.completeWorklogs(ctx, &jiraIssue); err != nil {
+		return nil, err
+	}
+
+	issue := c.convertJiraIssue(jiraIssue, cache)
 	log.Printf("[DEBUG] GetIssue: Successfully fetched issue %s", issueKey)
 	return &issue, nil
 }
 
+// ListComments fetches all comments of an issue with pagination.
+// Search and issue responses embed only the first page of comments.
+func (c *Client) ListComments(ctx context.Context, issueKey string) ([]jiraComment, error) {
+	escapedKey := url.QueryEscape(issueKey)
+	var all []jiraComment
+	startAt := 0
+
+	for {
+		path := c.apiPath(fmt.Sprintf("/issue/%s/comment?startAt=%d&maxResults=%d&orderBy=created", escapedKey, startAt, CommentPageSize))
+
+		resp, err := c.doRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch comments: %w", err)
+		}
+
+		var page jiraCommentPage
+		if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&page); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode comments response: %w", err)
+		}
+		resp.Body.Close()
+
+		all = append(all, page.Comments...)
+		startAt += len(page.Comments)
+		if len(page.Comments) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] ListComments: Fetched %d comments for %s", len(all), issueKey)
+	return all, nil
+}
+
+// completeComments replaces a truncated embedded comment page with the full list
+func (c *Client) completeComments(ctx context.Context, jr *jiraIssueResponse) error {
+	if !jr.hasMoreComments() {
+		return nil
+	}
+	comments, err := c.ListComments(ctx, jr.Key)
+	if err != nil {
+		return err
+	}
+	jr.Fields.Comment = jiraCommentPage{Comments: comments, Total: len(comments)}
+	return nil
+}
+
+// ListWorklogs fetches all worklogs of an issue with pagination.
+// Search and issue responses embed only the first 20 worklogs.
+func (c *Client) ListWorklogs(ctx context.Context, issueKey string) ([]jiraWorklog, error) {
+	escapedKey := url.QueryEscape(issueKey)
+	var all []jiraWorklog
+	startAt := 0
+
+	for {
+		path := c.apiPath(fmt.Sprintf("/issue/%s/worklog?startAt=%d&maxResults=%d", escapedKey, startAt, WorklogPageSize))
+
+		resp, err := c.doRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch worklogs: %w", err)
+		}
+
+		var page jiraWorklogPage
+		if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&page); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decode worklogs response: %w", err)
+		}
+		resp.Body.Close()
+
+		all = append(all, page.Worklogs...)
+		startAt += len(page.Worklogs)
+		if len(page.Worklogs) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	log.Printf("[DEBUG] ListWorklogs: Fetched %d worklogs for %s", len(all), issueKey)
+	return all, nil
+}
+
+// completeWorklogs replaces a truncated embedded worklog page with the full list
+func (c *Client) completeWorklogs(ctx context.Context, jr *jiraIssueResponse) error {
+	if !jr.hasMoreWorklogs() {
+		return nil
+	}
+	worklogs, err := c.ListWorklogs(ctx, jr.Key)
+	if err != nil {
+		return err
+	}
+	jr.Fields.Worklog = jiraWorklogPage{Worklogs: worklogs, Total: len(worklogs)}
+	return nil
+}
+
 // UpdateIssue updates an issue's fields in Jira
 // Supports updating: summary (title), description, and labels
-// Note: description should be provided as markdown and will be converted to ADF
+// Note: description should be provided as markdown and will be converted to ADF (wiki markup on Server)
 func (c *Client) UpdateIssue(ctx context.Context, issueKey string, updates map[string]interface{}) error {
 	// URL-escape issue key for safety
 	escapedKey := url.QueryEscape(issueKey)
-	path := fmt.Sprintf("/rest/api/3/issue/%s", escapedKey)
+	path := c.apiPath(fmt.Sprintf("/issue/%s", escapedKey))
 
-	// Convert description from markdown to ADF if present
+	// Convert description from markdown if present
 	if desc, ok := updates["description"].(string); ok {
-		adf, err := MarkdownToADF(desc)
+		converted, err := c.fromMarkdown(desc)
 		if err != nil {
-			return fmt.Errorf("failed to convert description to ADF: %w", err)
-		}
-		// Unmarshal the ADF JSON into a map so it serializes correctly
-		var adfDoc map[string]interface{}
-		if err := json.Unmarshal(adf, &adfDoc); err != nil {
-			return fmt.Errorf("failed to unmarshal ADF: %w", err)
+			return fmt.Errorf("failed to convert description: %w", err)
 		}
-		updates["description"] = adfDoc
+		updates["description"] = converted
 	}
 
 	// Wrap updates in "fields" object as required by Jira API
@@ -384,27 +699,56 @@ func (c *Client) UpdateIssue(ctx context.Context, issueKey string, updates map[s
 	return nil
 }
 
+// CreateIssue creates an issue in Jira and returns its ID and key.
+// fields must include project, issuetype and summary; a description is
+// provided as markdown and converted like in UpdateIssue.
+func (c *Client) CreateIssue(ctx context.Context, fields map[string]interface{}) (*jiraCreatedIssue, error) {
+	if desc, ok := fields["description"].(string); ok {
+		converted, err := c.fromMarkdown(desc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert description: %w", err)
+		}
+		fields["description"] = converted
+	}
+
+	body := map[string]interface{}{
+		"fields": fields,
+	}
+
+	log.Printf("[DEBUG] CreateIssue: Creating issue")
+
+	resp, err := c.doRequest(ctx, "POST", c.apiPath("/issue"), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var created jiraCreatedIssue
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode create response: %w", err)
+	}
+	if created.Key == "" {
+		return nil, fmt.Errorf("create response has no issue key")
+	}
+
+	log.Printf("[DEBUG] CreateIssue: Created issue %s", created.Key)
+	return &created, nil
+}
+
 // AddComment adds a comment to an issue in Jira
-// The comment body should be in markdown format (will be converted to ADF)
+// The comment body should be in markdown format (will be converted to ADF, or wiki markup on Server)
 func (c *Client) AddComment(ctx context.Context, issueKey string, commentBody string) error {
 	// URL-escape issue key for safety
 	escapedKey := url.QueryEscape(issueKey)
-	path := fmt.Sprintf("/rest/api/3/issue/%s/comment", escapedKey)
+	path := c.apiPath(fmt.Sprintf("/issue/%s/comment", escapedKey))
 
-	// Convert markdown to ADF for Jira API
-	adf, err := MarkdownToADF(commentBody)
+	converted, err := c.fromMarkdown(commentBody)
 	if err != nil {
-		return fmt.Errorf("failed to convert comment to ADF: %w", err)
-	}
-
-	// Unmarshal the ADF JSON into a map so it serializes correctly
-	var adfDoc map[string]interface{}
-	if err := json.Unmarshal(adf, &adfDoc); err != nil {
-		return fmt.Errorf("failed to unmarshal ADF: %w", err)
+		return fmt.Errorf("failed to convert comment: %w", err)
 	}
 
 	body := map[string]interface{}{
-		"body": adfDoc,
+		"body": converted,
 	}
 
 	log.Printf("[DEBUG] AddComment: Adding comment to issue %s", issueKey)
@@ -419,6 +763,71 @@ func (c *Client) AddComment(ctx context.Context, issueKey string, commentBody st
 	return nil
 }
 
+// AddWorklog logs time on an issue in Jira. adjustEstimate is "auto" to reduce
+// the remaining estimate by the time spent, or "leave" to keep it.
+// The comment should be in markdown format (converted like comments).
+func (c *Client) AddWorklog(ctx context.Context, issueKey string, worklog issue.Worklog, adjustEstimate string) error {
+	escapedKey := url.QueryEscape(issueKey)
+	path := c.apiPath(fmt.Sprintf("/issue/%s/worklog?adjustEstimate=%s", escapedKey, url.QueryEscape(adjustEstimate)))
+
+	body := map[string]interface{}{
+		"started":          worklog.Started.Format(jiraTimeFormat),
+		"timeSpentSeconds": worklog.TimeSpent,
+	}
+	if worklog.Comment != "" {
+		converted, err := c.fromMarkdown(worklog.Comment)
+		if err != nil {
+			return fmt.Errorf("failed to convert worklog comment: %w", err)
+		}
+		body["comment"] = converted
+	}
+
+	log.Printf("[DEBUG] AddWorklog: Logging %ds on issue %s", worklog.TimeSpent, issueKey)
+
+	resp, err := c.doRequest(ctx, "POST", path, body)
+	if err != nil {
+		return fmt.Errorf("failed to add worklog: %w", err)
+	}
+	defer resp.Body.Close()
+
+	log.Printf("[DEBUG] AddWorklog: Successfully logged time on issue %s", issueKey)
+	return nil
+}
+
+// CreateIssueLink links two issues. The link reads "<from> <outward phrase> <to>",
+// e.g. from=PROJ-1, type Blocks, to=PROJ-2 means PROJ-1 blocks PROJ-2.
+func (c *Client) CreateIssueLink(ctx context.Context, linkType, from, to string) error {
+	// Jira names the source of the outward phrase "inwardIssue"
+	body := map[string]interface{}{
+		"type":         jiraNamed{Name: linkType},
+		"inwardIssue":  map[string]string{"key": from},
+		"outwardIssue": map[string]string{"key": to},
+	}
+
+	log.Printf("[DEBUG] CreateIssueLink: Linking %s %s %s", from, linkType, to)
+
+	resp, err := c.doRequest(ctx, "POST", c.apiPath("/issueLink"), body)
+	if err != nil {
+		return fmt.Errorf("failed to create issue link: %w", err)
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// DeleteIssueLink deletes an issue link by ID
+func (c *Client) DeleteIssueLink(ctx context.Context, linkID string) error {
+	path := c.apiPath("/issueLink/" + url.PathEscape(linkID))
+
+	log.Printf("[DEBUG] DeleteIssueLink: Deleting link %s", linkID)
+
+	resp, err := c.doRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete issue link: %w", err)
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
 // GetTransitions fetches available workflow transitions for an issue
 func (c *Client) GetTransitions(ctx context.Context, issueKey string) ([]struct {
 	ID         string
@@ -428,7 +837,7 @@ func (c *Client) GetTransitions(ctx context.Context, issueKey string) ([]struct
 }, error) {
 	// URL-escape issue key for safety
 	escapedKey := url.QueryEscape(issueKey)
-	path := fmt.Sprintf("/rest/api/3/issue/%s/transitions", escapedKey)
+	path := c.apiPath(fmt.Sprintf("/issue/%s/transitions", escapedKey))
 
 	log.Printf("[DEBUG] GetTransitions: Fetching transitions for issue %s", issueKey)
 
@@ -473,7 +882,7 @@ func (c *Client) GetTransitions(ctx context.Context, issueKey string) ([]struct
 func (c *Client) TransitionIssue(ctx context.Context, issueKey string, transitionID string) error {
 	// URL-escape issue key for safety
 	escapedKey := url.QueryEscape(issueKey)
-	path := fmt.Sprintf("/rest/api/3/issue/%s/transitions", escapedKey)
+	path := c.apiPath(fmt.Sprintf("/issue/%s/transitions", escapedKey))
 
 	body := map[string]interface{}{
 		"transition": map[string]string{
@@ -1,26 +1,22 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 )
 
-// LoadConfig loads and validates Jira configuration from .takl/jira.json
-// in the specified project directory. Returns the config and project path.
-func LoadConfig(projectPath string) (*JiraConfig, error) {
+// ReadConfig reads Jira configuration from .takl/jira.json in the specified
+// project directory without resolving credentials or validating it.
+func ReadConfig(projectPath string) (*JiraConfig, error) {
 	configPath := filepath.Join(projectPath, ".takl", "jira.json")
 
-	// Check file permissions (should be 0600 to protect API token)
-	fi, statErr := os.Stat(configPath)
-	if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
-		return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", configPath, configPath)
-	}
-
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read jira config at %s: %w\nPlease create .takl/jira.json with your Jira credentials", configPath, err)
+		return nil, fmt.Errorf("failed to read jira config at %s: %w\nPlease create .takl/jira.json with your Jira settings", configPath, err)
 	}
 
 	var config JiraConfig
@@ -28,14 +24,40 @@ func LoadConfig(projectPath string) (*JiraConfig, error) {
 		return nil, fmt.Errorf("failed to parse jira config: %w", err)
 	}
 
-	// Validate required fields
-	if config.BaseURL == "" || config.Email == "" || config.APIToken == "" || config.Project == "" {
-		return nil, fmt.Errorf("jira config is incomplete: base_url, email, api_token, and project are required")
+	// Secrets in the project file are still honored, but only if the file is private
+	if config.hasSecrets() {
+		fi, statErr := os.Stat(configPath)
+		if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
+			return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", configPath, configPath)
+		}
+		log.Printf("[WARN] ReadConfig: %s contains credentials; move them to the credential store ('takl jira credentials set')", configPath)
 	}
 
 	return &config, nil
 }
 
+// LoadConfig loads Jira configuration from .takl/jira.json, resolves its
+// credentials (see ResolveCredentials) and validates it.
+func LoadConfig(projectPath string) (*JiraConfig, error) {
+	config, err := ReadConfig(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ResolveCredentials(context.Background(), config); err != nil {
+		return nil, fmt.Errorf("failed to resolve jira credentials: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		if config.missingCredentials() {
+			return nil, fmt.Errorf("%w (run 'takl jira credentials set' or set %s/%s/%s)", err, EnvAPIToken, EnvToken, EnvClientSecret)
+		}
+		return nil, err
+	}
+
+	return config, nil
+}
+
 // LoadConfigFromCwd loads Jira configuration from the current working directory.
 // Returns the config and the current working directory path.
 func LoadConfigFromCwd() (*JiraConfig, string, error) {
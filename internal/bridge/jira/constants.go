@@ -1,5 +1,7 @@
 package jira
 
+import "time"
+
 const (
 	// MaxJSONPayloadSize is the maximum size for incoming JSON payloads (1MB)
 	MaxJSONPayloadSize = 1 << 20
@@ -16,3 +18,61 @@ const (
 	// MaxSearchResults is the maximum total number of issues to fetch
 	MaxSearchResults = 1000
 )
+
+// Default IDs of the custom fields holding the sprint, story points and rank on
+// Jira Cloud; they differ per site and can be overridden in JiraConfig
+const (
+	DefaultSprintField      = "customfield_10020"
+	DefaultStoryPointsField = "customfield_10016"
+	DefaultRankField        = "customfield_10019"
+)
+
+// DefaultIssueType is the type of issues created from local files without a type
+const DefaultIssueType = "Task"
+
+// REST API versions for JiraConfig.APIVersion
+const (
+	APIVersionCloud  = "3" // Jira Cloud: /search/jql with page tokens, ADF rich text
+	APIVersionServer = "2" // Data Center/Server: /search with startAt, wiki markup rich text
+)
+
+const (
+	// MaxRetries is the number of times a rate-limited or failed request is retried
+	MaxRetries = 4
+
+	// RetryBaseDelay is the backoff before the first retry; it doubles per attempt
+	RetryBaseDelay = 500 * time.Millisecond
+
+	// RetryMaxDelay caps the backoff between retries, including Retry-After waits
+	RetryMaxDelay = 30 * time.Second
+
+	// RequestsPerSecond is the sustained client-side request rate
+	RequestsPerSecond = 10
+
+	// RequestBurst is the number of requests allowed before rate limiting kicks in
+	RequestBurst = 20
+
+	// DefaultConcurrency is the number of issues processed in parallel during pull and push
+	DefaultConcurrency = 4
+
+	// MaxConcurrency caps the configured concurrency
+	MaxConcurrency = 16
+
+	// DefaultRedirectPort is the loopback port for the OAuth callback
+	DefaultRedirectPort = 8765
+
+	// CredentialCommandTimeout bounds how long a credential_command may run
+	CredentialCommandTimeout = 30 * time.Second
+
+	// CommentPageSize is the number of comments to fetch per request
+	CommentPageSize = 100
+
+	// WorklogPageSize is the number of worklogs to fetch per request
+	WorklogPageSize = 1000
+
+	// AgilePageSize is the number of boards, sprints or sprint issues to fetch per request
+	AgilePageSize = 50
+
+	// MaxAgileIssues is the number of issues the Agile API moves or ranks per request
+	MaxAgileIssues = 50
+)
@@ -0,0 +1,293 @@
+package jira
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gurisko/takl/internal/paths"
+)
+
+// Environment variables consulted for Jira credentials
+const (
+	EnvEmail        = "TAKL_JIRA_EMAIL"
+	EnvAPIToken     = "TAKL_JIRA_API_TOKEN"
+	EnvToken        = "TAKL_JIRA_TOKEN"
+	EnvClientSecret = "TAKL_JIRA_CLIENT_SECRET"
+)
+
+// credentialsFilename is the per-user credential store in the state directory
+const credentialsFilename = "jira-credentials.json"
+
+// Credentials are the per-user secrets for a Jira site, kept out of the
+// project's .takl/jira.json so that file can be committed
+type Credentials struct {
+	Email        string `json:"email,omitempty"`
+	APIToken     string `json:"api_token,omitempty"`     // auth: basic
+	Token        string `json:"token,omitempty"`         // auth: pat
+	ClientSecret string `json:"client_secret,omitempty"` // auth: oauth
+
+	// CredentialCommand is run through sh to fetch missing credentials, like a
+	// git credential helper: it reads "protocol=" and "host=" lines on stdin and
+	// prints key=value lines (username/email, password, api_token, token, client_secret).
+	// "git credential fill" works as is.
+	CredentialCommand string `json:"credential_command,omitempty"`
+}
+
+// credentialsPath returns the location of the credential store
+func credentialsPath() string {
+	return filepath.Join(paths.DefaultStateDir(), credentialsFilename)
+}
+
+// siteKey normalizes a base URL to the scheme and host used as the store key
+func siteKey(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid base_url %q", baseURL)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
+
+// LoadCredentialStore loads all stored credentials keyed by site (scheme://host).
+// A missing store is empty.
+func LoadCredentialStore() (map[string]*Credentials, error) {
+	path := credentialsPath()
+
+	// Check file permissions (must be 0600 to protect the tokens)
+	fi, statErr := os.Stat(path)
+	if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
+		return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", path, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]*Credentials), nil
+		}
+		return nil, fmt.Errorf("failed to read credential store: %w", err)
+	}
+
+	store := make(map[string]*Credentials)
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse credential store %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// SaveCredentials stores the credentials for a Jira site, replacing any previous entry
+func SaveCredentials(baseURL string, creds *Credentials) error {
+	key, err := siteKey(baseURL)
+	if err != nil {
+		return err
+	}
+
+	store, err := LoadCredentialStore()
+	if err != nil {
+		return err
+	}
+	store[key] = creds
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential store: %w", err)
+	}
+	return writePrivateFile(credentialsPath(), data)
+}
+
+// AccountEmail returns the account email used for a project's Jira site, from
+// the config, the environment or the credential store, without running
+// credential commands. Empty if none is set.
+func AccountEmail(projectPath string) string {
+	config, err := ReadConfig(projectPath)
+	if err != nil {
+		return ""
+	}
+	if config.Email != "" {
+		return config.Email
+	}
+	if email := os.Getenv(EnvEmail); email != "" {
+		return email
+	}
+	key, err := siteKey(config.BaseURL)
+	if err != nil {
+		return ""
+	}
+	if store, err := LoadCredentialStore(); err == nil && store[key] != nil {
+		return store[key].Email
+	}
+	return ""
+}
+
+// ResolveCredentials fills in the secrets missing from config. Sources are
+// tried in order and never override a value that is already set:
+// environment variables, the credential store, then the store's credential_command.
+func ResolveCredentials(ctx context.Context, config *JiraConfig) error {
+	config.applyCredentials(&Credentials{
+		Email:        os.Getenv(EnvEmail),
+		APIToken:     os.Getenv(EnvAPIToken),
+		Token:        os.Getenv(EnvToken),
+		ClientSecret: os.Getenv(EnvClientSecret),
+	})
+	if !config.missingCredentials() {
+		return nil
+	}
+
+	key, err := siteKey(config.BaseURL)
+	if err != nil {
+		return err
+	}
+	store, err := LoadCredentialStore()
+	if err != nil {
+		return err
+	}
+	stored, ok := store[key]
+	if !ok {
+		return nil
+	}
+	config.applyCredentials(stored)
+
+	if stored.CredentialCommand == "" || !config.missingCredentials() {
+		return nil
+	}
+	fetched, err := runCredentialCommand(ctx, stored.CredentialCommand, config.BaseURL, config.AuthMode())
+	if err != nil {
+		return err
+	}
+	config.applyCredentials(fetched)
+	return nil
+}
+
+// hasSecrets reports whether the config carries secrets inline
+func (c *JiraConfig) hasSecrets() bool {
+	return c.APIToken != "" || c.Token != "" || (c.OAuth != nil && c.OAuth.ClientSecret != "")
+}
+
+// missingCredentials reports whether the auth mode still lacks a credential
+func (c *JiraConfig) missingCredentials() bool {
+	switch c.AuthMode() {
+	case AuthPAT:
+		return c.Token == ""
+	case AuthOAuth:
+		return c.OAuth == nil || c.OAuth.ClientSecret == ""
+	default:
+		return c.Email == "" || c.APIToken == ""
+	}
+}
+
+// applyCredentials copies credentials into the config's empty fields
+func (c *JiraConfig) applyCredentials(creds *Credentials) {
+	if c.Email == "" {
+		c.Email = creds.Email
+	}
+	if c.APIToken == "" {
+		c.APIToken = creds.APIToken
+	}
+	if c.Token == "" {
+		c.Token = creds.Token
+	}
+	if c.OAuth != nil && c.OAuth.ClientSecret == "" {
+		c.OAuth.ClientSecret = creds.ClientSecret
+	}
+}
+
+// runCredentialCommand runs a credential helper for the site and parses its
+// key=value output. A generic "password" is taken as the secret of the auth mode.
+func runCredentialCommand(ctx context.Context, command, baseURL, authMode string) (*Credentials, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base_url %q", baseURL)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CredentialCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\n\n", u.Scheme, u.Host))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > MaxErrorBodySize {
+			msg = msg[:MaxErrorBodySize]
+		}
+		return nil, fmt.Errorf("credential_command failed: %w: %s", err, msg)
+	}
+
+	creds := &Credentials{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username", "email":
+			creds.Email = value
+		case "api_token":
+			creds.APIToken = value
+		case "token":
+			creds.Token = value
+		case "client_secret":
+			creds.ClientSecret = value
+		case "password":
+			switch authMode {
+			case AuthPAT:
+				creds.Token = value
+			case AuthOAuth:
+				creds.ClientSecret = value
+			default:
+				creds.APIToken = value
+			}
+		}
+	}
+	return creds, nil
+}
+
+// writePrivateFile writes data to path with 0600 permissions, creating the
+// directory with 0700. Uses atomic write (temp file + rename) so readers never see a torn file.
+func writePrivateFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	// Ensure cleanup on any error
+	defer func() {
+		if tmpFile != nil {
+			tmpFile.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if err := tmpFile.Chmod(0600); err != nil {
+		return fmt.Errorf("failed to set temp file permissions: %w", err)
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	// Success - prevent cleanup from removing the file
+	tmpFile = nil
+	return nil
+}
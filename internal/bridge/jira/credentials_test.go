@@ -0,0 +1,158 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeProjectConfig writes .takl/jira.json with the given mode and returns the project path
+func writeProjectConfig(t *testing.T, body string, mode os.FileMode) string {
+	t.Helper()
+	projectPath := t.TempDir()
+	dir := filepath.Join(projectPath, ".takl")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "jira.json"), []byte(body), mode); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return projectPath
+}
+
+// isolateCredentials points the state directory at a temp dir and clears credential env vars
+func isolateCredentials(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	for _, env := range []string{EnvEmail, EnvAPIToken, EnvToken, EnvClientSecret} {
+		t.Setenv(env, "")
+	}
+}
+
+const committableConfig = `{"base_url": "https://example.atlassian.net", "project": "PROJ"}`
+
+// TestLoadConfig_CredentialStore tests that secrets come from the store and
+// the secret-free project file may be world-readable
+func TestLoadConfig_CredentialStore(t *testing.T) {
+	isolateCredentials(t)
+	projectPath := writeProjectConfig(t, committableConfig, 0644)
+
+	if _, err := LoadConfig(projectPath); err == nil || !strings.Contains(err.Error(), "takl jira credentials set") {
+		t.Errorf("Expected missing credentials error, got %v", err)
+	}
+
+	err := SaveCredentials("https://EXAMPLE.atlassian.net/", &Credentials{Email: "me@example.com", APIToken: "stored"})
+	if err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+
+	config, err := LoadConfig(projectPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Email != "me@example.com" || config.APIToken != "stored" {
+		t.Errorf("Expected stored credentials, got %s", config)
+	}
+
+	fi, err := os.Stat(credentialsPath())
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Errorf("Expected credential store mode 0600, got %o", fi.Mode().Perm())
+	}
+}
+
+// TestAccountEmail tests that the account email comes from the environment or
+// the credential store without a credential command
+func TestAccountEmail(t *testing.T) {
+	isolateCredentials(t)
+	projectPath := writeProjectConfig(t, committableConfig, 0644)
+
+	if got := AccountEmail(projectPath); got != "" {
+		t.Errorf("Expected no email, got %q", got)
+	}
+
+	if err := SaveCredentials("https://example.atlassian.net", &Credentials{Email: "stored@example.com"}); err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+	if got := AccountEmail(projectPath); got != "stored@example.com" {
+		t.Errorf("Expected the stored email, got %q", got)
+	}
+
+	t.Setenv(EnvEmail, "env@example.com")
+	if got := AccountEmail(projectPath); got != "env@example.com" {
+		t.Errorf("Expected the email from the environment, got %q", got)
+	}
+}
+
+// TestLoadConfig_EnvOverridesStore tests that environment variables win over the store
+func TestLoadConfig_EnvOverridesStore(t *testing.T) {
+	isolateCredentials(t)
+	projectPath := writeProjectConfig(t, committableConfig, 0644)
+	if err := SaveCredentials("https://example.atlassian.net", &Credentials{Email: "stored@example.com", APIToken: "stored"}); err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+	t.Setenv(EnvAPIToken, "from-env")
+
+	config, err := LoadConfig(projectPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.APIToken != "from-env" || config.Email != "stored@example.com" {
+		t.Errorf("Expected env token and stored email, got %s", config)
+	}
+}
+
+// TestLoadConfig_CredentialCommand tests fetching credentials from a git-style helper
+func TestLoadConfig_CredentialCommand(t *testing.T) {
+	isolateCredentials(t)
+	projectPath := writeProjectConfig(t, `{"base_url": "https://jira.example.com", "project": "PROJ", "auth": "pat", "api_version": "2"}`, 0644)
+
+	// The helper echoes the host it was asked for, so the test sees the protocol input
+	helper := `while IFS== read -r k v; do [ "$k" = host ] && h=$v; done; echo "password=pat-for-$h"`
+	if err := SaveCredentials("https://jira.example.com", &Credentials{CredentialCommand: helper}); err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+
+	config, err := LoadConfig(projectPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Token != "pat-for-jira.example.com" {
+		t.Errorf("Expected token from credential_command, got %q", config.Token)
+	}
+}
+
+// TestLoadConfig_CredentialCommandFails tests that helper failures surface with stderr
+func TestLoadConfig_CredentialCommandFails(t *testing.T) {
+	isolateCredentials(t)
+	projectPath := writeProjectConfig(t, committableConfig, 0644)
+	if err := SaveCredentials("https://example.atlassian.net", &Credentials{CredentialCommand: "echo locked >&2; exit 1"}); err != nil {
+		t.Fatalf("SaveCredentials failed: %v", err)
+	}
+
+	if _, err := LoadConfig(projectPath); err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Errorf("Expected credential_command error, got %v", err)
+	}
+}
+
+// TestReadConfig_InlineSecretsRequirePrivateFile tests that legacy inline
+// tokens are still accepted, but only from a 0600 file
+func TestReadConfig_InlineSecretsRequirePrivateFile(t *testing.T) {
+	isolateCredentials(t)
+	body := `{"base_url": "https://example.atlassian.net", "project": "PROJ", "email": "me@example.com", "api_token": "inline"}`
+
+	if _, err := ReadConfig(writeProjectConfig(t, body, 0644)); err == nil || !strings.Contains(err.Error(), "insecure permissions") {
+		t.Errorf("Expected insecure permissions error, got %v", err)
+	}
+
+	config, err := LoadConfig(writeProjectConfig(t, body, 0600))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.APIToken != "inline" {
+		t.Errorf("Expected inline token, got %q", config.APIToken)
+	}
+}
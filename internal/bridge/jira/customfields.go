@@ -0,0 +1,348 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// FieldInfo describes a Jira field as listed by GET /rest/api/3/field
+type FieldInfo struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Custom bool   `json:"custom"`
+	// Type and Items are Jira's schema type, e.g. "number", "option", "user",
+	// "date", or "array" with Items "option"
+	Type  string `json:"type,omitempty"`
+	Items string `json:"items,omitempty"`
+	// Mapped is the custom_fields name the field is mapped to in the project config
+	Mapped string `json:"mapped,omitempty"`
+}
+
+// Schema returns the field's schema type, e.g. "number" or "array<option>"
+func (f *FieldInfo) Schema() string {
+	if f.Type == "array" {
+		return "array<" + f.Items + ">"
+	}
+	return f.Type
+}
+
+// Supported reports whether takl can convert values of the field
+func (f *FieldInfo) Supported() bool {
+	switch f.Schema() {
+	case "number", "string", "date", "datetime", "option", "user",
+		"array<option>", "array<string>", "array<user>":
+		return true
+	}
+	return false
+}
+
+// jiraFieldResponse represents a field from GET /rest/api/3/field
+//
+// Example response:
+//
+//	[{
+//	  "id": "customfield_10016",
+//	  "name": "Story point estimate",
+//	  "custom": true,
+//	  "schema": {
+//	    "type": "number",
+//	    "custom": "com.pyxis.greenhopper.jira:jsw-story-points",
+//	    "customId": 10016
+//	  }
+//	}]
+type jiraFieldResponse struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Custom bool   `json:"custom"`
+	Schema *struct {
+		Type  string `json:"type"`
+		Items string `json:"items"`
+	} `json:"schema"`
+}
+
+// FetchFields fetches the metadata of all system and custom fields
+func (c *Client) FetchFields(ctx context.Context) ([]*FieldInfo, error) {
+	log.Printf("[DEBUG] FetchFields: Fetching field metadata")
+
+	resp, err := c.doRequest(ctx, "GET", c.apiPath("/field"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fields: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var fields []jiraFieldResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxSearchResponseSize)).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to decode fields response: %w", err)
+	}
+
+	out := make([]*FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		info := &FieldInfo{ID: f.ID, Name: f.Name, Custom: f.Custom}
+		if f.Schema != nil {
+			info.Type = f.Schema.Type
+			info.Items = f.Schema.Items
+		}
+		out = append(out, info)
+	}
+	return out, nil
+}
+
+// customField is a custom_fields entry resolved against the site's field metadata
+type customField struct {
+	name string // Key in the issue's custom frontmatter map
+	info *FieldInfo
+}
+
+// resolveCustomFields looks up the fields mapped in custom_fields (by ID or
+// field name) and stores them on the client for conversion. Must be called
+// before the client is shared between workers.
+func (c *Client) resolveCustomFields(ctx context.Context, mapping map[string]string) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+
+	fields, err := c.FetchFields(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*FieldInfo, len(fields))
+	byName := make(map[string]*FieldInfo, len(fields))
+	for _, f := range fields {
+		byID[f.ID] = f
+		byName[strings.ToLower(f.Name)] = f
+	}
+
+	resolved := make([]customField, 0, len(mapping))
+	for name, ref := range mapping {
+		info, ok := byID[ref]
+		if !ok {
+			info, ok = byName[strings.ToLower(ref)]
+		}
+		if !ok {
+			return fmt.Errorf("custom field %q: no Jira field %q (run 'takl jira fields' to list them)", name, ref)
+		}
+		if !info.Supported() {
+			return fmt.Errorf("custom field %q: unsupported field type %q of %s", name, info.Schema(), info.ID)
+		}
+		resolved = append(resolved, customField{name: name, info: info})
+	}
+
+	// Deterministic order for field lists and logs
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].name < resolved[j].name })
+	c.customFields = resolved
+	return nil
+}
+
+// decodeCustomField converts a raw Jira field value to its frontmatter form:
+// a string, a number or a list of strings. Returns nil for empty values.
+func (c *Client) decodeCustomField(f *FieldInfo, raw json.RawMessage, cache *issue.MemberCache) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	switch f.Schema() {
+	case "number":
+		var n float64
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case "string":
+		// Multi-line text fields are ADF on Cloud
+		if raw[0] == '{' {
+			return c.toMarkdown(raw)
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "date", "datetime":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "option":
+		var option jiraOption
+		if err := json.Unmarshal(raw, &option); err != nil {
+			return nil, err
+		}
+		return option.Value, nil
+	case "user":
+		var user jiraUser
+		if err := json.Unmarshal(raw, &user); err != nil {
+			return nil, err
+		}
+		return formatMember(user, cache), nil
+	case "array<option>":
+		var options []jiraOption
+		if err := json.Unmarshal(raw, &options); err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(options))
+		for _, option := range options {
+			values = append(values, option.Value)
+		}
+		return emptyAsNil(values), nil
+	case "array<string>":
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, err
+		}
+		return emptyAsNil(values), nil
+	case "array<user>":
+		var users []jiraUser
+		if err := json.Unmarshal(raw, &users); err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(users))
+		for _, user := range users {
+			values = append(values, formatMember(user, cache))
+		}
+		return emptyAsNil(values), nil
+	}
+	return nil, fmt.Errorf("unsupported field type %q", f.Schema())
+}
+
+// encodeCustomField converts a frontmatter value to the Jira representation
+// of the field. A nil value clears the field.
+func (c *Client) encodeCustomField(f *FieldInfo, value interface{}, cache *issue.MemberCache) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch f.Schema() {
+	case "number":
+		switch n := value.(type) {
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		}
+		return nil, fmt.Errorf("expected a number, got %v", value)
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %v", value)
+		}
+		if c.isServer() {
+			return s, nil
+		}
+		// Multi-line text fields need ADF on Cloud; single-line ones take plain strings
+		if strings.Contains(s, "\n") {
+			return c.fromMarkdown(s)
+		}
+		return s, nil
+	case "date", "datetime":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a date string, got %v", value)
+		}
+		return s, nil
+	case "option":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an option value, got %v", value)
+		}
+		return jiraOption{Value: s}, nil
+	case "user":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a user, got %v", value)
+		}
+		return c.userRef(s, cache)
+	}
+
+	values, err := stringList(value)
+	if err != nil {
+		return nil, err
+	}
+	switch f.Schema() {
+	case "array<option>":
+		options := make([]jiraOption, 0, len(values))
+		for _, v := range values {
+			options = append(options, jiraOption{Value: v})
+		}
+		return options, nil
+	case "array<string>":
+		return values, nil
+	case "array<user>":
+		users := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			ref, err := c.userRef(v, cache)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, ref)
+		}
+		return users, nil
+	}
+	return nil, fmt.Errorf("unsupported field type %q", f.Schema())
+}
+
+// jiraOption is a select list option
+type jiraOption struct {
+	Value string `json:"value"`
+}
+
+// userRef resolves a user string through the member cache to the reference
+// Jira expects: the account ID on Cloud, the username on Data Center/Server
+func (c *Client) userRef(user string, cache *issue.MemberCache) (map[string]string, error) {
+	member, err := cache.ParseUser(user)
+	if err != nil {
+		return nil, fmt.Errorf("%w (run 'takl jira members' to refresh the cache)", err)
+	}
+	if c.isServer() {
+		return map[string]string{"name": member.AccountID}, nil
+	}
+	return map[string]string{"accountId": member.AccountID}, nil
+}
+
+// formatMember formats a user as "Display Name <email>" when cached, else the display name
+func formatMember(user jiraUser, cache *issue.MemberCache) string {
+	if cache != nil {
+		if member := cache.FindByAccountID(user.id()); member != nil {
+			return member.FormatMember()
+		}
+	}
+	return user.DisplayName
+}
+
+// stringList converts a decoded list value ([]string, or []interface{} from YAML) to strings
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %v", value)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	case string:
+		return []string{v}, nil
+	}
+	return nil, fmt.Errorf("expected a list of strings, got %v", value)
+}
+
+// emptyAsNil sorts a list for stable hashing and returns nil for an empty
+// list so it is left out of the frontmatter
+func emptyAsNil(values []string) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Strings(values)
+	return values
+}
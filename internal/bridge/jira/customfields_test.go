@@ -0,0 +1,126 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// customFieldsFixture returns field metadata covering each supported converter
+func customFieldsFixture() []map[string]interface{} {
+	field := func(id, name, typ, items string) map[string]interface{} {
+		schema := map[string]string{"type": typ}
+		if items != "" {
+			schema["items"] = items
+		}
+		return map[string]interface{}{"id": id, "name": name, "custom": true, "schema": schema}
+	}
+	return []map[string]interface{}{
+		{"id": "summary", "name": "Summary", "custom": false, "schema": map[string]string{"type": "string"}},
+		field("customfield_1", "Effort", "number", ""),
+		field("customfield_2", "Severity", "option", ""),
+		field("customfield_3", "Teams", "array", "option"),
+		field("customfield_4", "Reviewer", "user", ""),
+		field("customfield_5", "Launch date", "date", ""),
+		field("customfield_6", "Rank", "any", ""),
+	}
+}
+
+// TestCustomFields_PullAndPush tests that mapped custom fields are converted
+// into the custom frontmatter map and that edits are pushed in Jira's format
+func TestCustomFields_PullAndPush(t *testing.T) {
+	f := newFakeJira(t)
+	f.fields = customFieldsFixture()
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Custom", fields: map[string]interface{}{
+		"customfield_1": 8,
+		"customfield_2": map[string]string{"value": "S2", "id": "100"},
+		"customfield_3": []map[string]string{{"value": "Web"}, {"value": "API"}},
+		"customfield_4": map[string]string{"accountId": "a-1", "displayName": "Ann"},
+		"customfield_5": nil,
+	}}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	if err := SaveMembersCache(storage.ProjectPath(), &issue.MemberCache{Members: map[string]*issue.Member{
+		"a-1": {AccountID: "a-1", DisplayName: "Ann", EmailAddress: "ann@example.com"},
+		"a-2": {AccountID: "a-2", DisplayName: "Bob", EmailAddress: "bob@example.com"},
+	}}); err != nil {
+		t.Fatalf("SaveMembersCache failed: %v", err)
+	}
+
+	config := &JiraConfig{Project: "PROJ", CustomFields: map[string]string{
+		"effort":   "customfield_1",
+		"severity": "Severity", // By field name
+		"teams":    "customfield_3",
+		"reviewer": "customfield_4",
+		"launch":   "customfield_5",
+	}}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	pulled, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	want := map[string]interface{}{
+		"effort":   8,
+		"severity": "S2",
+		"teams":    []interface{}{"API", "Web"},
+		"reviewer": "Ann <ann@example.com>",
+	}
+	if !reflect.DeepEqual(pulled.Custom, want) {
+		t.Errorf("Expected custom %v, got %v", want, pulled.Custom)
+	}
+	if pulled.Hash != storage.ComputeHash(pulled) {
+		t.Errorf("Expected the hash to survive the frontmatter round trip")
+	}
+
+	pulled.Custom["effort"] = 13
+	pulled.Custom["teams"] = []interface{}{"API"}
+	pulled.Custom["reviewer"] = "bob@example.com"
+	pulled.Custom["launch"] = "2025-06-01"
+	delete(pulled.Custom, "severity")
+	if err := storage.WriteIssue(pulled); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "PROJ-1")
+	if err != nil || result.Pushed != 1 {
+		t.Fatalf("Push failed: %v (result: %+v)", err, result)
+	}
+
+	got := fmt.Sprint(f.lastUpdate)
+	wantUpdate := fmt.Sprint(map[string]interface{}{
+		"customfield_1": float64(13),
+		"customfield_2": nil,
+		"customfield_3": []interface{}{map[string]interface{}{"value": "API"}},
+		"customfield_4": map[string]interface{}{"accountId": "a-2"},
+		"customfield_5": "2025-06-01",
+	})
+	if got != wantUpdate {
+		t.Errorf("Expected update %s, got %s", wantUpdate, got)
+	}
+}
+
+// TestCustomFields_ResolveErrors tests that unknown and unsupported mappings are rejected
+func TestCustomFields_ResolveErrors(t *testing.T) {
+	f := newFakeJira(t)
+	f.fields = customFieldsFixture()
+
+	for name, mapping := range map[string]map[string]string{
+		"unknown":     {"missing": "customfield_404"},
+		"unsupported": {"rank": "customfield_6"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := f.client().resolveCustomFields(context.Background(), mapping); err == nil {
+				t.Errorf("Expected error for mapping %v", mapping)
+			}
+		})
+	}
+}
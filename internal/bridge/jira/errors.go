@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned for Jira responses with a status of 400 or above.
+//
+// Jira reports failures as {"errorMessages": [...], "errors": {"field": "message"}};
+// both are parsed when present. Body holds the raw (truncated) response otherwise.
+type APIError struct {
+	StatusCode    int
+	ErrorMessages []string
+	Errors        map[string]string
+	Body          string
+}
+
+// newAPIError builds an APIError from a response status and body
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status, Body: string(body)}
+
+	var parsed struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		apiErr.ErrorMessages = parsed.ErrorMessages
+		apiErr.Errors = parsed.Errors
+	}
+	return apiErr
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	messages := make([]string, 0, len(e.ErrorMessages)+len(e.Errors))
+	messages = append(messages, e.ErrorMessages...)
+
+	// Field errors in a stable order
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+e.Errors[field])
+	}
+
+	if len(messages) == 0 {
+		return fmt.Sprintf("jira API error %d: %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("jira API error %d: %s", e.StatusCode, strings.Join(messages, "; "))
+}
+
+// statusOf returns the status code of a wrapped APIError, or 0 if err isn't one
+func statusOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a Jira 404 (missing issue or project, or no permission to see it)
+func IsNotFound(err error) bool {
+	return statusOf(err) == http.StatusNotFound
+}
+
+// IsAuth reports whether err is a Jira 401 (bad email or API token)
+func IsAuth(err error) bool {
+	return statusOf(err) == http.StatusUnauthorized
+}
+
+// IsPermission reports whether err is a Jira 403 (authenticated but not allowed)
+func IsPermission(err error) bool {
+	return statusOf(err) == http.StatusForbidden
+}
+
+// IsRateLimited reports whether err is a Jira 429 that outlasted the client's retries
+func IsRateLimited(err error) bool {
+	return statusOf(err) == http.StatusTooManyRequests
+}
@@ -0,0 +1,82 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestAPIError_Parse tests that Jira error bodies are parsed into the error
+func TestAPIError_Parse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "error messages",
+			body: `{"errorMessages":["Issue does not exist or you do not have permission to see it."],"errors":{}}`,
+			want: "jira API error 400: Issue does not exist or you do not have permission to see it.",
+		},
+		{
+			name: "field errors",
+			body: `{"errorMessages":[],"errors":{"summary":"You must specify a summary.","labels":"Invalid label."}}`,
+			want: "jira API error 400: labels: Invalid label.; summary: You must specify a summary.",
+		},
+		{
+			name: "plain body",
+			body: `Bad Request`,
+			want: "jira API error 400: Bad Request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newAPIError(http.StatusBadRequest, []byte(tt.body)).Error(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAPIError_Checks tests that the status checks see through wrapped errors
+func TestAPIError_Checks(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusUnauthorized, IsAuth},
+		{http.StatusForbidden, IsPermission},
+		{http.StatusTooManyRequests, IsRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			statuses := []int{tt.status}
+			if tt.status == http.StatusTooManyRequests {
+				// Exhaust the retries so the 429 reaches the caller
+				statuses = make([]int, MaxRetries+1)
+				for i := range statuses {
+					statuses[i] = tt.status
+				}
+			}
+			s := newScriptedServer(t, `{"errorMessages":["nope"]}`, statuses...)
+			var delays []time.Duration
+			c := testClient(s, &delays)
+
+			_, err := c.GetIssue(context.Background(), "PROJ-1", nil)
+			if err == nil {
+				t.Fatalf("Expected error")
+			}
+			if !tt.check(err) {
+				t.Errorf("Expected check to match %v", err)
+			}
+			if IsNotFound(fmt.Errorf("unrelated")) {
+				t.Errorf("Expected plain errors not to match")
+			}
+		})
+	}
+}
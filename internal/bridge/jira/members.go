@@ -5,31 +5,33 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/gurisko/takl/internal/issue"
 )
 
 const membersCacheFilename = "jira-members.json"
 
 // LoadMembersCache loads the members cache from .takl/jira-members.json
-func LoadMembersCache(projectPath string) (*MemberCache, error) {
+func LoadMembersCache(projectPath string) (*issue.MemberCache, error) {
 	cachePath := filepath.Join(projectPath, ".takl", membersCacheFilename)
 
 	data, err := os.ReadFile(cachePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Cache doesn't exist yet, return empty cache
-			return NewMemberCache(), nil
+			return issue.NewMemberCache(), nil
 		}
 		return nil, fmt.Errorf("failed to read members cache: %w", err)
 	}
 
-	var cache MemberCache
+	var cache issue.MemberCache
 	if err := json.Unmarshal(data, &cache); err != nil {
 		return nil, fmt.Errorf("failed to parse members cache: %w", err)
 	}
 
 	// Initialize the map if it's nil
 	if cache.Members == nil {
-		cache.Members = make(map[string]*Member)
+		cache.Members = make(map[string]*issue.Member)
 	}
 
 	return &cache, nil
@@ -37,7 +39,7 @@ func LoadMembersCache(projectPath string) (*MemberCache, error) {
 
 // SaveMembersCache saves the members cache to .takl/jira-members.json
 // Uses atomic write (temp file + rename) to prevent corruption
-func SaveMembersCache(projectPath string, cache *MemberCache) error {
+func SaveMembersCache(projectPath string, cache *issue.MemberCache) error {
 	taklDir := filepath.Join(projectPath, ".takl")
 
 	// Ensure .takl directory exists with restrictive permissions (contains PII)
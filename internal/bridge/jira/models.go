@@ -58,34 +58,206 @@ import (
 //	  }
 //	}
 //
-// Note: description can be null or ADF object (as shown)
+// Note: description can be null or ADF object (as shown).
+// Data Center/Server (API v2) returns description and comment bodies as wiki
+// markup strings, and identifies users by "name" instead of "accountId".
 type jiraIssueResponse struct {
-	ID       string `json:"id"`
-	Key      string `json:"key"`
-	Archived bool   `json:"archived,omitempty"`
-	Fields   struct {
-		Summary string `json:"summary"`
-		// API v3 uses ADF (Atlassian Document Format) for rich text
-		Description json.RawMessage `json:"description"`
-		Status      struct {
-			Name string `json:"name"`
-		} `json:"status"`
-		Assignee *struct {
-			AccountID   string `json:"accountId"`
-			DisplayName string `json:"displayName"`
-		} `json:"assignee"`
-		Reporter struct {
-			AccountID   string `json:"accountId"`
-			DisplayName string `json:"displayName"`
-		} `json:"reporter"`
-		Created jiraTime `json:"created"`
-		Updated jiraTime `json:"updated"`
-		Labels  []string `json:"labels"`
-		Comment struct {
-			Comments []jiraComment `json:"comments"`
-		} `json:"comment"`
-		Attachment []jiraAttachment `json:"attachment"`
-	} `json:"fields"`
+	ID       string          `json:"id"`
+	Key      string          `json:"key"`
+	Archived bool            `json:"archived,omitempty"`
+	Fields   jiraIssueFields `json:"fields"`
+}
+
+// jiraIssueFields are the fields of an issue. Custom fields such as sprint and
+// story points have site-specific IDs (customfield_NNNNN), so every field is
+// also kept raw in Custom.
+type jiraIssueFields struct {
+	Summary string `json:"summary"`
+	// API v3 uses ADF (Atlassian Document Format) for rich text
+	Description json.RawMessage `json:"description"`
+	Status      struct {
+		Name string `json:"name"`
+	} `json:"status"`
+	Assignee    *jiraUser        `json:"assignee"`
+	Reporter    jiraUser         `json:"reporter"`
+	Created     jiraTime         `json:"created"`
+	Updated     jiraTime         `json:"updated"`
+	Labels      []string         `json:"labels"`
+	Comment     jiraCommentPage  `json:"comment"`
+	Attachment  []jiraAttachment `json:"attachment"`
+	IssueType   *jiraNamed       `json:"issuetype"`
+	Priority    *jiraNamed       `json:"priority"`
+	Components  []jiraNamed      `json:"components"`
+	FixVersions []jiraNamed      `json:"fixVersions"`
+	DueDate     string           `json:"duedate"` // "2025-03-31" or null
+	Parent      *struct {
+		Key string `json:"key"`
+	} `json:"parent"`
+	IssueLinks []jiraIssueLink `json:"issuelinks"`
+	Subtasks   []struct {
+		Key string `json:"key"`
+	} `json:"subtasks"`
+	Worklog      jiraWorklogPage   `json:"worklog"`
+	TimeTracking *jiraTimeTracking `json:"timetracking"`
+
+	Custom map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and keeps all fields raw in Custom
+func (f *jiraIssueFields) UnmarshalJSON(b []byte) error {
+	type plain jiraIssueFields
+	if err := json.Unmarshal(b, (*plain)(f)); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &f.Custom)
+}
+
+// jiraIssueLink is a link as listed on one of its issues. Exactly one of
+// InwardIssue and OutwardIssue is set: the other end of the link.
+//
+// Example (on PROJ-1, meaning "PROJ-1 blocks PROJ-2"):
+//
+//	{
+//	  "id": "10001",
+//	  "type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+//	  "outwardIssue": {"key": "PROJ-2"}
+//	}
+type jiraIssueLink struct {
+	ID   string `json:"id"`
+	Type struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	InwardIssue *struct {
+		Key string `json:"key"`
+	} `json:"inwardIssue"`
+	OutwardIssue *struct {
+		Key string `json:"key"`
+	} `json:"outwardIssue"`
+}
+
+// jiraNamed is a reference to a named entity: issue type, priority, component or version
+type jiraNamed struct {
+	Name string `json:"name"`
+}
+
+// names returns the names of the referenced entities
+func names(refs []jiraNamed) []string {
+	if len(refs) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		out = append(out, ref.Name)
+	}
+	return out
+}
+
+// namedRefs builds the {"name": ...} references Jira expects when setting a list field
+func namedRefs(values []string) []jiraNamed {
+	refs := make([]jiraNamed, 0, len(values))
+	for _, v := range values {
+		refs = append(refs, jiraNamed{Name: v})
+	}
+	return refs
+}
+
+// parseSprint returns the name of the current sprint from the sprint custom field:
+// the active sprint if there is one, otherwise the last listed.
+//
+// Cloud returns objects ({"name": "Sprint 12", "state": "active"}); Data
+// Center/Server returns strings like
+// "com.atlassian.greenhopper.service.sprint.Sprint@1f[id=12,state=ACTIVE,name=Sprint 12,...]".
+func parseSprint(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var objects []struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(raw, &objects); err != nil {
+		var encoded []string
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return ""
+		}
+		for _, s := range encoded {
+			objects = append(objects, struct {
+				Name  string `json:"name"`
+				State string `json:"state"`
+			}{Name: sprintAttr(s, "name"), State: sprintAttr(s, "state")})
+		}
+	}
+
+	name := ""
+	for _, sprint := range objects {
+		if strings.EqualFold(sprint.State, "active") {
+			return sprint.Name
+		}
+		name = sprint.Name
+	}
+	return name
+}
+
+// sprintAttr extracts an attribute from a Server sprint string ("...[id=1,name=Sprint 1,...]")
+func sprintAttr(s, attr string) string {
+	_, rest, ok := strings.Cut(s, "["+attr+"=")
+	if !ok {
+		_, rest, ok = strings.Cut(s, ","+attr+"=")
+	}
+	if !ok {
+		return ""
+	}
+	// Values run to the next ",key=" or the closing bracket
+	end := len(rest)
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == ']' {
+			end = i
+			break
+		}
+		if rest[i] == ',' {
+			if eq := strings.IndexByte(rest[i:], '='); eq > 0 && !strings.ContainsAny(rest[i+1:i+eq], ", ") {
+				end = i
+				break
+			}
+		}
+	}
+	value := rest[:end]
+	if value == "<null>" {
+		return ""
+	}
+	return value
+}
+
+// parseStoryPoints decodes a numeric story points field; null or missing is 0
+func parseStoryPoints(raw json.RawMessage) float64 {
+	var points float64
+	if len(raw) > 0 && string(raw) != "null" {
+		_ = json.Unmarshal(raw, &points)
+	}
+	return points
+}
+
+// parseRank returns the rank from the rank custom field, a LexoRank string
+// such as "0|i0001b:" that sorts issues in board order
+func parseRank(raw json.RawMessage) string {
+	var rank string
+	if len(raw) > 0 && string(raw) != "null" {
+		_ = json.Unmarshal(raw, &rank)
+	}
+	return rank
+}
+
+// jiraCommentPage is a page of comments, as embedded in issue responses or
+// returned by the comment endpoint. Total counts all comments on the issue.
+type jiraCommentPage struct {
+	Comments []jiraComment `json:"comments"`
+	Total    int           `json:"total"`
+}
+
+// hasMoreComments reports whether the embedded comments are a truncated first page
+func (jr *jiraIssueResponse) hasMoreComments() bool {
+	return jr.Fields.Comment.Total > len(jr.Fields.Comment.Comments)
 }
 
 // jiraComment represents a comment on an issue
@@ -117,17 +289,67 @@ type jiraIssueResponse struct {
 //	  "updated": "2024-12-13T12:21:09.078+0100"
 //	}
 type jiraComment struct {
-	ID     string `json:"id"`
-	Author struct {
-		AccountID   string `json:"accountId"`
-		DisplayName string `json:"displayName"`
-	} `json:"author"`
+	ID     string   `json:"id"`
+	Author jiraUser `json:"author"`
 	// API v3 uses ADF (Atlassian Document Format) for rich text
 	Body    json.RawMessage `json:"body"`
 	Created jiraTime        `json:"created"`
 	Updated jiraTime        `json:"updated"`
 }
 
+// jiraWorklogPage is a page of worklogs, as embedded in issue responses or
+// returned by the worklog endpoint. Total counts all worklogs on the issue.
+type jiraWorklogPage struct {
+	Worklogs []jiraWorklog `json:"worklogs"`
+	Total    int           `json:"total"`
+}
+
+// hasMoreWorklogs reports whether the embedded worklogs are a truncated first page
+func (jr *jiraIssueResponse) hasMoreWorklogs() bool {
+	return jr.Fields.Worklog.Total > len(jr.Fields.Worklog.Worklogs)
+}
+
+// jiraWorklog represents time logged on an issue
+//
+// Example worklog (the comment is ADF, or wiki markup on Server, and optional):
+//
+//	{
+//	  "id": "100028",
+//	  "author": {"accountId": "5b10a2844c20165700ede21g", "displayName": "Mia Krystof"},
+//	  "comment": {"type": "doc", "version": 1, "content": [...]},
+//	  "started": "2021-01-17T12:34:00.000+0000",
+//	  "timeSpent": "3h 20m",
+//	  "timeSpentSeconds": 12000
+//	}
+type jiraWorklog struct {
+	ID               string          `json:"id"`
+	Author           jiraUser        `json:"author"`
+	Comment          json.RawMessage `json:"comment"`
+	Started          jiraTime        `json:"started"`
+	TimeSpentSeconds int             `json:"timeSpentSeconds"`
+}
+
+// jiraTimeTracking holds an issue's estimates as Jira duration strings ("2d 4h")
+type jiraTimeTracking struct {
+	OriginalEstimate  string `json:"originalEstimate,omitempty"`
+	RemainingEstimate string `json:"remainingEstimate,omitempty"`
+}
+
+// jiraUser is a user reference on an issue or comment
+type jiraUser struct {
+	AccountID   string `json:"accountId"`
+	Name        string `json:"name"` // Username on Data Center/Server, which has no account IDs
+	DisplayName string `json:"displayName"`
+}
+
+// id returns the Cloud account ID, or the username on Data Center/Server
+func (u jiraUser) id() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
+}
+
 type jiraAttachment struct {
 	ID       string   `json:"id"`
 	Filename string   `json:"filename"`
@@ -137,6 +359,9 @@ type jiraAttachment struct {
 	Created  jiraTime `json:"created"`
 }
 
+// jiraTimeFormat is the timestamp format Jira expects in requests, e.g. worklog start times
+const jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+
 // jiraTime is a custom type to handle Jira's timestamp format
 type jiraTime struct {
 	time.Time
@@ -179,7 +404,9 @@ func (jt *jiraTime) UnmarshalJSON(b []byte) error {
 //	}
 //
 // The nextPageToken is used for pagination - include it in the next request
-// instead of using traditional startAt/maxResults (deprecated in API v3)
+// instead of using traditional startAt/maxResults (deprecated in API v3).
+// Data Center/Server only supports POST /rest/api/2/search, paginated with
+// startAt and bounded by total.
 type jiraSearchResponse struct {
 	Issues        []jiraIssueResponse `json:"issues"`
 	Total         int                 `json:"total"`
@@ -198,11 +425,20 @@ type jiraSearchResponse struct {
 //	}
 type jiraUserResponse struct {
 	AccountID    string `json:"accountId"`
+	Name         string `json:"name"` // Data Center/Server only
 	DisplayName  string `json:"displayName"`
 	EmailAddress string `json:"emailAddress"`
 	Active       bool   `json:"active"`
 }
 
+// id returns the Cloud account ID, or the username on Data Center/Server
+func (u jiraUserResponse) id() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
+}
+
 // jiraProjectStatusesResponse represents the response from GET /rest/api/3/project/{projectKey}/statuses
 //
 // Example response:
@@ -267,3 +503,17 @@ type jiraTransitionsResponse struct {
 		} `json:"to"`
 	} `json:"transitions"`
 }
+
+// jiraCreatedIssue represents the response of POST /rest/api/3/issue
+//
+// Example response:
+//
+//	{
+//	  "id": "10042",
+//	  "key": "PROJ-24",
+//	  "self": "https://your-domain.atlassian.net/rest/api/3/issue/10042"
+//	}
+type jiraCreatedIssue struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
@@ -0,0 +1,371 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gurisko/takl/internal/paths"
+)
+
+// oauthEndpoints are the Atlassian OAuth 2.0 (3LO) endpoints; replaced in tests
+var oauthEndpoints = struct {
+	Authorize string
+	Token     string
+	Resources string
+	Gateway   string // API requests go to Gateway + cloud ID
+}{
+	Authorize: "https://auth.atlassian.com/authorize",
+	Token:     "https://auth.atlassian.com/oauth/token",
+	Resources: "https://api.atlassian.com/oauth/token/accessible-resources",
+	Gateway:   "https://api.atlassian.com/ex/jira/",
+}
+
+// oauthScopes are requested at login; offline_access grants the refresh token
+var oauthScopes = []string{"read:jira-work", "write:jira-work", "read:jira-user", "offline_access"}
+
+// ErrNotLoggedIn is returned when OAuth is configured but no token is stored
+var ErrNotLoggedIn = errors.New("not logged in to Jira; run 'takl jira login'")
+
+// OAuthToken is the persisted result of an OAuth login
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"`
+	CloudID      string    `json:"cloud_id"` // Atlassian site ID used to route API requests
+	SiteURL      string    `json:"site_url"`
+}
+
+// expired reports whether the access token is expired or about to expire
+func (t *OAuthToken) expired(now time.Time) bool {
+	return !now.Before(t.Expiry.Add(-time.Minute))
+}
+
+// tokenPath returns where the OAuth token for a Jira site is stored.
+// Tokens belong to the user rather than the project, so they live in the
+// state directory and are shared by every project on the same site.
+func tokenPath(siteURL string) (string, error) {
+	u, err := url.Parse(siteURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid base_url %q", siteURL)
+	}
+	name := strings.ReplaceAll(strings.ToLower(u.Host), ":", "_") + ".json"
+	return filepath.Join(paths.DefaultStateDir(), "jira-oauth", name), nil
+}
+
+// LoadOAuthToken loads the stored OAuth token for a Jira site.
+// Returns ErrNotLoggedIn if there is none.
+func LoadOAuthToken(siteURL string) (*OAuthToken, error) {
+	path, err := tokenPath(siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check file permissions (must be 0600 to protect the refresh token)
+	fi, statErr := os.Stat(path)
+	if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
+		return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", path, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotLoggedIn
+		}
+		return nil, fmt.Errorf("failed to read oauth token: %w", err)
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse oauth token: %w", err)
+	}
+	if token.RefreshToken == "" || token.CloudID == "" {
+		return nil, fmt.Errorf("oauth token at %s is incomplete; run 'takl jira login' again", path)
+	}
+	return &token, nil
+}
+
+// SaveOAuthToken stores the OAuth token for its site with 0600 permissions.
+// Uses atomic write (temp file + rename) so a refresh never leaves a torn file.
+func SaveOAuthToken(token *OAuthToken) error {
+	path, err := tokenPath(token.SiteURL)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal oauth token: %w", err)
+	}
+	return writePrivateFile(path, data)
+}
+
+// oauthAuth authenticates with an OAuth access token, refreshing it when it expires.
+// Atlassian rotates refresh tokens, so every refresh is persisted immediately.
+type oauthAuth struct {
+	mu         sync.Mutex
+	config     *OAuthConfig
+	token      *OAuthToken
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func newOAuthAuth(config *OAuthConfig, token *OAuthToken) *oauthAuth {
+	return &oauthAuth{
+		config:     config,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		now:        time.Now,
+	}
+}
+
+// Authorize implements Authenticator
+func (a *oauthAuth) Authorize(ctx context.Context, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token.expired(a.now()) {
+		log.Printf("[DEBUG] oauthAuth: Access token expired, refreshing")
+		refreshed, err := requestToken(ctx, a.httpClient, map[string]string{
+			"grant_type":    "refresh_token",
+			"client_id":     a.config.ClientID,
+			"client_secret": a.config.ClientSecret,
+			"refresh_token": a.token.RefreshToken,
+		}, a.now())
+		if err != nil {
+			return fmt.Errorf("failed to refresh oauth token (run 'takl jira login' if this persists): %w", err)
+		}
+		refreshed.CloudID = a.token.CloudID
+		refreshed.SiteURL = a.token.SiteURL
+		if refreshed.RefreshToken == "" {
+			refreshed.RefreshToken = a.token.RefreshToken
+		}
+		if err := SaveOAuthToken(refreshed); err != nil {
+			return fmt.Errorf("failed to save refreshed oauth token: %w", err)
+		}
+		a.token = refreshed
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token.AccessToken)
+	return nil
+}
+
+// requestToken posts a grant to the token endpoint
+func requestToken(ctx context.Context, client *http.Client, grant map[string]string, now time.Time) (*OAuthToken, error) {
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oauthEndpoints.Token, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodySize))
+		return nil, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tr struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxJSONPayloadSize)).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tr.AccessToken == "" {
+		return nil, errors.New("token response has no access_token")
+	}
+
+	return &OAuthToken{
+		AccessToken:  tr.AccessToken,
+		RefreshToken: tr.RefreshToken,
+		Expiry:       now.Add(time.Duration(tr.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// fetchCloudID finds the Atlassian cloud ID of the site the token grants access to
+func fetchCloudID(ctx context.Context, client *http.Client, accessToken, siteURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, oauthEndpoints.Resources, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch accessible resources: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodySize))
+		return "", fmt.Errorf("accessible resources returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var resources []struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxJSONPayloadSize)).Decode(&resources); err != nil {
+		return "", fmt.Errorf("failed to decode accessible resources: %w", err)
+	}
+
+	want := strings.TrimSuffix(strings.ToLower(siteURL), "/")
+	for _, r := range resources {
+		if strings.TrimSuffix(strings.ToLower(r.URL), "/") == want {
+			return r.ID, nil
+		}
+	}
+	return "", fmt.Errorf("the authorized account has no access to %s", siteURL)
+}
+
+// Login runs the OAuth 2.0 authorization code flow with a loopback redirect
+// and stores the resulting token. open is called with the URL the user must
+// visit; the flow waits for the browser to hit the callback or ctx to end.
+// Callbacks with a foreign state are refused without ending the flow.
+func Login(ctx context.Context, config *JiraConfig, open func(authURL string) error) (*OAuthToken, error) {
+	if config.OAuth == nil {
+		return nil, errors.New("jira config has no oauth section")
+	}
+	port := config.OAuth.RedirectPort
+	if port == 0 {
+		port = DefaultRedirectPort
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the oauth callback on port %d: %w", port, err)
+	}
+	return loginWithListener(ctx, config, listener, open)
+}
+
+// loginWithListener runs the login flow with the callback served on listener
+func loginWithListener(ctx context.Context, config *JiraConfig, listener net.Listener, open func(authURL string) error) (*OAuthToken, error) {
+	defer listener.Close()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	redirectURI := fmt.Sprintf("http://localhost:%d/callback", port)
+
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	state := hex.EncodeToString(stateBytes)
+
+	params := url.Values{}
+	params.Set("audience", "api.atlassian.com")
+	params.Set("client_id", config.OAuth.ClientID)
+	params.Set("scope", strings.Join(oauthScopes, " "))
+	params.Set("redirect_uri", redirectURI)
+	params.Set("state", state)
+	params.Set("response_type", "code")
+	params.Set("prompt", "consent")
+	authURL := oauthEndpoints.Authorize + "?" + params.Encode()
+
+	type callback struct {
+		code string
+		err  error
+	}
+	results := make(chan callback, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			// Not our redirect: keep waiting for the real one
+			http.Error(w, "Login failed: oauth callback state mismatch", http.StatusBadRequest)
+			return
+		}
+
+		var cb callback
+		switch {
+		case q.Get("error") != "":
+			cb.err = fmt.Errorf("authorization denied: %s %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("code") == "":
+			cb.err = errors.New("oauth callback has no code")
+		default:
+			cb.code = q.Get("code")
+		}
+
+		if cb.err != nil {
+			http.Error(w, "Login failed: "+cb.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Logged in to Jira. You can close this window and return to the terminal.")
+		}
+
+		select {
+		case results <- cb:
+		default: // Only the first callback counts
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	if err := open(authURL); err != nil {
+		return nil, err
+	}
+
+	var cb callback
+	select {
+	case cb = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if cb.err != nil {
+		return nil, cb.err
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	token, err := requestToken(ctx, httpClient, map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     config.OAuth.ClientID,
+		"client_secret": config.OAuth.ClientSecret,
+		"code":          cb.code,
+		"redirect_uri":  redirectURI,
+	}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	if token.RefreshToken == "" {
+		return nil, errors.New("no refresh token was issued; make sure the app allows the offline_access scope")
+	}
+
+	cloudID, err := fetchCloudID(ctx, httpClient, token.AccessToken, config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	token.CloudID = cloudID
+	token.SiteURL = config.BaseURL
+
+	if err := SaveOAuthToken(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,35 @@
+package jira
+
+import "sync"
+
+// forEach calls fn for every index in [0, n) using up to workers goroutines.
+//
+// Callers collect results into a slice indexed by i so aggregation stays in
+// input order regardless of which worker finishes first. All workers share the
+// client's rate limiter, so concurrency bounds in-flight requests, not the rate.
+func forEach(workers, n int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
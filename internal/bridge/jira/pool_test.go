@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestForEach_Bounded tests that every index runs once and no more than
+// workers run at the same time
+func TestForEach_Bounded(t *testing.T) {
+	const n, workers = 50, 5
+	var seen [n]atomic.Int32
+	var inFlight, maxInFlight atomic.Int32
+
+	forEach(workers, n, func(i int) {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if cur <= m || maxInFlight.CompareAndSwap(m, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		seen[i].Add(1)
+	})
+
+	for i := range seen {
+		if got := seen[i].Load(); got != 1 {
+			t.Errorf("Expected index %d to run once, ran %d times", i, got)
+		}
+	}
+	if got := maxInFlight.Load(); got > workers {
+		t.Errorf("Expected at most %d concurrent calls, got %d", workers, got)
+	}
+}
+
+// TestForEach_Empty tests that an empty range returns without starting workers
+func TestForEach_Empty(t *testing.T) {
+	forEach(4, 0, func(i int) {
+		t.Errorf("Unexpected call for index %d", i)
+	})
+}
@@ -4,32 +4,33 @@ import (
 	"context"
 	"fmt"
 	"log"
-)
 
-// PullResult represents the result of pulling issues
-type PullResult struct {
-	Fetched int      `json:"fetched"`
-	Created int      `json:"created"`
-	Updated int      `json:"updated"`
-	Deleted int      `json:"deleted"`
-	Errors  []string `json:"errors"`
-}
+	"github.com/gurisko/takl/internal/issue"
+)
 
-// RefreshMemberCache fetches project members from Jira and updates the local cache.
+// RefreshMemberCache fetches the members of the projects from Jira and updates the local cache.
 // Returns the cache (loaded from disk if fetch fails) and any error.
 // This function is non-fatal - it will return a cache even if fetching fails.
-func RefreshMemberCache(ctx context.Context, client *Client, projectPath, projectKey string) (*MemberCache, error) {
+func RefreshMemberCache(ctx context.Context, client *Client, projectPath string, projectKeys []string) (*issue.MemberCache, error) {
 	log.Printf("[DEBUG] RefreshMemberCache: Fetching project members")
 
 	// Fetch members from Jira
-	members, err := client.FetchProjectMembers(ctx, projectKey)
+	var members []*issue.Member
+	var err error
+	for _, projectKey := range projectKeys {
+		var fetched []*issue.Member
+		if fetched, err = client.FetchProjectMembers(ctx, projectKey); err != nil {
+			break
+		}
+		members = append(members, fetched...)
+	}
 	if err != nil {
 		log.Printf("[WARN] RefreshMemberCache: Failed to fetch project members: %v", err)
 		// Try to load existing cache as fallback
 		cache, loadErr := LoadMembersCache(projectPath)
 		if loadErr != nil {
 			log.Printf("[WARN] RefreshMemberCache: Failed to load existing cache: %v", loadErr)
-			return NewMemberCache(), fmt.Errorf("failed to fetch members: %w", err)
+			return issue.NewMemberCache(), fmt.Errorf("failed to fetch members: %w", err)
 		}
 		return cache, fmt.Errorf("failed to fetch members (using cached data): %w", err)
 	}
@@ -38,7 +39,7 @@ func RefreshMemberCache(ctx context.Context, client *Client, projectPath, projec
 	cache, err := LoadMembersCache(projectPath)
 	if err != nil {
 		log.Printf("[WARN] RefreshMemberCache: Failed to load existing cache: %v", err)
-		cache = NewMemberCache()
+		cache = issue.NewMemberCache()
 	}
 
 	// Update cache with fetched members
@@ -56,27 +57,35 @@ func RefreshMemberCache(ctx context.Context, client *Client, projectPath, projec
 	return cache, nil
 }
 
-// RefreshWorkflowCache fetches project statuses from Jira and updates the local cache.
+// RefreshWorkflowCache fetches the statuses of the projects from Jira and updates the local cache.
 // Returns the cache (loaded from disk if fetch fails) and any error.
 // This function is non-fatal - it will return a cache even if fetching fails.
-func RefreshWorkflowCache(ctx context.Context, client *Client, projectPath, projectKey string) (*WorkflowCache, error) {
+func RefreshWorkflowCache(ctx context.Context, client *Client, projectPath string, projectKeys []string) (*issue.WorkflowCache, error) {
 	log.Printf("[DEBUG] RefreshWorkflowCache: Fetching project statuses")
 
 	// Fetch statuses from Jira
-	statuses, err := client.FetchProjectStatuses(ctx, projectKey)
+	var statuses []*issue.StatusInfo
+	var err error
+	for _, projectKey := range projectKeys {
+		var fetched []*issue.StatusInfo
+		if fetched, err = client.FetchProjectStatuses(ctx, projectKey); err != nil {
+			break
+		}
+		statuses = append(statuses, fetched...)
+	}
 	if err != nil {
 		log.Printf("[WARN] RefreshWorkflowCache: Failed to fetch project statuses: %v", err)
 		// Try to load existing cache as fallback
 		cache, loadErr := LoadWorkflowCache(projectPath)
 		if loadErr != nil {
 			log.Printf("[WARN] RefreshWorkflowCache: Failed to load existing cache: %v", loadErr)
-			return NewWorkflowCache(), fmt.Errorf("failed to fetch statuses: %w", err)
+			return issue.NewWorkflowCache(), fmt.Errorf("failed to fetch statuses: %w", err)
 		}
 		return cache, fmt.Errorf("failed to fetch statuses (using cached data): %w", err)
 	}
 
 	// Create new cache from fetched data (don't merge with old cache)
-	cache := NewWorkflowCache()
+	cache := issue.NewWorkflowCache()
 	for _, status := range statuses {
 		cache.AddStatus(status)
 	}
@@ -92,27 +101,40 @@ func RefreshWorkflowCache(ctx context.Context, client *Client, projectPath, proj
 }
 
 // Pull fetches issues from Jira and saves them to local storage
-func Pull(ctx context.Context, client *Client, storage *Storage, config *JiraConfig) (*PullResult, error) {
-	result := &PullResult{
+func Pull(ctx context.Context, client *Client, storage *issue.Storage, config *JiraConfig) (*issue.PullResult, error) {
+	result := &issue.PullResult{
 		Errors: make([]string, 0),
 	}
 
+	// Resolve mapped custom fields before the client is shared by workers
+	if err := client.resolveCustomFields(ctx, config.CustomFields); err != nil {
+		return nil, fmt.Errorf("failed to resolve custom fields: %w", err)
+	}
+
 	// Fetch and cache project members first (non-fatal)
-	memberCache, _ := RefreshMemberCache(ctx, client, storage.projectPath, config.Project)
+	memberCache, _ := RefreshMemberCache(ctx, client, storage.ProjectPath(), config.ProjectKeys())
 
 	// Fetch and cache project workflow/statuses (non-fatal)
-	_, _ = RefreshWorkflowCache(ctx, client, storage.projectPath, config.Project)
+	_, _ = RefreshWorkflowCache(ctx, client, storage.ProjectPath(), config.ProjectKeys())
 
-	// Search for all issues in the project (archived filtering handled client-side)
-	jql := fmt.Sprintf("project=%s ORDER BY updated DESC", config.Project)
+	// Search for all issues in scope (archived filtering handled client-side)
+	jql := config.ScopeJQL()
 	log.Printf("[DEBUG] Pull: Searching Jira with JQL: %s", jql)
-	issues, err := client.SearchIssues(ctx, jql, MaxSearchResults, memberCache)
+	found, err := client.searchJiraIssues(ctx, jql, MaxSearchResults)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search issues: %w", err)
 	}
 
-	log.Printf("[DEBUG] Pull: Fetched %d issues from Jira", len(issues))
-	result.Fetched = len(issues)
+	log.Printf("[DEBUG] Pull: Fetched %d issues from Jira", len(found))
+	result.Fetched = len(found)
+
+	// Record sprints from the configured board (non-fatal)
+	var sprints map[string]string
+	if config.BoardID != 0 {
+		if sprints, err = fetchBoardSprints(ctx, client, config.BoardID); err != nil {
+			log.Printf("[WARN] Pull: Failed to fetch sprints of board %d, using the sprint field: %v", config.BoardID, err)
+		}
+	}
 
 	// Get list of existing local issues
 	localIssues, err := storage.ListIssues()
@@ -127,13 +149,31 @@ func Pull(ctx context.Context, client *Client, storage *Storage, config *JiraCon
 
 	// Build set of fetched issue keys for efficient lookup
 	fetchedKeys := make(map[string]bool)
-	for _, issue := range issues {
-		fetchedKeys[issue.JiraKey] = true
+	for _, jr := range found {
+		fetchedKeys[jr.Key] = true
 	}
 
-	// Delete local issues that are no longer in Jira (archived or deleted)
+	// Delete local issues of the configured projects that are no longer in scope
+	// (archived, deleted or filtered out). Issues of other projects, local-only
+	// issues and issues with unpushed local edits are left alone, and nothing is
+	// deleted if the search hit the result cap.
+	truncated := len(found) >= MaxSearchResults
+	if truncated {
+		log.Printf("[WARN] Pull: Search returned %d issues (the maximum); skipping deletion", len(found))
+	}
 	for _, localKey := range localIssues {
-		if !fetchedKeys[localKey] {
+		if !truncated && !fetchedKeys[localKey] && config.inScope(localKey) {
+			if local, err := storage.ReadIssue(localKey); err == nil {
+				// Local-only issues have not been created in Jira yet
+				if local.RemoteID == "" {
+					continue
+				}
+				if storage.ComputeHash(local) != local.Hash {
+					log.Printf("[WARN] Pull: Keeping %s, out of scope but edited locally", localKey)
+					result.Kept = append(result.Kept, localKey)
+					continue
+				}
+			}
 			log.Printf("[DEBUG] Pull: Deleting locally archived/removed issue %s", localKey)
 			if err := storage.DeleteIssue(localKey); err != nil {
 				log.Printf("[ERROR] Pull: Failed to delete %s: %v", localKey, err)
@@ -148,32 +188,19 @@ func Pull(ctx context.Context, client *Client, storage *Storage, config *JiraCon
 		log.Printf("[DEBUG] Pull: Deleted %d locally archived/removed issues", result.Deleted)
 	}
 
-	// Process each issue
-	for _, issue := range issues {
-		isNew := !localMap[issue.JiraKey]
-
-		log.Printf("[DEBUG] Pull: Processing issue %s (new=%v)", issue.JiraKey, isNew)
-
-		// Check if the issue is unchanged
-		if !isNew {
-			// Compute hash to compare with existing
-			newHash := storage.ComputeHash(&issue)
-			if oldHash, ok := storage.ReadExistingHash(issue.JiraKey); ok && oldHash == newHash {
-				log.Printf("[DEBUG] Pull: Skipping %s (unchanged)", issue.JiraKey)
-				continue // Skip unchanged issues
-			}
-		}
+	// Process issues in parallel; outcomes are tallied in search order afterwards
+	outcomes := make([]pullOutcome, len(found))
+	forEach(config.Workers(), len(found), func(i int) {
+		outcomes[i] = pullIssue(ctx, client, storage, &found[i], !localMap[found[i].Key], memberCache, sprints)
+	})
 
-		// Save the issue
-		if err := storage.SaveIssue(&issue); err != nil {
-			log.Printf("[ERROR] Pull: Failed to save %s: %v", issue.JiraKey, err)
-			result.Errors = append(result.Errors, fmt.Sprintf("failed to save %s: %v", issue.JiraKey, err))
-			continue
-		}
-
-		if isNew {
+	for _, o := range outcomes {
+		switch {
+		case o.err != "":
+			result.Errors = append(result.Errors, o.err)
+		case o.created:
 			result.Created++
-		} else {
+		case o.updated:
 			result.Updated++
 		}
 	}
@@ -187,3 +214,77 @@ func Pull(ctx context.Context, client *Client, storage *Storage, config *JiraCon
 
 	return result, nil
 }
+
+// pullOutcome is the result of pulling a single issue
+type pullOutcome struct {
+	created bool
+	updated bool
+	err     string
+}
+
+// pullIssue completes an issue's comments and worklogs if needed and saves it
+// unless unchanged. sprints maps issue keys to open sprints of the board, if any.
+func pullIssue(ctx context.Context, client *Client, storage *issue.Storage, jr *jiraIssueResponse, isNew bool, memberCache *issue.MemberCache, sprints map[string]string) pullOutcome {
+	log.Printf("[DEBUG] Pull: Processing issue %s (new=%v)", jr.Key, isNew)
+
+	if err := client.completeComments(ctx, jr); err != nil {
+		log.Printf("[ERROR] Pull: Failed to fetch comments for %s: %v", jr.Key, err)
+		return pullOutcome{err: fmt.Sprintf("failed to fetch comments for %s: %v", jr.Key, err)}
+	}
+	if err := client.completeWorklogs(ctx, jr); err != nil {
+		log.Printf("[ERROR] Pull: Failed to fetch worklogs for %s: %v", jr.Key, err)
+		return pullOutcome{err: fmt.Sprintf("failed to fetch worklogs for %s: %v", jr.Key, err)}
+	}
+
+	converted := client.convertJiraIssue(*jr, memberCache)
+	if sprint, ok := sprints[converted.Key]; ok {
+		converted.Sprint = sprint
+	}
+
+	// Check if the issue is unchanged
+	if !isNew {
+		newHash := storage.ComputeHash(&converted)
+		if oldHash, ok := storage.ReadExistingHash(converted.Key); ok && oldHash == newHash && !boardMoved(storage, &converted) {
+			log.Printf("[DEBUG] Pull: Skipping %s (unchanged)", converted.Key)
+			return pullOutcome{}
+		}
+	}
+
+	if err := storage.SaveIssue(&converted); err != nil {
+		log.Printf("[ERROR] Pull: Failed to save %s: %v", converted.Key, err)
+		return pullOutcome{err: fmt.Sprintf("failed to save %s: %v", converted.Key, err)}
+	}
+
+	return pullOutcome{created: isNew, updated: !isNew}
+}
+
+// boardMoved reports whether the issue's sprint or rank differs from the local
+// file; both are excluded from the hash but should still be pulled
+func boardMoved(storage *issue.Storage, converted *issue.Issue) bool {
+	local, err := storage.ReadIssue(converted.Key)
+	return err != nil || local.Sprint != converted.Sprint || local.Rank != converted.Rank
+}
+
+// fetchBoardSprints maps the keys of the issues in the board's active and
+// future sprints to the sprint name. The board is authoritative where the
+// sprint field lists several sprints.
+func fetchBoardSprints(ctx context.Context, client *Client, boardID int) (map[string]string, error) {
+	sprints, err := client.ListSprints(ctx, boardID, SprintActive+","+SprintFuture)
+	if err != nil {
+		return nil, err
+	}
+
+	bySprint := make(map[string]string)
+	for _, sprint := range sprints {
+		keys, err := client.SprintIssueKeys(ctx, sprint.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			bySprint[key] = sprint.Name
+		}
+	}
+
+	log.Printf("[DEBUG] fetchBoardSprints: %d issues in %d open sprints of board %d", len(bySprint), len(sprints), boardID)
+	return bySprint, nil
+}
@@ -0,0 +1,547 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// fakeIssue is the mutable state of an issue in fakeJira
+type fakeIssue struct {
+	summary  string
+	updated  int // Minutes past the base time; bumped on every update
+	comments []string
+	fields   map[string]interface{} // Extra fields returned as is, and set by updates
+}
+
+// fakeSprint is a sprint of the fake board (ID 7) with the keys of its issues
+type fakeSprint struct {
+	Sprint
+	keys []string
+}
+
+// fakeJira is an in-memory stand-in for the Jira Cloud REST API v3 issue endpoints
+// and the Agile API sprint endpoints of a single board.
+// Search and issue responses embed at most embedComments comments, like Jira does.
+type fakeJira struct {
+	mu            sync.Mutex
+	t             *testing.T
+	issues        map[string]*fakeIssue
+	embedComments int
+	delay         time.Duration // Per-request latency, to make overlap observable
+	inFlight      atomic.Int32
+	maxInFlight   atomic.Int32
+	commentCalls  atomic.Int32
+	jql           string                   // JQL of the last search
+	lastUpdate    map[string]interface{}   // Fields of the last issue update
+	lastCreate    map[string]interface{}   // Fields of the last issue creation
+	fields        []map[string]interface{} // Field metadata served by /field
+	linkCalls     []string                 // Issue link creations and deletions
+	worklogCalls  []string                 // Worklogs added, as "<key> <seconds>s <started> adjust=<mode>"
+	sprints       []*fakeSprint            // Sprints of board 7, in board order
+	agileCalls    []string                 // Sprint moves and ranks, e.g. "move PROJ-1 to 12", "rank PROJ-2 after PROJ-1"
+	server        *httptest.Server
+}
+
+func newFakeJira(t *testing.T) *fakeJira {
+	t.Helper()
+	f := &fakeJira{
+		t:             t,
+		issues:        make(map[string]*fakeIssue),
+		embedComments: 20,
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// client returns a client without client-side rate limiting or retry sleeps
+func (f *fakeJira) client() *Client {
+	c := NewClient(f.server.URL, "me@example.com", "token")
+	rt := c.httpClient.Transport.(*retryTransport)
+	rt.limiter = nil
+	rt.maxRetries = 0
+	return c
+}
+
+func (f *fakeJira) handle(w http.ResponseWriter, r *http.Request) {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		m := f.maxInFlight.Load()
+		if n <= m || f.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/rest/api/3/search/jql" && r.Method == http.MethodPost:
+		var body struct {
+			JQL string `json:"jql"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.jql = body.JQL
+		keys := make([]string, 0, len(f.issues))
+		for k := range f.issues {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		list := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			list = append(list, f.issueJSON(k))
+		}
+		f.writeJSON(w, map[string]interface{}{"issues": list})
+
+	case path == "/rest/api/3/issue" && r.Method == http.MethodPost:
+		var body struct {
+			Fields map[string]interface{} `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		f.lastCreate = body.Fields
+		project, _ := body.Fields["project"].(map[string]interface{})
+		key := fmt.Sprintf("%s-%d", project["key"], len(f.issues)+1)
+		fi := &fakeIssue{fields: make(map[string]interface{})}
+		for name, v := range body.Fields {
+			switch name {
+			case "summary":
+				fi.summary, _ = v.(string)
+			case "project", "description":
+			default:
+				fi.fields[name] = v
+			}
+		}
+		f.issues[key] = fi
+		w.WriteHeader(http.StatusCreated)
+		f.writeJSON(w, map[string]string{"id": strings.TrimPrefix(key, "PROJ-"), "key": key})
+
+	case path == "/rest/api/3/issueLink" && r.Method == http.MethodPost:
+		var body struct {
+			Type         jiraNamed         `json:"type"`
+			InwardIssue  map[string]string `json:"inwardIssue"`
+			OutwardIssue map[string]string `json:"outwardIssue"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.linkCalls = append(f.linkCalls, fmt.Sprintf("create %s %s %s", body.InwardIssue["key"], body.Type.Name, body.OutwardIssue["key"]))
+		w.WriteHeader(http.StatusCreated)
+
+	case strings.HasPrefix(path, "/rest/api/3/issueLink/") && r.Method == http.MethodDelete:
+		f.linkCalls = append(f.linkCalls, "delete "+strings.TrimPrefix(path, "/rest/api/3/issueLink/"))
+		w.WriteHeader(http.StatusNoContent)
+
+	case path == "/rest/api/3/field" && r.Method == http.MethodGet:
+		f.writeJSON(w, f.fields)
+
+	case strings.HasPrefix(path, "/rest/agile/1.0/"):
+		f.handleAgile(w, r, strings.TrimPrefix(path, "/rest/agile/1.0"))
+
+	case strings.HasPrefix(path, "/rest/api/3/issue/"):
+		rest := strings.TrimPrefix(path, "/rest/api/3/issue/")
+		key, sub, _ := strings.Cut(rest, "/")
+		fi, ok := f.issues[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			f.writeJSON(w, map[string]interface{}{"errorMessages": []string{"Issue does not exist or you do not have permission to see it."}})
+			return
+		}
+		switch {
+		case sub == "comment" && r.Method == http.MethodGet:
+			f.commentCalls.Add(1)
+			startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+			f.writeJSON(w, f.commentsJSON(key, startAt, len(fi.comments)))
+		case sub == "" && r.Method == http.MethodGet:
+			f.writeJSON(w, f.issueJSON(key))
+		case sub == "worklog" && r.Method == http.MethodPost:
+			var body struct {
+				Started          string `json:"started"`
+				TimeSpentSeconds int    `json:"timeSpentSeconds"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			f.worklogCalls = append(f.worklogCalls, fmt.Sprintf("%s %ds %s adjust=%s", key, body.TimeSpentSeconds, body.Started, r.URL.Query().Get("adjustEstimate")))
+			w.WriteHeader(http.StatusCreated)
+		case sub == "" && r.Method == http.MethodPut:
+			var body struct {
+				Fields map[string]interface{} `json:"fields"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			f.lastUpdate = body.Fields
+			for name, v := range body.Fields {
+				switch name {
+				case "summary":
+					fi.summary, _ = v.(string)
+				case "description", "labels":
+				default:
+					if fi.fields == nil {
+						fi.fields = make(map[string]interface{})
+					}
+					fi.fields[name] = v
+				}
+			}
+			fi.updated++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+
+	default:
+		// Members and statuses are not needed; their refresh is non-fatal
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+// handleAgile serves the Agile API; sprint moves and ranks update the issues'
+// sprint and rank fields
+func (f *fakeJira) handleAgile(w http.ResponseWriter, r *http.Request, path string) {
+	var body struct {
+		Issues          []string `json:"issues"`
+		RankBeforeIssue string   `json:"rankBeforeIssue"`
+		RankAfterIssue  string   `json:"rankAfterIssue"`
+	}
+	if r.Method != http.MethodGet {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case path == "/board":
+		f.writeJSON(w, map[string]interface{}{"values": []Board{{ID: 7, Name: "PROJ board", Type: "scrum"}}, "isLast": true})
+
+	case path == "/board/7/sprint":
+		states := r.URL.Query().Get("state")
+		sprints := make([]Sprint, 0, len(f.sprints))
+		for _, s := range f.sprints {
+			if states == "" || strings.Contains(states, s.State) {
+				sprints = append(sprints, s.Sprint)
+			}
+		}
+		f.writeJSON(w, map[string]interface{}{"values": sprints, "isLast": true})
+
+	case path == "/backlog/issue" || strings.HasPrefix(path, "/sprint/") && r.Method == http.MethodPost:
+		target := "backlog"
+		if path != "/backlog/issue" {
+			target = strings.TrimSuffix(strings.TrimPrefix(path, "/sprint/"), "/issue")
+		}
+		for _, key := range body.Issues {
+			f.agileCalls = append(f.agileCalls, fmt.Sprintf("move %s to %s", key, target))
+			for _, s := range f.sprints {
+				s.keys = slices.DeleteFunc(s.keys, func(k string) bool { return k == key })
+				if strconv.Itoa(s.ID) == target {
+					s.keys = append(s.keys, key)
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case strings.HasPrefix(path, "/sprint/"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/sprint/"), "/issue")
+		issues := make([]map[string]string, 0)
+		for _, s := range f.sprints {
+			if strconv.Itoa(s.ID) == id {
+				for _, key := range s.keys {
+					issues = append(issues, map[string]string{"key": key})
+				}
+			}
+		}
+		f.writeJSON(w, map[string]interface{}{"issues": issues, "total": len(issues)})
+
+	case path == "/issue/rank" && r.Method == http.MethodPut:
+		anchor := "before " + body.RankBeforeIssue
+		if body.RankAfterIssue != "" {
+			anchor = "after " + body.RankAfterIssue
+		}
+		for i, key := range body.Issues {
+			f.agileCalls = append(f.agileCalls, fmt.Sprintf("rank %s %s", key, anchor))
+			if fi, ok := f.issues[key]; ok {
+				if fi.fields == nil {
+					fi.fields = make(map[string]interface{})
+				}
+				fi.fields[DefaultRankField] = fmt.Sprintf("0|r%d:", i)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func (f *fakeJira) issueJSON(key string) map[string]interface{} {
+	fi := f.issues[key]
+	fields := map[string]interface{}{
+		"summary":     fi.summary,
+		"description": nil,
+		"status":      map[string]string{"name": "To Do"},
+		"assignee":    nil,
+		"reporter":    map[string]string{"accountId": "a-1", "displayName": "Ann"},
+		"created":     "2025-01-01T00:00:00.000+0000",
+		"updated":     fmt.Sprintf("2025-01-01T00:%02d:00.000+0000", fi.updated),
+		"labels":      []string{},
+		"comment":     f.commentsJSON(key, 0, f.embedComments),
+		"attachment":  []interface{}{},
+	}
+	for name, v := range fi.fields {
+		fields[name] = v
+	}
+	return map[string]interface{}{
+		"id":     strings.TrimPrefix(key, "PROJ-"),
+		"key":    key,
+		"fields": fields,
+	}
+}
+
+// commentsJSON returns up to limit comments of an issue starting at startAt
+func (f *fakeJira) commentsJSON(key string, startAt, limit int) map[string]interface{} {
+	all := f.issues[key].comments
+	end := min(startAt+limit, len(all))
+	comments := make([]interface{}, 0, end-startAt)
+	for i := startAt; i < end; i++ {
+		comments = append(comments, map[string]interface{}{
+			"id":      strconv.Itoa(i + 1),
+			"author":  map[string]string{"accountId": "a-1", "displayName": "Ann"},
+			"body":    map[string]interface{}{"type": "doc", "version": 1, "content": []interface{}{map[string]interface{}{"type": "paragraph", "content": []interface{}{map[string]string{"type": "text", "text": all[i]}}}}},
+			"created": fmt.Sprintf("2025-01-02T00:%02d:00.000+0000", i),
+			"updated": fmt.Sprintf("2025-01-02T00:%02d:00.000+0000", i),
+		})
+	}
+	return map[string]interface{}{"comments": comments, "startAt": startAt, "total": len(all)}
+}
+
+func (f *fakeJira) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.t.Errorf("failed to encode fake response: %v", err)
+	}
+}
+
+// TestPull_FetchesRemainingComments tests that issues whose embedded comments
+// are truncated get a follow-up fetch for the rest
+func TestPull_FetchesRemainingComments(t *testing.T) {
+	f := newFakeJira(t)
+	f.embedComments = 2
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Chatty", comments: []string{"one", "two", "three", "four", "five"}}
+	f.issues["PROJ-2"] = &fakeIssue{summary: "Quiet", comments: []string{"only"}}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &JiraConfig{Project: "PROJ"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Created != 2 {
+		t.Errorf("Expected 2 created issues, got %d (errors: %v)", result.Created, result.Errors)
+	}
+	if got := f.commentCalls.Load(); got != 1 {
+		t.Errorf("Expected 1 follow-up comment fetch, got %d", got)
+	}
+
+	got, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if len(got.Comments) != 5 || got.Comments[4].Body != "five" {
+		t.Errorf("Expected all 5 comments, got %v", got.Comments)
+	}
+}
+
+// TestPull_Concurrent tests that pull processes issues in parallel within the
+// configured bound and tallies every issue exactly once
+func TestPull_Concurrent(t *testing.T) {
+	f := newFakeJira(t)
+	f.embedComments = 0 // Every issue with comments needs a follow-up fetch
+	f.delay = 10 * time.Millisecond
+	for i := 1; i <= 12; i++ {
+		f.issues[fmt.Sprintf("PROJ-%d", i)] = &fakeIssue{summary: fmt.Sprintf("Issue %d", i), comments: []string{"hi"}}
+	}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &JiraConfig{Project: "PROJ", Concurrency: 3})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Created != 12 {
+		t.Errorf("Expected 12 created issues, got %d (errors: %v)", result.Created, result.Errors)
+	}
+	if got := f.maxInFlight.Load(); got > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, got %d", got)
+	}
+	if got := f.maxInFlight.Load(); got < 2 {
+		t.Errorf("Expected requests to overlap, got max %d in flight", got)
+	}
+}
+
+// TestPull_DeletesOnlyInScope tests that pull sends the scope JQL and deletes
+// only local issues of the configured projects that left the scope
+func TestPull_DeletesOnlyInScope(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Kept"}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	for _, key := range []string{"PROJ-2", "OTHER-1"} {
+		if err := storage.SaveIssue(&issue.Issue{Key: key, RemoteID: "1000" + key[len(key)-1:], Title: "Stale"}); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+	// Not created in Jira yet
+	if err := storage.WriteIssue(&issue.Issue{Key: "PROJ-draft", Title: "Draft"}); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	config := &JiraConfig{Project: "PROJ", JQL: `component = "Back end"`}
+	result, err := Pull(context.Background(), f.client(), storage, config)
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	if want := `project = "PROJ" AND (component = "Back end") ORDER BY updated DESC`; f.jql != want {
+		t.Errorf("Expected JQL %q, got %q", want, f.jql)
+	}
+	if result.Deleted != 1 {
+		t.Errorf("Expected 1 deleted issue, got %d", result.Deleted)
+	}
+	if _, err := storage.ReadIssue("PROJ-2"); err == nil {
+		t.Errorf("Expected PROJ-2 to be deleted")
+	}
+	if _, err := storage.ReadIssue("OTHER-1"); err != nil {
+		t.Errorf("Expected OTHER-1 (outside the scope) to be kept, got %v", err)
+	}
+	if _, err := storage.ReadIssue("PROJ-draft"); err != nil {
+		t.Errorf("Expected local-only PROJ-draft to be kept, got %v", err)
+	}
+}
+
+// TestPull_KeepsLocalEditsOutOfScope tests that an issue that left the scope
+// is not deleted while it has unpushed local edits
+func TestPull_KeepsLocalEditsOutOfScope(t *testing.T) {
+	f := newFakeJira(t)
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	local := &issue.Issue{Key: "PROJ-2", RemoteID: "10002", Title: "Synced"}
+	if err := storage.SaveIssue(local); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+	local.Title = "Edited locally"
+	if err := storage.WriteIssue(local); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	config := &JiraConfig{Project: "PROJ", JQL: "sprint in openSprints()"}
+	result, err := Pull(context.Background(), f.client(), storage, config)
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	if result.Deleted != 0 {
+		t.Errorf("Expected no deleted issues, got %d", result.Deleted)
+	}
+	if !slices.Equal(result.Kept, []string{"PROJ-2"}) {
+		t.Errorf("Expected PROJ-2 to be reported as kept, got %v", result.Kept)
+	}
+	got, err := storage.ReadIssue("PROJ-2")
+	if err != nil {
+		t.Fatalf("Expected PROJ-2 to be kept, got %v", err)
+	}
+	if got.Title != "Edited locally" {
+		t.Errorf("Expected the local edit to be kept, got title %q", got.Title)
+	}
+}
+
+// TestPull_PlanningFields tests that type, priority, components, versions,
+// due date, parent, sprint and story points are mapped onto the issue
+func TestPull_PlanningFields(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Planned", fields: map[string]interface{}{
+		"issuetype":   map[string]string{"name": "Bug"},
+		"priority":    map[string]string{"name": "High"},
+		"components":  []map[string]string{{"name": "API"}, {"name": "Back end"}},
+		"fixVersions": []map[string]string{{"name": "1.2"}},
+		"duedate":     "2025-03-31",
+		"parent":      map[string]string{"key": "PROJ-100"},
+		DefaultSprintField: []map[string]string{
+			{"name": "Sprint 11", "state": "closed"},
+			{"name": "Sprint 12", "state": "active"},
+		},
+		DefaultStoryPointsField: 5,
+	}}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	if _, err := Pull(context.Background(), f.client(), storage, &JiraConfig{Project: "PROJ"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	got, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if got.Type != "Bug" || got.Priority != "High" || got.Parent != "PROJ-100" || got.DueDate != "2025-03-31" {
+		t.Errorf("Expected Bug/High/PROJ-100/2025-03-31, got %q/%q/%q/%q", got.Type, got.Priority, got.Parent, got.DueDate)
+	}
+	if strings.Join(got.Components, ",") != "API,Back end" || strings.Join(got.FixVersions, ",") != "1.2" {
+		t.Errorf("Expected components API,Back end and fix version 1.2, got %v and %v", got.Components, got.FixVersions)
+	}
+	if got.Sprint != "Sprint 12" {
+		t.Errorf("Expected active sprint %q, got %q", "Sprint 12", got.Sprint)
+	}
+	if got.StoryPoints != 5 {
+		t.Errorf("Expected 5 story points, got %v", got.StoryPoints)
+	}
+}
+
+// TestParseSprint tests sprint extraction from Cloud objects and Server strings
+func TestParseSprint(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"null", `null`, ""},
+		{"cloud active", `[{"name":"S1","state":"closed"},{"name":"S2","state":"active"},{"name":"S3","state":"future"}]`, "S2"},
+		{"cloud none active", `[{"name":"S1","state":"closed"},{"name":"S3","state":"future"}]`, "S3"},
+		{"server", `["com.atlassian.greenhopper.service.sprint.Sprint@1f[id=12,rapidViewId=3,state=ACTIVE,name=Sprint 12, Team A,startDate=2025-01-01T00:00:00.000Z,sequence=12]"]`, "Sprint 12, Team A"},
+		{"server last", `["com.atlassian.greenhopper.service.sprint.Sprint@1f[id=1,state=CLOSED,name=Old]","com.atlassian.greenhopper.service.sprint.Sprint@2a[id=2,state=FUTURE,name=Next]"]`, "Next"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSprint([]byte(tt.raw)); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -5,49 +5,40 @@ import (
 	"fmt"
 	"log"
 	"strings"
-	"time"
-)
-
-// PushResult represents the result of pushing local changes to Jira
-type PushResult struct {
-	Scanned   int            `json:"scanned"`   // Total local issues scanned
-	Pushed    int            `json:"pushed"`    // Successfully pushed to Jira
-	Skipped   int            `json:"skipped"`   // No local changes
-	Conflicts []ConflictInfo `json:"conflicts"` // Issues with conflicts
-	Errors    []string       `json:"errors"`    // Other errors
-}
 
-// ConflictInfo describes a conflict for a specific issue
-type ConflictInfo struct {
-	IssueKey string    `json:"issue_key"`
-	Updated  time.Time `json:"updated"` // When the remote was last updated
-}
+	"github.com/gurisko/takl/internal/issue"
+)
 
 // Push pushes local changes to Jira with strict conflict detection
 // Option 1 (Strict): Fails if remote has ANY changes since last pull
 // If issueKey is provided, only that issue will be pushed
-func Push(ctx context.Context, client *Client, storage *Storage, config *JiraConfig, issueKey string) (*PushResult, error) {
-	result := &PushResult{
-		Conflicts: make([]ConflictInfo, 0),
+func Push(ctx context.Context, client *Client, storage *issue.Storage, config *JiraConfig, issueKey string) (*issue.PushResult, error) {
+	result := &issue.PushResult{
+		Conflicts: make([]issue.ConflictInfo, 0),
 		Errors:    make([]string, 0),
 	}
 
 	// Load member cache for user resolution (non-fatal if missing)
-	memberCache, err := LoadMembersCache(storage.projectPath)
+	memberCache, err := LoadMembersCache(storage.ProjectPath())
 	if err != nil {
 		log.Printf("[WARN] Push: Failed to load member cache: %v", err)
-		memberCache = NewMemberCache()
+		memberCache = issue.NewMemberCache()
+	}
+
+	// Resolve mapped custom fields before the client is shared by workers
+	if err := client.resolveCustomFields(ctx, config.CustomFields); err != nil {
+		return nil, fmt.Errorf("failed to resolve custom fields: %w", err)
 	}
 
 	// Get local issues (all or specific one)
-	var localIssues []*Issue
+	var localIssues []*issue.Issue
 	if issueKey != "" {
 		// Push only the specified issue
-		issue, err := storage.ReadIssue(issueKey)
+		single, err := storage.ReadIssue(issueKey)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read issue %s: %w", issueKey, err)
 		}
-		localIssues = []*Issue{issue}
+		localIssues = []*issue.Issue{single}
 		log.Printf("[DEBUG] Push: Pushing single issue %s", issueKey)
 	} else {
 		// Push all issues
@@ -60,116 +51,388 @@ func Push(ctx context.Context, client *Client, storage *Storage, config *JiraCon
 
 	result.Scanned = len(localIssues)
 
-	// Process each local issue
-	for _, localIssue := range localIssues {
-		log.Printf("[DEBUG] Push: Processing issue %s", localIssue.JiraKey)
+	// Issues without a remote ID exist only locally and are created first, one
+	// at a time: each creation renames the file and rewrites references to it
+	localIssues, err = createLocalIssues(ctx, client, storage, config, localIssues, memberCache, result)
+	if err != nil {
+		return nil, err
+	}
 
-		// Compute local hash
-		localHash := storage.ComputeHash(localIssue)
+	// Push issues in parallel; outcomes are tallied in scan order afterwards
+	outcomes := make([]pushOutcome, len(localIssues))
+	forEach(config.Workers(), len(localIssues), func(i int) {
+		outcomes[i] = pushOne(ctx, client, storage, localIssues[i], memberCache)
+	})
+
+	for _, o := range outcomes {
+		switch {
+		case o.conflict != nil:
+			result.Conflicts = append(result.Conflicts, *o.conflict)
+		case o.err != "":
+			result.Errors = append(result.Errors, o.err)
+		case o.pushed:
+			result.Pushed++
+		default:
+			result.Skipped++
+		}
+	}
 
-		// Compare with base hash (from file)
-		baseHash := localIssue.Hash
+	log.Printf("[DEBUG] Push: Complete - Scanned: %d, Pushed: %d, Skipped: %d, Conflicts: %d, Errors: %d",
+		result.Scanned, result.Pushed, result.Skipped, len(result.Conflicts), len(result.Errors))
 
-		// If local == base, no changes to push
-		if localHash == baseHash {
-			log.Printf("[DEBUG] Push: Skipping %s (no local changes)", localIssue.JiraKey)
-			result.Skipped++
-			continue
+	// Return error if conflicts detected
+	if len(result.Conflicts) > 0 {
+		return result, fmt.Errorf("cannot push - %d issue(s) have conflicts", len(result.Conflicts))
+	}
+
+	return result, nil
+}
+
+// createLocalIssues creates the local-only issues in Jira and returns the
+// remaining issues to push, re-read so they see renamed references. Creation
+// happens in two passes so that links between new issues resolve: first every
+// issue is created and renamed, then the fields and relations that cannot be
+// set on creation (status, links, comments, ...) are pushed.
+func createLocalIssues(ctx context.Context, client *Client, storage *issue.Storage, config *JiraConfig, localIssues []*issue.Issue, memberCache *issue.MemberCache, result *issue.PushResult) ([]*issue.Issue, error) {
+	var existing []*issue.Issue
+	newKeys := make(map[string]bool)
+	for _, local := range localIssues {
+		if local.RemoteID == "" {
+			newKeys[local.Key] = true
+		} else {
+			existing = append(existing, local)
 		}
+	}
+	if len(newKeys) == 0 {
+		return localIssues, nil
+	}
 
-		log.Printf("[DEBUG] Push: Issue %s has local changes (base=%s, local=%s)",
-			localIssue.JiraKey, baseHash[:8], localHash[:8])
+	type createdIssue struct {
+		key    string
+		remote *issue.Issue
+	}
+	var created []createdIssue
+	for _, local := range localIssues {
+		if local.RemoteID != "" {
+			continue
+		}
+		key, remote, err := createIssue(ctx, client, storage, config, local, newKeys, memberCache)
+		if err != nil {
+			log.Printf("[ERROR] Push: Failed to create %s: %v", local.Key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", local.Key, err))
+			continue
+		}
+		log.Printf("[DEBUG] Push: Created %s as %s", local.Key, key)
+		result.Created++
+		created = append(created, createdIssue{key: key, remote: remote})
+	}
 
-		// Fetch current remote version for conflict detection
-		remoteIssue, err := client.GetIssue(ctx, localIssue.JiraKey, memberCache)
+	for _, c := range created {
+		renamed, err := storage.ReadIssue(c.key)
+		if err == nil {
+			err = pushIssue(ctx, client, storage, renamed, c.remote, memberCache)
+		}
 		if err != nil {
-			log.Printf("[ERROR] Push: Failed to fetch remote %s: %v", localIssue.JiraKey, err)
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to fetch remote: %v", localIssue.JiraKey, err))
+			log.Printf("[ERROR] Push: Failed to push created issue %s: %v", c.key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", c.key, err))
 			continue
 		}
+		result.Pushed++
+	}
 
-		// Compute remote hash
-		remoteHash := storage.ComputeHash(remoteIssue)
+	// Re-read the other issues; references to the temporary keys were rewritten
+	reread := make([]*issue.Issue, 0, len(existing))
+	for _, local := range existing {
+		current, err := storage.ReadIssue(local.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issue %s: %w", local.Key, err)
+		}
+		reread = append(reread, current)
+	}
+	return reread, nil
+}
 
-		log.Printf("[DEBUG] Push: Issue %s remote hash=%s", localIssue.JiraKey, remoteHash[:8])
+// createIssue creates a local-only issue in Jira with the fields accepted on
+// creation, renames its file to the new key and records the remote ID and
+// base hash, so a failed follow-up push is retried as an update instead of
+// creating a duplicate. Returns the new key and the issue as created.
+func createIssue(ctx context.Context, client *Client, storage *issue.Storage, config *JiraConfig, local *issue.Issue, newKeys map[string]bool, memberCache *issue.MemberCache) (string, *issue.Issue, error) {
+	if strings.TrimSpace(local.Title) == "" {
+		return "", nil, fmt.Errorf("cannot create issue: title is required")
+	}
 
-		// Check for conflict: remote != base (remote was modified)
-		if remoteHash != baseHash {
-			log.Printf("[WARN] Push: Conflict detected for %s (remote modified)", localIssue.JiraKey)
-			result.Conflicts = append(result.Conflicts, ConflictInfo{
-				IssueKey: localIssue.JiraKey,
-				Updated:  remoteIssue.Updated,
-			})
-			continue
+	issueType := local.Type
+	if issueType == "" {
+		issueType = DefaultIssueType
+	}
+	fields := map[string]interface{}{
+		"project":   map[string]string{"key": projectForKey(config, local.Key)},
+		"issuetype": jiraNamed{Name: issueType},
+		"summary":   local.Title,
+	}
+	if local.Description != "" {
+		fields["description"] = local.Description
+	}
+	if len(local.Labels) > 0 {
+		fields["labels"] = issue.NormalizeLabels(local.Labels)
+	}
+	if local.Assignee != "" {
+		ref, err := client.userRef(local.Assignee, memberCache)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid assignee: %w", err)
 		}
+		fields["assignee"] = ref
+	}
+	// A parent that is itself new gets its key later and is set by the follow-up push
+	if local.Parent != "" && !newKeys[local.Parent] {
+		fields["parent"] = map[string]string{"key": local.Parent}
+	}
 
-		// No conflict: safe to push
-		log.Printf("[DEBUG] Push: Pushing changes for %s", localIssue.JiraKey)
-		if err := pushIssue(ctx, client, storage, localIssue, remoteIssue); err != nil {
-			log.Printf("[ERROR] Push: Failed to push %s: %v", localIssue.JiraKey, err)
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", localIssue.JiraKey, err))
-			continue
+	created, err := client.CreateIssue(ctx, fields)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := storage.RenameIssue(local.Key, created.Key); err != nil {
+		return "", nil, fmt.Errorf("created %s but failed to rename the file: %w", created.Key, err)
+	}
+	renamed, err := storage.ReadIssue(created.Key)
+	if err != nil {
+		return "", nil, fmt.Errorf("created %s but failed to read it back: %w", created.Key, err)
+	}
+	renamed.RemoteID = created.ID
+
+	remote, err := client.GetIssue(ctx, created.Key, memberCache)
+	if err != nil {
+		// Keep the remote ID so the next push does not create the issue again
+		if werr := storage.WriteIssue(renamed); werr != nil {
+			log.Printf("[WARN] createIssue: Failed to record remote ID of %s: %v", created.Key, werr)
 		}
+		return "", nil, fmt.Errorf("created %s but failed to fetch it: %w", created.Key, err)
+	}
 
-		result.Pushed++
-		log.Printf("[DEBUG] Push: Successfully pushed %s", localIssue.JiraKey)
+	// The issue starts in the workflow's initial status unless one was set
+	if renamed.Status == "" {
+		renamed.Status = remote.Status
 	}
+	renamed.Hash = storage.ComputeHash(remote)
+	if err := storage.WriteIssue(renamed); err != nil {
+		return "", nil, fmt.Errorf("created %s but failed to save it: %w", created.Key, err)
+	}
+	return created.Key, remote, nil
+}
 
-	log.Printf("[DEBUG] Push: Complete - Scanned: %d, Pushed: %d, Skipped: %d, Conflicts: %d, Errors: %d",
-		result.Scanned, result.Pushed, result.Skipped, len(result.Conflicts), len(result.Errors))
+// projectForKey returns the project a new issue is created in: the configured
+// project its key prefix names, or the main project
+func projectForKey(config *JiraConfig, key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		for _, project := range config.ProjectKeys() {
+			if strings.EqualFold(project, key[:i]) {
+				return project
+			}
+		}
+	}
+	return config.Project
+}
 
-	// Return error if conflicts detected
-	if len(result.Conflicts) > 0 {
-		return result, fmt.Errorf("cannot push - %d issue(s) have conflicts", len(result.Conflicts))
+// pushOutcome is the result of pushing a single issue.
+// An outcome with no conflict, error or push means the issue was skipped.
+type pushOutcome struct {
+	pushed   bool
+	conflict *issue.ConflictInfo
+	err      string
+}
+
+// pushOne checks a single issue for local changes and conflicts, then pushes it
+func pushOne(ctx context.Context, client *Client, storage *issue.Storage, localIssue *issue.Issue, memberCache *issue.MemberCache) pushOutcome {
+	log.Printf("[DEBUG] Push: Processing issue %s", localIssue.Key)
+
+	// Compute local hash
+	localHash := storage.ComputeHash(localIssue)
+
+	// Compare with base hash (from file)
+	baseHash := localIssue.Hash
+
+	// If local == base, no changes to push
+	if localHash == baseHash {
+		log.Printf("[DEBUG] Push: Skipping %s (no local changes)", localIssue.Key)
+		return pushOutcome{}
 	}
 
-	return result, nil
+	log.Printf("[DEBUG] Push: Issue %s has local changes (base=%s, local=%s)",
+		localIssue.Key, shortHash(baseHash), shortHash(localHash))
+
+	// Fetch current remote version for conflict detection
+	remoteIssue, err := client.GetIssue(ctx, localIssue.Key, memberCache)
+	if err != nil {
+		log.Printf("[ERROR] Push: Failed to fetch remote %s: %v", localIssue.Key, err)
+		return pushOutcome{err: fmt.Sprintf("%s: failed to fetch remote: %v", localIssue.Key, err)}
+	}
+
+	// Compute remote hash
+	remoteHash := storage.ComputeHash(remoteIssue)
+
+	log.Printf("[DEBUG] Push: Issue %s remote hash=%s", localIssue.Key, shortHash(remoteHash))
+
+	// Check for conflict: remote != base (remote was modified)
+	if remoteHash != baseHash {
+		log.Printf("[WARN] Push: Conflict detected for %s (remote modified)", localIssue.Key)
+		return pushOutcome{conflict: &issue.ConflictInfo{
+			IssueKey: localIssue.Key,
+			Updated:  remoteIssue.Updated,
+		}}
+	}
+
+	// No conflict: safe to push
+	log.Printf("[DEBUG] Push: Pushing changes for %s", localIssue.Key)
+	if err := pushIssue(ctx, client, storage, localIssue, remoteIssue, memberCache); err != nil {
+		log.Printf("[ERROR] Push: Failed to push %s: %v", localIssue.Key, err)
+		return pushOutcome{err: fmt.Sprintf("%s: %v", localIssue.Key, err)}
+	}
+
+	log.Printf("[DEBUG] Push: Successfully pushed %s", localIssue.Key)
+	return pushOutcome{pushed: true}
+}
+
+// shortHash returns the first 8 characters of a hash for logging
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
 }
 
 // pushIssue pushes changes for a single issue to Jira
 // Compares local vs remote and updates only what changed
-func pushIssue(ctx context.Context, client *Client, storage *Storage, local *Issue, remote *Issue) error {
+func pushIssue(ctx context.Context, client *Client, storage *issue.Storage, local *issue.Issue, remote *issue.Issue, memberCache *issue.MemberCache) error {
 	updates := make(map[string]interface{})
 	hasUpdates := false
 
 	// Check title (summary)
 	if local.Title != remote.Title {
-		log.Printf("[DEBUG] pushIssue: Title changed for %s", local.JiraKey)
+		log.Printf("[DEBUG] pushIssue: Title changed for %s", local.Key)
 		updates["summary"] = local.Title
 		hasUpdates = true
 	}
 
 	// Check description
 	if local.Description != remote.Description {
-		log.Printf("[DEBUG] pushIssue: Description changed for %s", local.JiraKey)
+		log.Printf("[DEBUG] pushIssue: Description changed for %s", local.Key)
 		updates["description"] = local.Description
 		hasUpdates = true
 	}
 
 	// Check labels (order-insensitive comparison to avoid churn from Jira reordering)
 	if !equalStringSlicesIgnoreOrder(local.Labels, remote.Labels) {
-		log.Printf("[DEBUG] pushIssue: Labels changed for %s", local.JiraKey)
+		log.Printf("[DEBUG] pushIssue: Labels changed for %s", local.Key)
 		// Normalize label order for stability
-		updates["labels"] = normalizeLabels(local.Labels)
+		updates["labels"] = issue.NormalizeLabels(local.Labels)
+		hasUpdates = true
+	}
+
+	// Check assignee, resolved to an account through the member cache
+	if local.Assignee != remote.Assignee {
+		log.Printf("[DEBUG] pushIssue: Assignee changed for %s", local.Key)
+		if local.Assignee == "" {
+			updates["assignee"] = nil
+		} else {
+			ref, err := client.userRef(local.Assignee, memberCache)
+			if err != nil {
+				return fmt.Errorf("invalid assignee: %w", err)
+			}
+			updates["assignee"] = ref
+		}
+		hasUpdates = true
+	}
+
+	// Check planning fields; sprint is read-only (it is changed on the board)
+	if local.Type != remote.Type && local.Type != "" {
+		log.Printf("[DEBUG] pushIssue: Type changed for %s", local.Key)
+		updates["issuetype"] = jiraNamed{Name: local.Type}
+		hasUpdates = true
+	}
+	if local.Priority != remote.Priority && local.Priority != "" {
+		log.Printf("[DEBUG] pushIssue: Priority changed for %s", local.Key)
+		updates["priority"] = jiraNamed{Name: local.Priority}
+		hasUpdates = true
+	}
+	if !equalStringSlicesIgnoreOrder(local.Components, remote.Components) {
+		log.Printf("[DEBUG] pushIssue: Components changed for %s", local.Key)
+		updates["components"] = namedRefs(local.Components)
+		hasUpdates = true
+	}
+	if !equalStringSlicesIgnoreOrder(local.FixVersions, remote.FixVersions) {
+		log.Printf("[DEBUG] pushIssue: Fix versions changed for %s", local.Key)
+		updates["fixVersions"] = namedRefs(local.FixVersions)
+		hasUpdates = true
+	}
+	if local.DueDate != remote.DueDate {
+		log.Printf("[DEBUG] pushIssue: Due date changed for %s", local.Key)
+		updates["duedate"] = nullIfEmpty(local.DueDate)
+		hasUpdates = true
+	}
+	if local.Parent != remote.Parent {
+		log.Printf("[DEBUG] pushIssue: Parent changed for %s", local.Key)
+		if local.Parent == "" {
+			updates["parent"] = nil
+		} else {
+			updates["parent"] = map[string]string{"key": local.Parent}
+		}
+		hasUpdates = true
+	}
+	if local.StoryPoints != remote.StoryPoints {
+		log.Printf("[DEBUG] pushIssue: Story points changed for %s", local.Key)
+		if local.StoryPoints == 0 {
+			updates[client.storyPointsField] = nil
+		} else {
+			updates[client.storyPointsField] = local.StoryPoints
+		}
+		hasUpdates = true
+	}
+
+	// Check estimates, compared as durations so "90m" matches Jira's "1h 30m".
+	// Estimates cleared locally are left alone.
+	remainingChanged := local.RemainingEstimate != "" && !sameWorkDuration(local.RemainingEstimate, remote.RemainingEstimate)
+	if remainingChanged || local.OriginalEstimate != "" && !sameWorkDuration(local.OriginalEstimate, remote.OriginalEstimate) {
+		log.Printf("[DEBUG] pushIssue: Estimates changed for %s", local.Key)
+		updates["timetracking"] = jiraTimeTracking{
+			OriginalEstimate:  local.OriginalEstimate,
+			RemainingEstimate: local.RemainingEstimate,
+		}
+		hasUpdates = true
+	}
+
+	// Check mapped custom fields, compared by their canonical encoding
+	for _, cf := range client.customFields {
+		localValue, remoteValue := local.Custom[cf.name], remote.Custom[cf.name]
+		if issue.CanonicalValue(localValue) == issue.CanonicalValue(remoteValue) {
+			continue
+		}
+		log.Printf("[DEBUG] pushIssue: Custom field %s changed for %s", cf.name, local.Key)
+		encoded, err := client.encodeCustomField(cf.info, localValue, memberCache)
+		if err != nil {
+			return fmt.Errorf("invalid custom field %s: %w", cf.name, err)
+		}
+		updates[cf.info.ID] = encoded
 		hasUpdates = true
 	}
 
 	// Update issue fields if any changed
 	if hasUpdates {
-		if err := client.UpdateIssue(ctx, local.JiraKey, updates); err != nil {
+		if err := client.UpdateIssue(ctx, local.Key, updates); err != nil {
 			return fmt.Errorf("failed to update issue fields: %w", err)
 		}
 	}
 
 	// Check status (requires workflow transition)
 	if local.Status != remote.Status {
-		log.Printf("[DEBUG] pushIssue: Status changed for %s (%s → %s)", local.JiraKey, remote.Status, local.Status)
+		log.Printf("[DEBUG] pushIssue: Status changed for %s (%s → %s)", local.Key, remote.Status, local.Status)
 
 		// Validate status against workflow cache
-		workflowCache, err := LoadWorkflowCache(storage.projectPath)
+		workflowCache, err := LoadWorkflowCache(storage.ProjectPath())
 		if err != nil {
 			log.Printf("[WARN] pushIssue: Failed to load workflow cache: %v", err)
-			workflowCache = NewWorkflowCache()
+			workflowCache = issue.NewWorkflowCache()
 		}
 
 		// Check if target status exists in workflow
@@ -186,7 +449,7 @@ func pushIssue(ctx context.Context, client *Client, storage *Storage, local *Iss
 		}
 
 		// Get available transitions for this issue
-		transitions, err := client.GetTransitions(ctx, local.JiraKey)
+		transitions, err := client.GetTransitions(ctx, local.Key)
 		if err != nil {
 			return fmt.Errorf("failed to get transitions: %w", err)
 		}
@@ -212,32 +475,70 @@ func pushIssue(ctx context.Context, client *Client, storage *Storage, local *Iss
 		}
 
 		// Execute transition
-		if err := client.TransitionIssue(ctx, local.JiraKey, transitionID); err != nil {
+		if err := client.TransitionIssue(ctx, local.Key, transitionID); err != nil {
 			return fmt.Errorf("failed to transition issue: %w", err)
 		}
 	}
 
+	// Sync links: create local links missing remotely, delete remote links
+	// removed locally. Subtasks are changed through the subtask's parent.
+	for _, l := range local.Links {
+		if l.Type == issue.LinkTypeSubtask || remote.HasLink(l) {
+			continue
+		}
+		from, to := local.Key, l.Key
+		if l.Direction == issue.LinkInward {
+			from, to = l.Key, local.Key
+		}
+		if err := client.CreateIssueLink(ctx, l.Type, from, to); err != nil {
+			return fmt.Errorf("failed to link %s %s %s: %w", local.Key, l.Relation(), l.Key, err)
+		}
+	}
+	for _, l := range remote.Links {
+		if l.Type == issue.LinkTypeSubtask || l.ID == "" || local.HasLink(l) {
+			continue
+		}
+		if err := client.DeleteIssueLink(ctx, l.ID); err != nil {
+			return fmt.Errorf("failed to unlink %s %s %s: %w", local.Key, l.Relation(), l.Key, err)
+		}
+	}
+
 	// Check for new comments (local has more comments than remote)
 	// We only support adding new comments, not editing existing ones
 	if len(local.Comments) > len(remote.Comments) {
 		log.Printf("[DEBUG] pushIssue: Detected %d new comment(s) for %s",
-			len(local.Comments)-len(remote.Comments), local.JiraKey)
+			len(local.Comments)-len(remote.Comments), local.Key)
 
 		// Push new comments
 		for i := len(remote.Comments); i < len(local.Comments); i++ {
 			comment := local.Comments[i]
-			log.Printf("[DEBUG] pushIssue: Adding comment #%d to %s", i+1, local.JiraKey)
-			if err := client.AddComment(ctx, local.JiraKey, comment.Body); err != nil {
+			log.Printf("[DEBUG] pushIssue: Adding comment #%d to %s", i+1, local.Key)
+			if err := client.AddComment(ctx, local.Key, comment.Body); err != nil {
 				return fmt.Errorf("failed to add comment #%d: %w", i+1, err)
 			}
 		}
 	}
 
+	// Log new worklogs; like comments, existing ones are not edited. Jira
+	// reduces the remaining estimate unless it was set in this push.
+	adjustEstimate := "auto"
+	if remainingChanged {
+		adjustEstimate = "leave"
+	}
+	for _, w := range local.Worklogs {
+		if remote.HasWorklog(w) {
+			continue
+		}
+		log.Printf("[DEBUG] pushIssue: Logging %s on %s", issue.FormatWorkDuration(w.TimeSpent), local.Key)
+		if err := client.AddWorklog(ctx, local.Key, w, adjustEstimate); err != nil {
+			return fmt.Errorf("failed to log %s: %w", issue.FormatWorkDuration(w.TimeSpent), err)
+		}
+	}
+
 	// After successful push, update the local file with new hash
 	// We need to fetch the updated issue from Jira to get the correct hash
-	log.Printf("[DEBUG] pushIssue: Fetching updated issue %s from Jira", local.JiraKey)
-	memberCache, _ := LoadMembersCache(storage.projectPath)
-	updatedIssue, err := client.GetIssue(ctx, local.JiraKey, memberCache)
+	log.Printf("[DEBUG] pushIssue: Fetching updated issue %s from Jira", local.Key)
+	updatedIssue, err := client.GetIssue(ctx, local.Key, memberCache)
 	if err != nil {
 		log.Printf("[WARN] pushIssue: Failed to fetch updated issue, keeping local hash: %v", err)
 		// Don't fail - the push succeeded, we just couldn't update the hash
@@ -253,17 +554,23 @@ func pushIssue(ctx context.Context, client *Client, storage *Storage, local *Iss
 	return nil
 }
 
-// equalStringSlices compares two string slices for equality (order matters)
-func equalStringSlices(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
+// sameWorkDuration reports whether two work durations are equal, falling back
+// to comparing the strings when either does not parse
+func sameWorkDuration(a, b string) bool {
+	x, errA := issue.ParseWorkDuration(a)
+	y, errB := issue.ParseWorkDuration(b)
+	if errA != nil || errB != nil {
+		return a == b
 	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
+	return x == y
+}
+
+// nullIfEmpty returns nil for an empty string, which clears a Jira field
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
 	}
-	return true
+	return s
 }
 
 // equalStringSlicesIgnoreOrder compares two string slices as multisets (order-insensitive)
@@ -283,15 +590,3 @@ func equalStringSlicesIgnoreOrder(a, b []string) bool {
 	}
 	return true
 }
-
-// FormatConflictError formats conflict information into a user-friendly error message
-func FormatConflictError(conflicts []ConflictInfo) string {
-	var buf strings.Builder
-	buf.WriteString(fmt.Sprintf("Cannot push - %d issue(s) have conflicts:\n", len(conflicts)))
-	for _, c := range conflicts {
-		buf.WriteString(fmt.Sprintf("  - %s: Remote modified (last updated: %s)\n",
-			c.IssueKey, c.Updated.Format("2006-01-02 15:04")))
-	}
-	buf.WriteString("\nRun 'takl jira pull' to fetch remote changes, then push again.")
-	return buf.String()
-}
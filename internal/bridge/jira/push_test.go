@@ -0,0 +1,403 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// TestPush_ConcurrentAggregation tests that a parallel push reports pushes,
+// skips, conflicts and errors in scan order with nothing lost
+func TestPush_ConcurrentAggregation(t *testing.T) {
+	f := newFakeJira(t)
+	f.delay = 5 * time.Millisecond
+	for i := 1; i <= 8; i++ {
+		f.issues[fmt.Sprintf("PROJ-%d", i)] = &fakeIssue{summary: fmt.Sprintf("Issue %d", i)}
+	}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ", Concurrency: 4}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	// Edit everything except PROJ-8
+	for i := 1; i <= 7; i++ {
+		key := fmt.Sprintf("PROJ-%d", i)
+		edited, err := storage.ReadIssue(key)
+		if err != nil {
+			t.Fatalf("ReadIssue failed: %v", err)
+		}
+		edited.Title = "Edited " + key
+		if err := storage.WriteIssue(edited); err != nil {
+			t.Fatalf("WriteIssue failed: %v", err)
+		}
+	}
+
+	// PROJ-2 and PROJ-5 change remotely; PROJ-3 and PROJ-6 disappear
+	f.issues["PROJ-2"].summary = "Remote edit"
+	f.issues["PROJ-5"].summary = "Remote edit"
+	delete(f.issues, "PROJ-3")
+	delete(f.issues, "PROJ-6")
+
+	result, err := Push(context.Background(), f.client(), storage, config, "")
+	if err == nil {
+		t.Fatalf("Expected conflict error")
+	}
+
+	if result.Scanned != 8 || result.Pushed != 3 || result.Skipped != 1 {
+		t.Errorf("Expected scanned 8, pushed 3, skipped 1; got %d, %d, %d", result.Scanned, result.Pushed, result.Skipped)
+	}
+
+	var conflicts []string
+	for _, c := range result.Conflicts {
+		conflicts = append(conflicts, c.IssueKey)
+	}
+	if strings.Join(conflicts, ",") != "PROJ-2,PROJ-5" {
+		t.Errorf("Expected conflicts [PROJ-2 PROJ-5] in order, got %v", conflicts)
+	}
+
+	if len(result.Errors) != 2 || !strings.HasPrefix(result.Errors[0], "PROJ-3:") || !strings.HasPrefix(result.Errors[1], "PROJ-6:") {
+		t.Errorf("Expected errors for PROJ-3 then PROJ-6, got %v", result.Errors)
+	}
+
+	if f.issues["PROJ-7"].summary != "Edited PROJ-7" {
+		t.Errorf("Expected PROJ-7 to be pushed, got summary %q", f.issues["PROJ-7"].summary)
+	}
+	if got := f.maxInFlight.Load(); got > 4 {
+		t.Errorf("Expected at most 4 concurrent requests, got %d", got)
+	}
+}
+
+// TestPush_PlanningFields tests that edited planning fields are sent as Jira
+// field references and cleared fields as null
+func TestPush_PlanningFields(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Planned", fields: map[string]interface{}{
+		"priority": map[string]string{"name": "Low"},
+		"duedate":  "2025-03-31",
+	}}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ"}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	edited, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Priority = "High"
+	edited.Components = []string{"API"}
+	edited.DueDate = ""
+	edited.StoryPoints = 3
+	if err := storage.WriteIssue(edited); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Push failed: %v (errors: %v)", err, result.Errors)
+	}
+	if result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %d (errors: %v)", result.Pushed, result.Errors)
+	}
+
+	got := fmt.Sprint(f.lastUpdate)
+	want := fmt.Sprint(map[string]interface{}{
+		"priority":              map[string]interface{}{"name": "High"},
+		"components":            []interface{}{map[string]interface{}{"name": "API"}},
+		"duedate":               nil,
+		DefaultStoryPointsField: float64(3),
+	})
+	if got != want {
+		t.Errorf("Expected update %s, got %s", want, got)
+	}
+
+	pushed, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if pushed.Priority != "High" || pushed.StoryPoints != 3 || pushed.Hash != storage.ComputeHash(pushed) {
+		t.Errorf("Expected the refreshed issue to be saved with a fresh hash, got %+v", pushed)
+	}
+}
+
+// TestPush_Assignee tests that a reassignment is pushed as the account ID of
+// the cached member
+func TestPush_Assignee(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Unassigned"}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ"}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	cache := issue.NewMemberCache()
+	cache.Add(&issue.Member{AccountID: "a-2", DisplayName: "Bob Jones", EmailAddress: "bob@example.com", Active: true})
+	if err := SaveMembersCache(storage.ProjectPath(), cache); err != nil {
+		t.Fatalf("SaveMembersCache failed: %v", err)
+	}
+
+	edited, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Assignee = "Bob Jones <bob@example.com>"
+	if err := storage.WriteIssue(edited); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Push failed: %v (errors: %v)", err, result.Errors)
+	}
+	if result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %d (errors: %v)", result.Pushed, result.Errors)
+	}
+	if got := fmt.Sprint(f.lastUpdate); got != "map[assignee:map[accountId:a-2]]" {
+		t.Errorf("Expected the assignee update, got %s", got)
+	}
+
+	pushed, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if pushed.Assignee != "Bob Jones <bob@example.com>" || pushed.Hash != storage.ComputeHash(pushed) {
+		t.Errorf("Expected the reassigned issue saved with a fresh hash, got %+v", pushed)
+	}
+}
+
+// TestPush_Links tests that links are pulled with their direction and that
+// local additions and removals are created and deleted on push
+func TestPush_Links(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Linked", fields: map[string]interface{}{
+		"issuelinks": []map[string]interface{}{
+			{"id": "501", "type": map[string]string{"name": "Relates"}, "outwardIssue": map[string]string{"key": "PROJ-9"}},
+			{"id": "502", "type": map[string]string{"name": "Blocks"}, "inwardIssue": map[string]string{"key": "PROJ-3"}},
+		},
+		"subtasks": []map[string]string{{"key": "PROJ-4"}},
+	}}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ"}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	pulled, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	var relations []string
+	for _, l := range pulled.Links {
+		relations = append(relations, l.Relation()+" "+l.Key)
+	}
+	if got, want := strings.Join(relations, "; "), "is blocked by PROJ-3; relates to PROJ-9; has subtask PROJ-4"; got != want {
+		t.Errorf("Expected links %q, got %q", want, got)
+	}
+
+	// Drop the Relates link, keep the subtask, add two blockers
+	pulled.Links = []issue.Link{
+		pulled.Links[0],
+		pulled.Links[2],
+		{Type: "Blocks", Direction: issue.LinkOutward, Key: "PROJ-5"},
+		{Type: "Blocks", Direction: issue.LinkInward, Key: "PROJ-6"},
+	}
+	if err := storage.WriteIssue(pulled); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "PROJ-1")
+	if err != nil || result.Pushed != 1 {
+		t.Fatalf("Push failed: %v (result: %+v)", err, result)
+	}
+
+	// Links are stored sorted by type, direction and key
+	want := []string{"create PROJ-6 Blocks PROJ-1", "create PROJ-1 Blocks PROJ-5", "delete 501"}
+	if strings.Join(f.linkCalls, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected link calls %v, got %v", want, f.linkCalls)
+	}
+}
+
+// TestPush_CreatesLocalIssues tests that local-only issues are created, renamed
+// to their new keys, and that references to the temporary keys are rewritten
+func TestPush_CreatesLocalIssues(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Existing"}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ"}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	// Two new issues: NEW-1 blocks NEW-2, and NEW-2 is a child of NEW-1
+	drafts := []*issue.Issue{
+		{Key: "NEW-1", Title: "First", Type: "Bug", Labels: []string{"b", "a"}, Description: "Some *text*",
+			Links: []issue.Link{{Type: "Blocks", Direction: issue.LinkOutward, Key: "NEW-2"}}},
+		{Key: "NEW-2", Title: "Second", Parent: "NEW-1"},
+	}
+	for _, draft := range drafts {
+		if err := storage.WriteIssue(draft); err != nil {
+			t.Fatalf("WriteIssue failed: %v", err)
+		}
+	}
+	existing, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	existing.Links = []issue.Link{{Type: "Blocks", Direction: issue.LinkInward, Key: "NEW-1"}}
+	if err := storage.WriteIssue(existing); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "")
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if result.Created != 2 || result.Pushed != 3 || len(result.Errors) != 0 {
+		t.Fatalf("Expected 2 created and 3 pushed, got %+v", result)
+	}
+
+	// NEW-1 and NEW-2 became PROJ-2 and PROJ-3
+	for _, key := range []string{"NEW-1", "NEW-2"} {
+		if _, err := storage.ReadIssue(key); err == nil {
+			t.Errorf("Expected %s to be renamed", key)
+		}
+	}
+	for key, id := range map[string]string{"PROJ-2": "2", "PROJ-3": "3"} {
+		created, err := storage.ReadIssue(key)
+		if err != nil {
+			t.Fatalf("ReadIssue %s failed: %v", key, err)
+		}
+		if created.RemoteID != id {
+			t.Errorf("Expected %s to have remote ID %q, got %q", key, id, created.RemoteID)
+		}
+	}
+
+	first := f.issues["PROJ-2"]
+	if first.summary != "First" || first.fields["issuetype"].(map[string]interface{})["name"] != "Bug" {
+		t.Errorf("Expected PROJ-2 to be a Bug titled First, got %q %v", first.summary, first.fields["issuetype"])
+	}
+	if fmt.Sprint(first.fields["labels"]) != "[a b]" {
+		t.Errorf("Expected labels [a b], got %v", first.fields["labels"])
+	}
+	if f.lastCreate["project"].(map[string]interface{})["key"] != "PROJ" {
+		t.Errorf("Expected issues to be created in PROJ, got %v", f.lastCreate["project"])
+	}
+	if parent, _ := f.issues["PROJ-3"].fields["parent"].(map[string]interface{}); parent["key"] != "PROJ-2" {
+		t.Errorf("Expected PROJ-3 to get parent PROJ-2, got %v", f.issues["PROJ-3"].fields["parent"])
+	}
+
+	want := []string{"create PROJ-2 Blocks PROJ-3", "create PROJ-2 Blocks PROJ-1"}
+	if strings.Join(f.linkCalls, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected link calls %v, got %v", want, f.linkCalls)
+	}
+
+	// Everything is in sync afterwards
+	result, err = Push(context.Background(), f.client(), storage, config, "")
+	if err != nil || result.Created != 0 || result.Pushed != 0 {
+		t.Errorf("Expected nothing to push, got %+v (err: %v)", result, err)
+	}
+}
+
+// TestPush_Worklogs tests that worklogs and estimates are pulled, and that new
+// local worklogs and estimate changes are pushed
+func TestPush_Worklogs(t *testing.T) {
+	f := newFakeJira(t)
+	f.issues["PROJ-1"] = &fakeIssue{summary: "Tracked", fields: map[string]interface{}{
+		"timetracking": map[string]string{"originalEstimate": "2d", "remainingEstimate": "1d 4h"},
+		"worklog": map[string]interface{}{"total": 1, "worklogs": []map[string]interface{}{{
+			"id":               "100",
+			"author":           map[string]string{"accountId": "a-1", "displayName": "Ann"},
+			"started":          "2025-03-10T09:00:00.000+0000",
+			"timeSpentSeconds": 5400,
+		}}},
+	}}
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	config := &JiraConfig{Project: "PROJ"}
+	if _, err := Pull(context.Background(), f.client(), storage, config); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+
+	pulled, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if pulled.OriginalEstimate != "2d" || pulled.RemainingEstimate != "1d 4h" {
+		t.Errorf("Expected estimates 2d and 1d 4h, got %q and %q", pulled.OriginalEstimate, pulled.RemainingEstimate)
+	}
+	if len(pulled.Worklogs) != 1 || pulled.Worklogs[0].Author != "Ann" || pulled.Worklogs[0].TimeSpent != 5400 {
+		t.Fatalf("Expected the pulled worklog, got %+v", pulled.Worklogs)
+	}
+
+	// Log 45 minutes; a remaining estimate of "12h" equals Jira's "1d 4h"
+	pulled.Worklogs = append(pulled.Worklogs, issue.Worklog{
+		Started:   time.Date(2025, 3, 11, 14, 30, 0, 0, time.UTC),
+		TimeSpent: 2700,
+	})
+	pulled.RemainingEstimate = "12h"
+	if err := storage.WriteIssue(pulled); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+
+	result, err := Push(context.Background(), f.client(), storage, config, "PROJ-1")
+	if err != nil || result.Pushed != 1 {
+		t.Fatalf("Push failed: %v (result: %+v)", err, result)
+	}
+	if f.lastUpdate != nil {
+		t.Errorf("Expected no field update for an equal estimate, got %v", f.lastUpdate)
+	}
+	want := []string{"PROJ-1 2700s 2025-03-11T14:30:00.000+0000 adjust=auto"}
+	if strings.Join(f.worklogCalls, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected worklog calls %v, got %v", want, f.worklogCalls)
+	}
+
+	// A changed remaining estimate is sent and kept when logging more time
+	edited, err := storage.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.RemainingEstimate = "4h"
+	edited.Worklogs = append(edited.Worklogs, issue.Worklog{Started: time.Date(2025, 3, 12, 8, 0, 0, 0, time.UTC), TimeSpent: 3600})
+	if err := storage.WriteIssue(edited); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+	f.worklogCalls = nil
+	if _, err := Push(context.Background(), f.client(), storage, config, "PROJ-1"); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if got := fmt.Sprint(f.lastUpdate["timetracking"]); got != "map[originalEstimate:2d remainingEstimate:4h]" {
+		t.Errorf("Expected timetracking update, got %s", got)
+	}
+	if len(f.worklogCalls) != 1 || !strings.HasSuffix(f.worklogCalls[0], "adjust=leave") {
+		t.Errorf("Expected one worklog keeping the estimate, got %v", f.worklogCalls)
+	}
+}
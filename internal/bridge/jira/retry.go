@@ -0,0 +1,184 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryTransport is an http.RoundTripper that rate-limits outgoing requests
+// and retries 429, 502, 503 and 504 responses with exponential backoff.
+//
+// Retry-After headers are honored when they fit within maxDelay; longer waits
+// return the response as-is rather than stalling a pull. Network errors, 502
+// and 504 are only retried for requests that are safe to re-send (see
+// isResendable), since the request may have been applied; 429 and 503 mean it
+// was not processed and are retried for all.
+type retryTransport struct {
+	base       http.RoundTripper
+	limiter    *rateLimiter
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	sleep      func(ctx context.Context, d time.Duration) error // Replaced in tests
+}
+
+// newRetryTransport wraps base with the package's default retry and rate-limit settings
+func newRetryTransport(base http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		base:       base,
+		limiter:    newRateLimiter(RequestsPerSecond, RequestBurst),
+		maxRetries: MaxRetries,
+		baseDelay:  RetryBaseDelay,
+		maxDelay:   RetryMaxDelay,
+		sleep:      sleepContext,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if !isResendable(req) {
+				return nil, err
+			}
+			delay = t.backoff(attempt)
+			log.Printf("[WARN] Jira: %s %s failed, retrying in %v (attempt %d/%d): %v",
+				req.Method, req.URL.Path, delay, attempt+1, t.maxRetries, err)
+
+		case isRetryableStatus(resp.StatusCode) && (isUnprocessedStatus(resp.StatusCode) || isResendable(req)):
+			delay = t.backoff(attempt)
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > t.maxDelay {
+					log.Printf("[WARN] Jira: %s %s returned %d with Retry-After %v, not retrying",
+						req.Method, req.URL.Path, resp.StatusCode, retryAfter)
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			log.Printf("[WARN] Jira: %s %s returned %d, retrying in %v (attempt %d/%d)",
+				req.Method, req.URL.Path, resp.StatusCode, delay, attempt+1, t.maxRetries)
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, MaxErrorBodySize))
+			resp.Body.Close()
+
+		default:
+			return resp, nil
+		}
+
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the exponential delay for an attempt with jitter in [d/2, d]
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.baseDelay << attempt
+	if d > t.maxDelay || d <= 0 {
+		d = t.maxDelay
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return out, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("cannot retry %s %s: request body is not replayable", req.Method, req.URL.Path)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+	out.Body = body
+	return out, nil
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isUnprocessedStatus reports whether a retryable status means the server did
+// not process the request, so that any request can be re-sent
+func isUnprocessedStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// isResendable reports whether a request can be safely re-sent after it may
+// have been applied: idempotent methods and searches, which POST only to read
+func isResendable(req *http.Request) bool {
+	if isIdempotent(req.Method) {
+		return true
+	}
+	return req.Method == http.MethodPost && (req.URL.Path == "/rest/api/3/search/jql" || strings.HasSuffix(req.URL.Path, "/search"))
+}
+
+// isIdempotent reports whether a request method can be safely re-sent
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		d := at.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
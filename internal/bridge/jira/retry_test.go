@@ -0,0 +1,291 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// scriptedServer replies with the given statuses in order, then 200 with body
+type scriptedServer struct {
+	mu       sync.Mutex
+	statuses []int
+	headers  map[int]http.Header // Extra headers by call index
+	body     string
+	calls    int
+	bodies   []string // Request bodies received
+	server   *httptest.Server
+}
+
+func newScriptedServer(t *testing.T, body string, statuses ...int) *scriptedServer {
+	t.Helper()
+	s := &scriptedServer{statuses: statuses, headers: make(map[int]http.Header), body: body}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		data, _ := io.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(data))
+		for k, v := range s.headers[s.calls] {
+			w.Header()[k] = v
+		}
+		status := http.StatusOK
+		if s.calls < len(s.statuses) {
+			status = s.statuses[s.calls]
+		}
+		s.calls++
+		w.WriteHeader(status)
+		io.WriteString(w, s.body)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// testClient returns a client for the server whose backoff sleeps are recorded
+// instead of slept, and without client-side rate limiting
+func testClient(s *scriptedServer, delays *[]time.Duration) *Client {
+	c := NewClient(s.server.URL, "me@example.com", "token")
+	rt := c.httpClient.Transport.(*retryTransport)
+	rt.limiter = nil
+	rt.sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return ctx.Err()
+	}
+	return c
+}
+
+// TestRetry_TransientErrors tests that 502/503 responses are retried until success
+func TestRetry_TransientErrors(t *testing.T) {
+	s := newScriptedServer(t, `{}`, http.StatusServiceUnavailable, http.StatusBadGateway)
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	resp, err := c.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if s.calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", s.calls)
+	}
+	if len(delays) != 2 {
+		t.Fatalf("Expected 2 backoff sleeps, got %d", len(delays))
+	}
+	// Jitter keeps each delay within [d/2, d] of the exponential step
+	if delays[0] < RetryBaseDelay/2 || delays[0] > RetryBaseDelay {
+		t.Errorf("Expected first delay within [%v, %v], got %v", RetryBaseDelay/2, RetryBaseDelay, delays[0])
+	}
+	if delays[1] < RetryBaseDelay || delays[1] > 2*RetryBaseDelay {
+		t.Errorf("Expected second delay within [%v, %v], got %v", RetryBaseDelay, 2*RetryBaseDelay, delays[1])
+	}
+}
+
+// TestRetry_HonorsRetryAfter tests that a 429 waits as long as Retry-After says
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	s := newScriptedServer(t, `{}`, http.StatusTooManyRequests)
+	s.headers[0] = http.Header{"Retry-After": {"7"}}
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	resp, err := c.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err != nil {
+		t.Fatalf("doRequest failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(delays) != 1 || delays[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s delay, got %v", delays)
+	}
+}
+
+// TestRetry_RetryAfterTooLong tests that waits beyond the cap fail fast
+func TestRetry_RetryAfterTooLong(t *testing.T) {
+	s := newScriptedServer(t, `rate limited`, http.StatusTooManyRequests)
+	s.headers[0] = http.Header{"Retry-After": {"3600"}}
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	_, err := c.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err == nil {
+		t.Fatalf("Expected error for 429")
+	}
+	if s.calls != 1 || len(delays) != 0 {
+		t.Errorf("Expected no retry, got %d calls and delays %v", s.calls, delays)
+	}
+}
+
+// TestRetry_GivesUp tests that the last response is returned once retries run out
+func TestRetry_GivesUp(t *testing.T) {
+	statuses := make([]int, MaxRetries+5)
+	for i := range statuses {
+		statuses[i] = http.StatusServiceUnavailable
+	}
+	s := newScriptedServer(t, `down`, statuses...)
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	_, err := c.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil)
+	if err == nil {
+		t.Fatalf("Expected error after retries ran out")
+	}
+	if s.calls != MaxRetries+1 {
+		t.Errorf("Expected %d calls, got %d", MaxRetries+1, s.calls)
+	}
+}
+
+// TestRetry_NotRetryable tests that client errors are returned immediately
+func TestRetry_NotRetryable(t *testing.T) {
+	s := newScriptedServer(t, `bad`, http.StatusBadRequest)
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	if _, err := c.doRequest(context.Background(), "GET", "/rest/api/3/myself", nil); err == nil {
+		t.Fatalf("Expected error for 400")
+	}
+	if s.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", s.calls)
+	}
+}
+
+// TestRetry_ReplaysBody tests that POST bodies are re-sent on retry
+func TestRetry_ReplaysBody(t *testing.T) {
+	s := newScriptedServer(t, `{}`, http.StatusServiceUnavailable)
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	resp, err := c.doRequest(context.Background(), "POST", "/rest/api/3/search/jql", map[string]string{"jql": "project = X"})
+	if err != nil {
+		t.Fatalf("doRequest failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(s.bodies) != 2 || s.bodies[0] == "" || s.bodies[0] != s.bodies[1] {
+		t.Errorf("Expected identical bodies on both attempts, got %q", s.bodies)
+	}
+}
+
+// TestRetry_NonIdempotentGatewayError tests that a POST which may have been
+// processed is not re-sent on 502, but is on 503
+func TestRetry_NonIdempotentGatewayError(t *testing.T) {
+	s := newScriptedServer(t, `bad gateway`, http.StatusBadGateway)
+	var delays []time.Duration
+	c := testClient(s, &delays)
+
+	if _, err := c.doRequest(context.Background(), "POST", "/rest/api/3/issue/PROJ-1/comment", map[string]string{"body": "hi"}); err == nil {
+		t.Fatalf("Expected error for 502")
+	}
+	if s.calls != 1 || len(delays) != 0 {
+		t.Errorf("Expected no retry, got %d calls and delays %v", s.calls, delays)
+	}
+
+	s = newScriptedServer(t, `{}`, http.StatusServiceUnavailable)
+	c = testClient(s, &delays)
+	resp, err := c.doRequest(context.Background(), "POST", "/rest/api/3/issue/PROJ-1/comment", map[string]string{"body": "hi"})
+	if err != nil {
+		t.Fatalf("doRequest failed: %v", err)
+	}
+	resp.Body.Close()
+	if s.calls != 2 {
+		t.Errorf("Expected 503 to be retried, got %d calls", s.calls)
+	}
+}
+
+// TestRetry_ContextCanceled tests that a canceled context stops retrying
+func TestRetry_ContextCanceled(t *testing.T) {
+	s := newScriptedServer(t, `{}`, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	c := NewClient(s.server.URL, "me@example.com", "token")
+	rt := c.httpClient.Transport.(*retryTransport)
+	rt.limiter = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rt.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleepContext(ctx, d)
+	}
+
+	_, err := c.doRequest(ctx, "GET", "/rest/api/3/myself", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if s.calls != 1 {
+		t.Errorf("Expected 1 call before cancellation, got %d", s.calls)
+	}
+}
+
+// TestParseRetryAfter tests both Retry-After formats
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"Wed, 01 Jan 2025 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 Jan 2025 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; expected %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestRateLimiter_TokenBucket tests that the burst is free and later requests
+// are spaced at the configured rate
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(10, 2)
+	l.now = func() time.Time { return now }
+
+	if d := l.reserve(); d != 0 {
+		t.Errorf("Expected first request to be free, got %v", d)
+	}
+	if d := l.reserve(); d != 0 {
+		t.Errorf("Expected second request to be free, got %v", d)
+	}
+	if d := l.reserve(); d != 100*time.Millisecond {
+		t.Errorf("Expected third request to wait 100ms, got %v", d)
+	}
+	if d := l.reserve(); d != 200*time.Millisecond {
+		t.Errorf("Expected fourth request to wait 200ms, got %v", d)
+	}
+
+	// After a full second the bucket is full again
+	now = now.Add(time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("Expected request after refill to be free, got %v", d)
+	}
+}
+
+// TestRateLimiter_CancelReturnsToken tests that a waiter whose context is
+// done gives its token back instead of delaying later requests
+func TestRateLimiter_CancelReturnsToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(10, 1)
+	l.now = func() time.Time { return now }
+
+	if d := l.reserve(); d != 0 {
+		t.Errorf("Expected first request to be free, got %v", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	if d := l.reserve(); d != 100*time.Millisecond {
+		t.Errorf("Expected the next request to wait 100ms, got %v", d)
+	}
+}
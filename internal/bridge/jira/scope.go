@@ -0,0 +1,80 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// reJQLOrderBy detects an ORDER BY clause, which cannot be ANDed into the scope
+var reJQLOrderBy = regexp.MustCompile(`(?i)\border\s+by\b`)
+
+// reFilterID matches a numeric saved-filter ID
+var reFilterID = regexp.MustCompile(`^[0-9]+$`)
+
+// ProjectKeys returns the configured project keys: project followed by projects, deduplicated
+func (c JiraConfig) ProjectKeys() []string {
+	keys := make([]string, 0, 1+len(c.Projects))
+	seen := make(map[string]bool)
+	for _, key := range append([]string{c.Project}, c.Projects...) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// validateScope checks the optional JQL and saved-filter settings
+func (c JiraConfig) validateScope() error {
+	if c.FilterID != "" && !reFilterID.MatchString(c.FilterID) {
+		return fmt.Errorf("invalid jira filter_id %q: must be a numeric saved-filter ID", c.FilterID)
+	}
+	if reJQLOrderBy.MatchString(c.JQL) {
+		return fmt.Errorf("invalid jira jql %q: ORDER BY is not allowed (pull orders by updated)", c.JQL)
+	}
+	return nil
+}
+
+// ScopeJQL builds the JQL selecting the issues to pull: the configured
+// projects, narrowed by the saved filter and the extra JQL if set
+func (c JiraConfig) ScopeJQL() string {
+	keys := c.ProjectKeys()
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = quoteJQL(key)
+	}
+
+	clauses := []string{"project = " + quoted[0]}
+	if len(quoted) > 1 {
+		clauses[0] = "project in (" + strings.Join(quoted, ", ") + ")"
+	}
+	if c.FilterID != "" {
+		clauses = append(clauses, "filter = "+c.FilterID)
+	}
+	if jql := strings.TrimSpace(c.JQL); jql != "" {
+		// Parenthesize so a top-level OR cannot escape the project scope
+		clauses = append(clauses, "("+jql+")")
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY updated DESC"
+}
+
+// quoteJQL quotes a value as a JQL string literal
+func quoteJQL(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// inScope reports whether an issue key belongs to one of the configured projects
+func (c JiraConfig) inScope(key string) bool {
+	project, _, ok := strings.Cut(key, "-")
+	if !ok {
+		return false
+	}
+	for _, k := range c.ProjectKeys() {
+		if strings.EqualFold(k, project) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package jira
+
+import "testing"
+
+// TestScopeJQL tests JQL construction and quoting for the configured scope
+func TestScopeJQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config JiraConfig
+		want   string
+	}{
+		{"single project", JiraConfig{Project: "PROJ"}, `project = "PROJ" ORDER BY updated DESC`},
+		{"reserved word", JiraConfig{Project: "IN"}, `project = "IN" ORDER BY updated DESC`},
+		{"quotes escaped", JiraConfig{Project: `P" OR project = "X`}, `project = "P\" OR project = \"X" ORDER BY updated DESC`},
+		{"several projects", JiraConfig{Project: "A", Projects: []string{"B", "A", "C"}}, `project in ("A", "B", "C") ORDER BY updated DESC`},
+		{"projects only", JiraConfig{Projects: []string{"B"}}, `project = "B" ORDER BY updated DESC`},
+		{"filter", JiraConfig{Project: "A", FilterID: "10042"}, `project = "A" AND filter = 10042 ORDER BY updated DESC`},
+		{"jql", JiraConfig{Project: "A", JQL: "sprint in openSprints() OR labels = x"}, `project = "A" AND (sprint in openSprints() OR labels = x) ORDER BY updated DESC`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ScopeJQL(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestConfig_ValidateScope tests rejection of malformed scope settings
+func TestConfig_ValidateScope(t *testing.T) {
+	base := JiraConfig{BaseURL: "u", Project: "P", Email: "e", APIToken: "t"}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *JiraConfig)
+		wantErr bool
+	}{
+		{"plain", func(c *JiraConfig) {}, false},
+		{"projects instead of project", func(c *JiraConfig) { c.Project, c.Projects = "", []string{"A"} }, false},
+		{"no project", func(c *JiraConfig) { c.Project = "" }, true},
+		{"filter id", func(c *JiraConfig) { c.FilterID = "123" }, false},
+		{"filter name", func(c *JiraConfig) { c.FilterID = "My filter" }, true},
+		{"order by", func(c *JiraConfig) { c.JQL = "status = Done order  by created" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			tt.mutate(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestConfig_InScope tests matching issue keys against the configured projects
+func TestConfig_InScope(t *testing.T) {
+	config := JiraConfig{Project: "PROJ", Projects: []string{"OPS"}}
+	for key, want := range map[string]bool{"PROJ-1": true, "OPS-12": true, "proj-3": true, "PROJX-1": false, "OTHER-1": false, "local": false} {
+		if got := config.inScope(key); got != want {
+			t.Errorf("inScope(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
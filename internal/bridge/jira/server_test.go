@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newServerClient returns a REST v2 (Data Center/Server) client for the test server
+func newServerClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := newClientFromConfig(&JiraConfig{BaseURL: server.URL, Auth: AuthPAT, Token: "pat", APIVersion: APIVersionServer})
+	c.httpClient.Transport.(*retryTransport).limiter = nil
+	return c
+}
+
+// TestServer_SearchPaginatesWithStartAt tests that v2 searches page with
+// startAt and convert wiki markup descriptions and comments
+func TestServer_SearchPaginatesWithStartAt(t *testing.T) {
+	const total = 5
+	var starts []int
+
+	c := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" || r.Method != http.MethodPost {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			StartAt int `json:"startAt"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		starts = append(starts, req.StartAt)
+
+		// The server caps pages at 2 issues regardless of maxResults
+		var issues []map[string]interface{}
+		for i := req.StartAt; i < total && i < req.StartAt+2; i++ {
+			issues = append(issues, map[string]interface{}{
+				"id":  fmt.Sprint(100 + i),
+				"key": fmt.Sprintf("DC-%d", i+1),
+				"fields": map[string]interface{}{
+					"summary":     "Issue",
+					"description": "Some *bold* text",
+					"reporter":    map[string]string{"name": "jdoe", "displayName": "Jane Doe"},
+					"created":     "2024-12-13T12:21:09.078+0100",
+					"updated":     "2024-12-13T12:21:09.078+0100",
+					"comment": map[string]interface{}{
+						"total": 1,
+						"comments": []map[string]interface{}{{
+							"id":      "1",
+							"author":  map[string]string{"name": "jdoe", "displayName": "Jane Doe"},
+							"body":    "{{code}} and _italic_",
+							"created": "2024-12-13T12:21:09.078+0100",
+							"updated": "2024-12-13T12:21:09.078+0100",
+						}},
+					},
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"startAt": req.StartAt, "total": total, "issues": issues})
+	})
+
+	found, err := c.SearchIssues(context.Background(), "project = DC", 0, nil)
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+
+	if len(found) != total {
+		t.Fatalf("Expected %d issues, got %d", total, len(found))
+	}
+	if fmt.Sprint(starts) != "[0 2 4]" {
+		t.Errorf("Expected startAt [0 2 4], got %v", starts)
+	}
+	if found[0].Description != "Some **bold** text" {
+		t.Errorf("Expected wiki description converted to Markdown, got %q", found[0].Description)
+	}
+	if found[0].Comments[0].Body != "`code` and *italic*" {
+		t.Errorf("Expected wiki comment converted to Markdown, got %q", found[0].Comments[0].Body)
+	}
+	if found[0].Reporter != "Jane Doe" {
+		t.Errorf("Expected reporter Jane Doe, got %q", found[0].Reporter)
+	}
+}
+
+// TestServer_UpdateSendsWikiMarkup tests that v2 updates and comments carry wiki markup strings
+func TestServer_UpdateSendsWikiMarkup(t *testing.T) {
+	bodies := make(map[string]map[string]interface{})
+
+	c := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.Method+" "+r.URL.Path] = body
+		if r.Header.Get("Authorization") != "Bearer pat" {
+			t.Errorf("Expected bearer auth, got %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{}`))
+	})
+
+	if err := c.UpdateIssue(context.Background(), "DC-1", map[string]interface{}{"description": "## Title\n\n**bold**"}); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := c.AddComment(context.Background(), "DC-1", "see `x`"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	fields, _ := bodies["PUT /rest/api/2/issue/DC-1"]["fields"].(map[string]interface{})
+	if got := fields["description"]; got != "h2. Title\n\n*bold*" {
+		t.Errorf("Expected wiki description, got %#v", got)
+	}
+	if got := bodies["POST /rest/api/2/issue/DC-1/comment"]["body"]; got != "see {{x}}" {
+		t.Errorf("Expected wiki comment, got %#v", got)
+	}
+}
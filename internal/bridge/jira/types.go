@@ -1,217 +1,136 @@
 package jira
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
-	"strings"
-	"time"
 )
 
-// Issue represents a JIRA issue with all its metadata
-type Issue struct {
-	JiraKey  string    `yaml:"jira_key" json:"jira_key"`
-	JiraID   string    `yaml:"jira_id" json:"jira_id"`
-	Title    string    `yaml:"title" json:"title"`
-	Status   string    `yaml:"status" json:"status"`
-	Assignee string    `yaml:"assignee,omitempty" json:"assignee,omitempty"`
-	Reporter string    `yaml:"reporter" json:"reporter"`
-	Created  time.Time `yaml:"created" json:"created"`
-	Updated  time.Time `yaml:"updated" json:"updated"`
-	Labels   []string  `yaml:"labels,omitempty" json:"labels,omitempty"`
-	Hash     string    `yaml:"hash" json:"hash"` // SHA256 of content (excluding hash field)
-
-	Description string       `yaml:"-" json:"description,omitempty"` // Not in frontmatter
-	Comments    []Comment    `yaml:"-" json:"comments,omitempty"`    // Not in frontmatter
-	Attachments []Attachment `yaml:"-" json:"attachments,omitempty"` // Not in frontmatter
-}
-
-// Comment represents a comment on an issue
-type Comment struct {
-	ID      string    `yaml:"-" json:"id,omitempty"`
-	Author  string    `yaml:"-" json:"author"`
-	Body    string    `yaml:"-" json:"body"`
-	Created time.Time `yaml:"-" json:"created"`
-	Updated time.Time `yaml:"-" json:"updated,omitempty"`
-}
-
-// Attachment represents a file attachment on an issue
-type Attachment struct {
-	ID       string    `yaml:"-" json:"id,omitempty"`
-	Filename string    `yaml:"-" json:"filename"`
-	URL      string    `yaml:"-" json:"url"`
-	MimeType string    `yaml:"-" json:"mime_type,omitempty"`
-	Size     int64     `yaml:"-" json:"size,omitempty"`
-	Created  time.Time `yaml:"-" json:"created,omitempty"`
-}
-
 // JiraConfig holds Jira connection configuration
 type JiraConfig struct {
 	BaseURL  string `yaml:"base_url" json:"base_url"`
 	Email    string `yaml:"email" json:"email"`
 	APIToken string `yaml:"api_token" json:"api_token"`
 	Project  string `yaml:"project" json:"project"`
-}
 
-// String returns a sanitized string representation (hides API token)
-func (c JiraConfig) String() string {
-	token := "***REDACTED***"
-	if len(c.APIToken) > 4 {
-		token = c.APIToken[:4] + "***"
+	// Projects are additional project keys to pull alongside Project
+	Projects []string `yaml:"projects,omitempty" json:"projects,omitempty"`
+	// JQL narrows the pull to issues matching this clause (ANDed with the projects)
+	JQL string `yaml:"jql,omitempty" json:"jql,omitempty"`
+	// FilterID narrows the pull to a saved filter
+	FilterID string `yaml:"filter_id,omitempty" json:"filter_id,omitempty"`
+
+	// Auth selects the authentication mode: basic (default), pat or oauth
+	Auth  string       `yaml:"auth,omitempty" json:"auth,omitempty"`
+	Token string       `yaml:"token,omitempty" json:"token,omitempty"` // Personal access token (auth: pat)
+	OAuth *OAuthConfig `yaml:"oauth,omitempty" json:"oauth,omitempty"` // OAuth app credentials (auth: oauth)
+
+	// APIVersion selects the REST API: "3" for Jira Cloud (default), "2" for Data Center/Server
+	APIVersion string `yaml:"api_version,omitempty" json:"api_version,omitempty"`
+
+	// SprintField, StoryPointsField and RankField are the IDs of the custom fields
+	// holding the sprint, story points and board rank (defaults: DefaultSprintField,
+	// DefaultStoryPointsField, DefaultRankField)
+	SprintField      string `yaml:"sprint_field,omitempty" json:"sprint_field,omitempty"`
+	StoryPointsField string `yaml:"story_points_field,omitempty" json:"story_points_field,omitempty"`
+	RankField        string `yaml:"rank_field,omitempty" json:"rank_field,omitempty"`
+
+	// BoardID selects the Agile board used for sprints (default: the first scrum
+	// board of Project)
+	BoardID int `yaml:"board_id,omitempty" json:"board_id,omitempty"`
+
+	// CustomFields maps names in the issue's custom frontmatter to Jira fields,
+	// by ID (customfield_10042) or field name
+	CustomFields map[string]string `yaml:"custom_fields,omitempty" json:"custom_fields,omitempty"`
+
+	// Concurrency is the number of issues pushed or fetched in parallel (default 4)
+	Concurrency int `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+}
+
+// OAuthConfig holds the OAuth 2.0 (3LO) app registered in the Atlassian developer console
+type OAuthConfig struct {
+	ClientID     string `yaml:"client_id" json:"client_id"`
+	ClientSecret string `yaml:"client_secret" json:"client_secret"`
+	// RedirectPort is the loopback port of the callback URL registered for the app
+	// (http://localhost:<port>/callback); defaults to 8765
+	RedirectPort int `yaml:"redirect_port,omitempty" json:"redirect_port,omitempty"`
+}
+
+// AuthMode returns the configured authentication mode, defaulting to basic
+func (c JiraConfig) AuthMode() string {
+	if c.Auth == "" {
+		return AuthBasic
 	}
-	return fmt.Sprintf("JiraConfig{BaseURL: %s, Email: %s, Token: %s, Project: %s}",
-		c.BaseURL, c.Email, token, c.Project)
-}
-
-// Member represents a Jira project member
-type Member struct {
-	AccountID    string `json:"accountId"`
-	DisplayName  string `json:"displayName"`
-	EmailAddress string `json:"emailAddress"`
-	Active       bool   `json:"active"`
-}
-
-// MemberCache holds cached project members
-type MemberCache struct {
-	Members map[string]*Member `json:"members"` // Key is account ID
+	return c.Auth
 }
 
-// NewMemberCache creates an empty member cache
-func NewMemberCache() *MemberCache {
-	return &MemberCache{
-		Members: make(map[string]*Member),
+// Validate checks that the fields required by the authentication mode are set
+func (c JiraConfig) Validate() error {
+	if c.BaseURL == "" || len(c.ProjectKeys()) == 0 {
+		return errors.New("jira config is incomplete: base_url and project are required")
 	}
-}
-
-// Add adds a member to the cache
-func (mc *MemberCache) Add(member *Member) {
-	if mc.Members == nil {
-		mc.Members = make(map[string]*Member)
+	if err := c.validateScope(); err != nil {
+		return err
 	}
-	mc.Members[member.AccountID] = member
-}
 
-// FindByAccountID looks up a member by account ID
-func (mc *MemberCache) FindByAccountID(accountID string) *Member {
-	return mc.Members[accountID]
-}
-
-// FindByEmail looks up a member by email address
-func (mc *MemberCache) FindByEmail(email string) *Member {
-	for _, member := range mc.Members {
-		if member.EmailAddress == email {
-			return member
+	switch c.AuthMode() {
+	case AuthBasic:
+		if c.Email == "" || c.APIToken == "" {
+			return errors.New("jira config is incomplete: email and api_token are required for basic auth")
 		}
-	}
-	return nil
-}
-
-// FindByDisplayName looks up a member by display name
-func (mc *MemberCache) FindByDisplayName(displayName string) *Member {
-	for _, member := range mc.Members {
-		if member.DisplayName == displayName {
-			return member
+	case AuthPAT:
+		if c.Token == "" {
+			return errors.New("jira config is incomplete: token is required for pat auth")
 		}
-	}
-	return nil
-}
-
-// FormatMember returns the canonical format: "Display Name <email>"
-func (m *Member) FormatMember() string {
-	if m.EmailAddress != "" {
-		return fmt.Sprintf("%s <%s>", m.DisplayName, m.EmailAddress)
-	}
-	return m.DisplayName
-}
-
-// Regular expression to parse "Display Name <email>" format
-var userFormatRegex = regexp.MustCompile(`^(.+?)\s*<([^>]+)>$`)
-
-// ParseUser parses a user string in various formats and resolves to a Member
-// Supports:
-// - "Display Name <email>" (canonical format)
-// - "email@example.com" (email only)
-// - "Display Name" (display name only)
-//
-// Returns the resolved Member and the original string for error messages
-func (mc *MemberCache) ParseUser(userStr string) (*Member, error) {
-	userStr = strings.TrimSpace(userStr)
-	if userStr == "" {
-		return nil, fmt.Errorf("empty user string")
-	}
-
-	// Try parsing "Display Name <email>" format
-	if matches := userFormatRegex.FindStringSubmatch(userStr); matches != nil {
-		displayName := strings.TrimSpace(matches[1])
-		email := strings.TrimSpace(matches[2])
-
-		// Prefer email lookup (more stable)
-		if member := mc.FindByEmail(email); member != nil {
-			return member, nil
+	case AuthOAuth:
+		if c.OAuth == nil || c.OAuth.ClientID == "" || c.OAuth.ClientSecret == "" {
+			return errors.New("jira config is incomplete: oauth.client_id and oauth.client_secret are required for oauth auth")
 		}
-
-		// Fallback to display name
-		if member := mc.FindByDisplayName(displayName); member != nil {
-			return member, nil
-		}
-
-		return nil, fmt.Errorf("user not found: %q", userStr)
+	default:
+		return fmt.Errorf("invalid jira auth %q: must be %s, %s or %s", c.Auth, AuthBasic, AuthPAT, AuthOAuth)
 	}
 
-	// Check if it looks like an email (contains @)
-	if strings.Contains(userStr, "@") {
-		if member := mc.FindByEmail(userStr); member != nil {
-			return member, nil
+	switch c.RESTVersion() {
+	case APIVersionCloud:
+	case APIVersionServer:
+		if c.AuthMode() == AuthOAuth {
+			return errors.New("jira oauth auth is only supported on Jira Cloud (api_version 3)")
 		}
-		return nil, fmt.Errorf("user with email %q not found", userStr)
-	}
-
-	// Try as display name
-	if member := mc.FindByDisplayName(userStr); member != nil {
-		return member, nil
+	default:
+		return fmt.Errorf("invalid jira api_version %q: must be %s (Cloud) or %s (Data Center/Server)", c.APIVersion, APIVersionCloud, APIVersionServer)
 	}
-
-	return nil, fmt.Errorf("user %q not found", userStr)
-}
-
-// StatusInfo represents a Jira status with its category
-type StatusInfo struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Category string `json:"category"` // "new", "indeterminate", "done", or "undefined"
-}
-
-// WorkflowCache holds cached project statuses
-type WorkflowCache struct {
-	Statuses map[string]*StatusInfo `json:"statuses"` // Key is status ID
+	return nil
 }
 
-// NewWorkflowCache creates an empty workflow cache
-func NewWorkflowCache() *WorkflowCache {
-	return &WorkflowCache{
-		Statuses: make(map[string]*StatusInfo),
+// RESTVersion returns the configured REST API version, defaulting to Cloud
+func (c JiraConfig) RESTVersion() string {
+	if c.APIVersion == "" {
+		return APIVersionCloud
 	}
-}
-
-// AddStatus adds a status to the cache
-func (wc *WorkflowCache) AddStatus(status *StatusInfo) {
-	if wc.Statuses == nil {
-		wc.Statuses = make(map[string]*StatusInfo)
+	return c.APIVersion
+}
+
+// Workers returns the configured concurrency, defaulted and capped
+func (c JiraConfig) Workers() int {
+	switch {
+	case c.Concurrency <= 0:
+		return DefaultConcurrency
+	case c.Concurrency > MaxConcurrency:
+		return MaxConcurrency
+	default:
+		return c.Concurrency
 	}
-	wc.Statuses[status.ID] = status
 }
 
-// FindByID looks up a status by ID
-func (wc *WorkflowCache) FindByID(id string) *StatusInfo {
-	return wc.Statuses[id]
-}
-
-// GetByCategory returns all statuses in a given category
-func (wc *WorkflowCache) GetByCategory(category string) []*StatusInfo {
-	var statuses []*StatusInfo
-	for _, status := range wc.Statuses {
-		if status.Category == category {
-			statuses = append(statuses, status)
-		}
+// String returns a sanitized string representation (hides API token and secrets)
+func (c JiraConfig) String() string {
+	secret := c.APIToken
+	if c.AuthMode() == AuthPAT {
+		secret = c.Token
+	}
+	token := "***REDACTED***"
+	if len(secret) > 4 {
+		token = secret[:4] + "***"
 	}
-	return statuses
+	return fmt.Sprintf("JiraConfig{BaseURL: %s, Auth: %s, Email: %s, Token: %s, Project: %s}",
+		c.BaseURL, c.AuthMode(), c.Email, token, c.Project)
 }
@@ -0,0 +1,437 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Jira Data Center and Server (REST API v2) store rich text as wiki markup
+// instead of ADF. The converters below cover the subset of wiki markup that
+// maps onto the Markdown produced and accepted by ADFToMarkdown/MarkdownToADF:
+// headings, emphasis, code, quotes, lists, links, images, rules and tables.
+
+// Precompiled regular expressions for wiki markup (Wiki <-> Markdown)
+var (
+	// reWikiHeading matches wiki headings (h1. through h6.)
+	// Group 1: level, Group 2: heading text
+	reWikiHeading = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+
+	// reWikiListItem matches wiki list items (*, #, nested ** / #*, or a single -)
+	// Group 1: markers, Group 2: item text
+	reWikiListItem = regexp.MustCompile(`^\s*([*#]+|-)\s+(.*)$`)
+
+	// reWikiCodeOpen matches {code}, {code:lang}, {code:title=x|language=lang} and {noformat}
+	// Group 1: macro name, Group 2: parameters
+	reWikiCodeOpen = regexp.MustCompile(`^\s*\{(code|noformat)(?::([^}]*))?\}`)
+
+	// reWikiRule matches a horizontal rule (four dashes)
+	reWikiRule = regexp.MustCompile(`^\s*-{4,}\s*$`)
+
+	// reWikiMono matches monospaced text {{text}}
+	reWikiMono = regexp.MustCompile(`\{\{(.+?)\}\}`)
+
+	// reWikiLink matches [url], [text|url] and [~user] mentions
+	// Group 1: text or target, Group 2: url (optional)
+	reWikiLink = regexp.MustCompile(`\[([^\]|]+)(?:\|([^\]]+))?\]`)
+
+	// reWikiImage matches embedded images !file.png! and !file.png|thumbnail!
+	reWikiImage = regexp.MustCompile(`!([^\s!|]+)(?:\|[^!]*)?!`)
+
+	// reWikiSup and reWikiSub match ^superscript^ and ~subscript~
+	reWikiSup = regexp.MustCompile(`\^([^\s^]+)\^`)
+	reWikiSub = regexp.MustCompile(`~([^\s~]+)~`)
+
+	// reMarkdownFence matches a fenced code block delimiter
+	// Group 1: language
+	reMarkdownFence = regexp.MustCompile("^\\s*```\\s*(\\S*)\\s*$")
+
+	// reMarkdownListItem matches ordered and unordered Markdown list items
+	// Group 1: indentation, Group 2: marker, Group 3: item text
+	reMarkdownListItem = regexp.MustCompile(`^(\s*)([-*+]|\d+\.)\s+(.*)$`)
+)
+
+// WikiToMarkdown converts Jira wiki markup to Markdown
+func WikiToMarkdown(wiki string) string {
+	lines := strings.Split(strings.ReplaceAll(wiki, "\r\n", "\n"), "\n")
+	var out []string
+	// counters holds the next number of each ordered list depth
+	var counters []int
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := reWikiCodeOpen.FindStringSubmatch(line); m != nil {
+			block, next := wikiCodeBlock(lines, i, m)
+			out = append(out, block...)
+			i = next
+			counters = nil
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "{quote}" {
+			var quoted []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "{quote}"; i++ {
+				quoted = append(quoted, "> "+wikiInlineToMarkdown(lines[i]))
+			}
+			out = append(out, quoted...)
+			counters = nil
+			continue
+		}
+
+		if m := reWikiListItem.FindStringSubmatch(line); m != nil && !reWikiRule.MatchString(line) {
+			markers := m[1]
+			depth := len(markers)
+			if markers == "-" {
+				markers = "*"
+			}
+			for len(counters) < depth {
+				counters = append(counters, 1)
+			}
+			counters = counters[:depth]
+
+			prefix := "- "
+			if markers[depth-1] == '#' {
+				prefix = fmt.Sprintf("%d. ", counters[depth-1])
+				counters[depth-1]++
+			}
+			out = append(out, strings.Repeat("  ", depth-1)+prefix+wikiInlineToMarkdown(m[2]))
+			continue
+		}
+		counters = nil
+
+		switch {
+		case reWikiRule.MatchString(line):
+			out = append(out, "---")
+		case reWikiHeading.MatchString(trimmed):
+			m := reWikiHeading.FindStringSubmatch(trimmed)
+			out = append(out, strings.Repeat("#", int(m[1][0]-'0'))+" "+wikiInlineToMarkdown(m[2]))
+		case strings.HasPrefix(trimmed, "bq. "):
+			out = append(out, "> "+wikiInlineToMarkdown(strings.TrimPrefix(trimmed, "bq. ")))
+		case strings.HasPrefix(trimmed, "|"):
+			rows, next := wikiTable(lines, i)
+			out = append(out, rows...)
+			i = next
+		default:
+			out = append(out, wikiInlineToMarkdown(line))
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// wikiCodeBlock converts a {code} or {noformat} block starting at lines[start]
+// to a fenced code block. Returns the Markdown lines and the index of the closing line.
+func wikiCodeBlock(lines []string, start int, open []string) ([]string, int) {
+	macro := "{" + open[1] + "}"
+	language := ""
+	if open[1] == "code" {
+		for _, param := range strings.Split(open[2], "|") {
+			if k, v, ok := strings.Cut(param, "="); ok {
+				if k == "language" {
+					language = v
+				}
+			} else if param != "" {
+				language = param
+			}
+		}
+	}
+
+	block := []string{"```" + language}
+	rest := strings.TrimPrefix(strings.TrimSpace(lines[start]), open[0][strings.Index(open[0], "{"):])
+	i := start
+	for {
+		if before, _, found := strings.Cut(rest, macro); found {
+			if before != "" {
+				block = append(block, before)
+			}
+			break
+		}
+		if i > start || rest != "" {
+			block = append(block, rest)
+		}
+		i++
+		if i >= len(lines) {
+			break
+		}
+		rest = lines[i]
+	}
+	return append(block, "```"), i
+}
+
+// wikiTable converts consecutive wiki table rows starting at lines[start].
+// Markdown requires a header, so the first row always becomes one.
+// Returns the Markdown lines and the index of the last row.
+func wikiTable(lines []string, start int) ([]string, int) {
+	var out []string
+	i := start
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		row := strings.TrimSpace(lines[i])
+		row = strings.ReplaceAll(row, "||", "|")
+		row = strings.Trim(row, "|")
+
+		cells := strings.Split(row, "|")
+		for j, cell := range cells {
+			cells[j] = wikiInlineToMarkdown(strings.TrimSpace(cell))
+		}
+		out = append(out, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == start {
+			sep := make([]string, len(cells))
+			for j := range sep {
+				sep[j] = "---"
+			}
+			out = append(out, "| "+strings.Join(sep, " | ")+" |")
+		}
+	}
+	return out, i - 1
+}
+
+// wikiInlineToMarkdown converts inline wiki formatting to Markdown
+func wikiInlineToMarkdown(text string) string {
+	var saved []string
+	text = protect(text, reWikiMono, &saved, func(m []string) string {
+		return "`" + m[1] + "`"
+	})
+	text = protect(text, reWikiImage, &saved, func(m []string) string {
+		return "![](" + m[1] + ")"
+	})
+	text = protect(text, reWikiLink, &saved, func(m []string) string {
+		switch {
+		case m[2] != "":
+			return "[" + m[1] + "](" + m[2] + ")"
+		case strings.HasPrefix(m[1], "~"):
+			return "@" + strings.TrimPrefix(m[1], "~")
+		default:
+			return "[" + m[1] + "](" + m[1] + ")"
+		}
+	})
+
+	// Bold goes through a placeholder so the italic pass does not re-read it
+	text = replaceDelimited(text, "*", "\x01", "\x01")
+	text = replaceDelimited(text, "_", "*", "*")
+	// Superscript and subscript are usually glued to a word (x^2^, H~2~O);
+	// convert them before strikethrough introduces "~~"
+	text = reWikiSup.ReplaceAllString(text, "<sup>$1</sup>")
+	text = reWikiSub.ReplaceAllString(text, "<sub>$1</sub>")
+	text = replaceDelimited(text, "-", "~~", "~~")
+	text = replaceDelimited(text, "+", "<u>", "</u>")
+	text = strings.ReplaceAll(text, "\x01", "**")
+
+	return restore(text, saved)
+}
+
+// MarkdownToWiki converts Markdown to Jira wiki markup
+func MarkdownToWiki(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	var out []string
+	// indents tracks the indentation of each open list level, markers their wiki marker
+	var indents []int
+	var markers []byte
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := reMarkdownFence.FindStringSubmatch(line); m != nil {
+			open := "{code}"
+			if m[1] != "" {
+				open = "{code:" + m[1] + "}"
+			}
+			out = append(out, open)
+			for i++; i < len(lines) && !reMarkdownFence.MatchString(lines[i]); i++ {
+				out = append(out, lines[i])
+			}
+			out = append(out, "{code}")
+			indents, markers = nil, nil
+			continue
+		}
+
+		if m := reMarkdownListItem.FindStringSubmatch(line); m != nil && !reHorizontalRule.MatchString(strings.TrimSpace(line)) {
+			indent := len(m[1])
+			for len(indents) > 0 && indents[len(indents)-1] > indent {
+				indents, markers = indents[:len(indents)-1], markers[:len(markers)-1]
+			}
+			marker := byte('*')
+			if strings.HasSuffix(m[2], ".") {
+				marker = '#'
+			}
+			if len(indents) == 0 || indents[len(indents)-1] < indent {
+				indents, markers = append(indents, indent), append(markers, marker)
+			} else {
+				markers[len(markers)-1] = marker
+			}
+			out = append(out, string(markers)+" "+markdownInlineToWiki(m[3]))
+			continue
+		}
+		indents, markers = nil, nil
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case reHorizontalRule.MatchString(trimmed):
+			out = append(out, "----")
+		case reHeading.MatchString(trimmed):
+			m := reHeading.FindStringSubmatch(trimmed)
+			out = append(out, fmt.Sprintf("h%d. %s", len(m[1]), markdownInlineToWiki(m[2])))
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, markdownInlineToWiki(strings.TrimPrefix(text, " ")))
+			}
+			i--
+			if len(quoted) == 1 {
+				out = append(out, "bq. "+quoted[0])
+			} else {
+				out = append(out, "{quote}")
+				out = append(out, quoted...)
+				out = append(out, "{quote}")
+			}
+		case strings.HasPrefix(trimmed, "|"):
+			rows, next := markdownTable(lines, i)
+			out = append(out, rows...)
+			i = next
+		default:
+			out = append(out, markdownInlineToWiki(line))
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// markdownTable converts consecutive Markdown table rows starting at lines[start],
+// turning the row above the separator into a wiki header row.
+// Returns the wiki lines and the index of the last row.
+func markdownTable(lines []string, start int) ([]string, int) {
+	var out []string
+	i := start
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		cells := strings.Split(strings.Trim(strings.TrimSpace(lines[i]), "|"), "|")
+		separator := true
+		for j, cell := range cells {
+			cells[j] = strings.TrimSpace(cell)
+			if !reTableSeparator.MatchString(cells[j]) {
+				separator = false
+			}
+		}
+		if separator {
+			continue
+		}
+		for j, cell := range cells {
+			cells[j] = markdownInlineToWiki(cell)
+		}
+
+		if i == start {
+			out = append(out, "||"+strings.Join(cells, "||")+"||")
+		} else {
+			out = append(out, "|"+strings.Join(cells, "|")+"|")
+		}
+	}
+	return out, i - 1
+}
+
+// markdownInlineToWiki converts inline Markdown formatting to wiki markup
+func markdownInlineToWiki(text string) string {
+	var saved []string
+	text = protect(text, reInlineCode, &saved, func(m []string) string {
+		return "{{" + m[1] + "}}"
+	})
+	text = protect(text, reImage, &saved, func(m []string) string {
+		return "!" + m[2] + "!"
+	})
+	text = protect(text, reLink, &saved, func(m []string) string {
+		if m[1] == m[2] {
+			return "[" + m[2] + "]"
+		}
+		return "[" + m[1] + "|" + m[2] + "]"
+	})
+
+	text = reUnderline.ReplaceAllString(text, "+$1+")
+	text = reSuperscript.ReplaceAllString(text, "^$1^")
+	text = reSubscript.ReplaceAllString(text, "~$1~")
+
+	// Bold goes through a placeholder so the italic pass does not re-read it
+	text = replaceDelimited(text, "**", "\x01", "\x01")
+	text = replaceDelimited(text, "__", "\x01", "\x01")
+	text = replaceDelimited(text, "*", "_", "_")
+	text = replaceDelimited(text, "~~", "-", "-")
+	text = strings.ReplaceAll(text, "\x01", "*")
+
+	return restore(text, saved)
+}
+
+// protect replaces every match of re with a placeholder holding the converted
+// text, so that later emphasis passes leave code, links and URLs untouched
+func protect(text string, re *regexp.Regexp, saved *[]string, convert func(m []string) string) string {
+	return re.ReplaceAllStringFunc(text, func(match string) string {
+		*saved = append(*saved, convert(re.FindStringSubmatch(match)))
+		return fmt.Sprintf("\x00%d\x00", len(*saved)-1)
+	})
+}
+
+// restore puts protected text back in place of its placeholders
+func restore(text string, saved []string) string {
+	for i := len(saved) - 1; i >= 0; i-- {
+		text = strings.ReplaceAll(text, fmt.Sprintf("\x00%d\x00", i), saved[i])
+	}
+	return text
+}
+
+// replaceDelimited rewrites delim-wrapped spans to open/close. Like wiki and
+// Markdown emphasis, a span must not start or end with whitespace and must not
+// be glued to a word on the outside, so "snake_case" and "a - b" stay as they are.
+func replaceDelimited(text, delim, open, close string) string {
+	var b strings.Builder
+	for {
+		start := findDelimiter(text, delim, 0, true)
+		if start < 0 {
+			break
+		}
+		end := findDelimiter(text, delim, start+len(delim)+1, false)
+		if end < 0 {
+			break
+		}
+		b.WriteString(text[:start])
+		b.WriteString(open)
+		b.WriteString(text[start+len(delim) : end])
+		b.WriteString(close)
+		text = text[end+len(delim):]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// findDelimiter returns the index of the first opening (or closing) delimiter
+// at or after from, or -1 if there is none
+func findDelimiter(text, delim string, from int, opening bool) int {
+	for i := from; i <= len(text)-len(delim); i++ {
+		if !strings.HasPrefix(text[i:], delim) {
+			continue
+		}
+		var before, after byte = ' ', ' '
+		if i > 0 {
+			before = text[i-1]
+		}
+		if i+len(delim) < len(text) {
+			after = text[i+len(delim)]
+		}
+		inner, outer := after, before
+		if !opening {
+			inner, outer = before, after
+		}
+		if !isSpace(inner) && !isWordByte(outer) && inner != delim[0] && outer != delim[0] {
+			return i
+		}
+	}
+	return -1
+}
+
+// isSpace reports whether b is ASCII whitespace
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n'
+}
+
+// isWordByte reports whether b is part of a word (letters, digits, non-ASCII)
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b >= 0x80
+}
@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWikiToMarkdown tests conversion of wiki markup constructs
+func TestWikiToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		wiki string
+		want string
+	}{
+		{"plain", "Hello, world!", "Hello, world!"},
+		{"bold and italic", "This is *bold* and _italic_", "This is **bold** and *italic*"},
+		{"strike underline", "-gone- and +under+", "~~gone~~ and <u>under</u>"},
+		{"sub sup", "H~2~O and x^2^", "H<sub>2</sub>O and x<sup>2</sup>"},
+		{"monospace", "Run {{go test ./...}} now", "Run `go test ./...` now"},
+		{"monospace keeps markup", "{{*not bold*}}", "`*not bold*`"},
+		{"link", "See [the docs|https://example.com/a_b-c_d]", "See [the docs](https://example.com/a_b-c_d)"},
+		{"bare link", "[https://example.com]", "[https://example.com](https://example.com)"},
+		{"mention", "Ping [~jdoe]", "Ping @jdoe"},
+		{"image", "!screenshot.png|thumbnail!", "![](screenshot.png)"},
+		{"words untouched", "snake_case and well-known a - b 2*3*4", "snake_case and well-known a - b 2*3*4"},
+		{"heading", "h2. Overview", "## Overview"},
+		{"quote line", "bq. Quoted", "> Quoted"},
+		{"quote block", "{quote}\nfirst\nsecond\n{quote}", "> first\n> second"},
+		{"rule", "----", "---"},
+		{"bullets", "* one\n** nested\n* two", "- one\n  - nested\n- two"},
+		{"numbered", "# one\n# two\n## sub\n# three", "1. one\n2. two\n  1. sub\n3. three"},
+		{"mixed", "# step\n#* detail", "1. step\n  - detail"},
+		{"code", "{code:go}\nfunc main() {\n\t_ = *p*\n}\n{code}", "```go\nfunc main() {\n\t_ = *p*\n}\n```"},
+		{"code params", "{code:title=Main.java|language=java}int x;{code}", "```java\nint x;\n```"},
+		{"noformat", "{noformat}\n*raw*\n{noformat}", "```\n*raw*\n```"},
+		{"table", "||Name||Value||\n|a|*b*|", "| Name | Value |\n| --- | --- |\n| a | **b** |"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WikiToMarkdown(tt.wiki); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestMarkdownToWiki tests conversion of Markdown constructs to wiki markup
+func TestMarkdownToWiki(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"plain", "Hello, world!", "Hello, world!"},
+		{"bold and italic", "**bold**, __bold__, *italic* and _italic_", "*bold*, *bold*, _italic_ and _italic_"},
+		{"strike", "~~gone~~", "-gone-"},
+		{"html marks", "<u>u</u> H<sub>2</sub>O x<sup>2</sup>", "+u+ H~2~O x^2^"},
+		{"inline code", "Run `a*b*c` now", "Run {{a*b*c}} now"},
+		{"link", "[docs](https://example.com/x_y_z)", "[docs|https://example.com/x_y_z]"},
+		{"image", "![shot](https://example.com/s.png)", "!https://example.com/s.png!"},
+		{"heading", "### Details", "h3. Details"},
+		{"quote line", "> Quoted", "bq. Quoted"},
+		{"quote block", "> first\n> second", "{quote}\nfirst\nsecond\n{quote}"},
+		{"rule", "---", "----"},
+		{"nested list", "- one\n  - nested\n    1. deep\n- two", "* one\n** nested\n**# deep\n* two"},
+		{"ordered list", "1. a\n2. b", "# a\n# b"},
+		{"code", "```python\nx = a * b * c\n```", "{code:python}\nx = a * b * c\n{code}"},
+		{"table", "| Name | Value |\n| --- | --- |\n| a | **b** |", "||Name||Value||\n|a|*b*|"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToWiki(tt.markdown); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestRoundTrip_Wiki tests Markdown → wiki → Markdown for the supported subset
+func TestRoundTrip_Wiki(t *testing.T) {
+	markdown := strings.Join([]string{
+		"## Summary",
+		"",
+		"Some **bold**, *italic* and ~~struck~~ text with `code` and a [link](https://example.com).",
+		"",
+		"- Parent",
+		"  - Child",
+		"- Other",
+		"",
+		"1. First",
+		"2. Second",
+		"",
+		"> Quoted",
+		"",
+		"```go",
+		"fmt.Println(\"*hi*\")",
+		"```",
+		"",
+		"| A | B |",
+		"| --- | --- |",
+		"| 1 | 2 |",
+	}, "\n")
+
+	got := WikiToMarkdown(MarkdownToWiki(markdown))
+	if got != markdown {
+		t.Errorf("Round-trip failed:\nExpected:\n%s\n\nGot:\n%s", markdown, got)
+	}
+}
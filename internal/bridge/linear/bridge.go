@@ -0,0 +1,69 @@
+package linear
+
+import (
+	"context"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Bridge implements issue.Bridge for a Linear team
+type Bridge struct {
+	client *Client
+	config *LinearConfig
+}
+
+// NewBridge creates a Linear bridge from the given configuration
+func NewBridge(config *LinearConfig) *Bridge {
+	return &Bridge{
+		client: NewClient(config.Endpoint(), config.APIKey),
+		config: config,
+	}
+}
+
+// Pull fetches issues from Linear and saves them to storage
+func (b *Bridge) Pull(ctx context.Context, storage *issue.Storage) (*issue.PullResult, error) {
+	return Pull(ctx, b.client, storage, b.config)
+}
+
+// Push pushes local changes to Linear
+func (b *Bridge) Push(ctx context.Context, storage *issue.Storage, key string) (*issue.PushResult, error) {
+	return Push(ctx, b.client, storage, b.config, key)
+}
+
+// Members returns the team's members, keyed by Linear user ID
+func (b *Bridge) Members(ctx context.Context, projectPath string) ([]*issue.Member, error) {
+	return teamMembers(ctx, b.client, b.config.Team)
+}
+
+// teamMembers fetches the team's members, keyed by Linear user ID
+func teamMembers(ctx context.Context, client *Client, team string) ([]*issue.Member, error) {
+	users, err := client.ListMembers(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*issue.Member, 0, len(users))
+	for _, u := range users {
+		members = append(members, &issue.Member{
+			AccountID:    u.ID,
+			DisplayName:  u.Name,
+			EmailAddress: u.Email,
+			Active:       u.Active,
+		})
+	}
+	return members, nil
+}
+
+// Workflow refreshes the workflow cache and returns the team's states
+func (b *Bridge) Workflow(ctx context.Context, projectPath string) ([]*issue.StatusInfo, error) {
+	cache, err := RefreshWorkflowCache(ctx, b.client, projectPath, b.config.Team)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*issue.StatusInfo, 0, len(cache.Statuses))
+	for _, status := range cache.Statuses {
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,351 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// issueFields is the selection shared by every query that returns issues
+const issueFields = `
+fragment IssueFields on Issue {
+  id identifier title description createdAt updatedAt
+  state { id name type }
+  creator { id name email }
+  assignee { id name email }
+  labels { nodes { id name } }
+  comments(first: $commentsFirst) {
+    nodes { id body createdAt updatedAt user { id name email } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const issuesQuery = `
+query Issues($teamKey: String!, $first: Int!, $after: String, $commentsFirst: Int!) {
+  issues(first: $first, after: $after, orderBy: updatedAt, filter: { team: { key: { eq: $teamKey } } }) {
+    nodes { ...IssueFields }
+    pageInfo { hasNextPage endCursor }
+  }
+}` + issueFields
+
+const issueQuery = `
+query Issue($id: String!, $commentsFirst: Int!) {
+  issue(id: $id) { ...IssueFields }
+}` + issueFields
+
+const commentsQuery = `
+query Comments($id: String!, $first: Int!, $after: String) {
+  issue(id: $id) {
+    comments(first: $first, after: $after) {
+      nodes { id body createdAt updatedAt user { id name email } }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+const statesQuery = `
+query States($teamKey: String!) {
+  workflowStates(first: 250, filter: { team: { key: { eq: $teamKey } } }) {
+    nodes { id name type }
+  }
+}`
+
+const membersQuery = `
+query Members($teamKey: String!) {
+  teams(filter: { key: { eq: $teamKey } }) {
+    nodes { members(first: 250) { nodes { id name email active } } }
+  }
+}`
+
+const labelsQuery = `
+query Labels($teamKey: String!, $after: String) {
+  issueLabels(first: 250, after: $after, filter: { or: [{ team: { key: { eq: $teamKey } } }, { team: { null: true } }] }) {
+    nodes { id name }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const issueUpdateMutation = `
+mutation IssueUpdate($id: String!, $input: IssueUpdateInput!) {
+  issueUpdate(id: $id, input: $input) { success }
+}`
+
+const commentCreateMutation = `
+mutation CommentCreate($input: CommentCreateInput!) {
+  commentCreate(input: $input) { success }
+}`
+
+// Client is a lightweight Linear GraphQL API client
+type Client struct {
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+}
+
+// NewClient creates a new Linear API client
+func NewClient(endpoint, apiKey string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		endpoint:   endpoint,
+		apiKey:     apiKey,
+	}
+}
+
+// authorization returns the Authorization header value.
+// Personal API keys are sent as-is; OAuth access tokens use the Bearer scheme.
+func (c *Client) authorization() string {
+	if strings.HasPrefix(c.apiKey, "lin_api_") {
+		return c.apiKey
+	}
+	return "Bearer " + c.apiKey
+}
+
+// do executes a GraphQL operation and decodes its data into out.
+// GraphQL-level errors are returned even when the HTTP status is 200.
+func (c *Client) do(ctx context.Context, operation, query string, variables map[string]interface{}, out interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"operationName": operation,
+		"query":         query,
+		"variables":     variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.authorization())
+	req.Header.Set("Content-Type", "application/json")
+
+	log.Printf("[DEBUG] Linear: Executing %s", operation)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodySize))
+		return fmt.Errorf("linear API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxResponseSize)).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", operation, err)
+	}
+
+	if len(envelope.Errors) > 0 {
+		messages := make([]string, 0, len(envelope.Errors))
+		for _, e := range envelope.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("linear API error: %s", strings.Join(messages, "; "))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return fmt.Errorf("failed to decode %s data: %w", operation, err)
+		}
+	}
+	return nil
+}
+
+// ListIssues fetches the team's issues, most recently updated first.
+// Each issue carries its first page of comments; use ListComments for the rest.
+func (c *Client) ListIssues(ctx context.Context, teamKey string, maxResults int) ([]lnIssue, error) {
+	var all []lnIssue
+	var after interface{} // nil encodes as null for the first page
+	pageNum := 1
+
+	for {
+		log.Printf("[DEBUG] ListIssues: Fetching page %d", pageNum)
+
+		var data struct {
+			Issues struct {
+				Nodes    []lnIssue `json:"nodes"`
+				PageInfo pageInfo  `json:"pageInfo"`
+			} `json:"issues"`
+		}
+		err := c.do(ctx, "Issues", issuesQuery, map[string]interface{}{
+			"teamKey":       teamKey,
+			"first":         PageSize,
+			"after":         after,
+			"commentsFirst": CommentPageSize,
+		}, &data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		all = append(all, data.Issues.Nodes...)
+
+		if maxResults > 0 && len(all) >= maxResults {
+			all = all[:maxResults]
+			break
+		}
+		if !data.Issues.PageInfo.HasNextPage {
+			break
+		}
+		after = data.Issues.PageInfo.EndCursor
+		pageNum++
+	}
+
+	log.Printf("[DEBUG] ListIssues: Complete - fetched %d issues", len(all))
+	return all, nil
+}
+
+// GetIssue fetches a single issue by identifier (e.g. ENG-123) or ID
+func (c *Client) GetIssue(ctx context.Context, id string) (*lnIssue, error) {
+	var data struct {
+		Issue *lnIssue `json:"issue"`
+	}
+	err := c.do(ctx, "Issue", issueQuery, map[string]interface{}{
+		"id":            id,
+		"commentsFirst": CommentPageSize,
+	}, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue: %w", err)
+	}
+	if data.Issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	return data.Issue, nil
+}
+
+// ListComments fetches the comments of an issue that follow the after cursor
+func (c *Client) ListComments(ctx context.Context, id, after string) ([]lnComment, error) {
+	var all []lnComment
+	for {
+		var data struct {
+			Issue struct {
+				Comments lnCommentConnection `json:"comments"`
+			} `json:"issue"`
+		}
+		err := c.do(ctx, "Comments", commentsQuery, map[string]interface{}{
+			"id":    id,
+			"first": CommentPageSize,
+			"after": after,
+		}, &data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+
+		all = append(all, data.Issue.Comments.Nodes...)
+		if !data.Issue.Comments.PageInfo.HasNextPage {
+			break
+		}
+		after = data.Issue.Comments.PageInfo.EndCursor
+	}
+	return all, nil
+}
+
+// ListStates fetches all workflow states of a team
+func (c *Client) ListStates(ctx context.Context, teamKey string) ([]lnState, error) {
+	var data struct {
+		WorkflowStates struct {
+			Nodes []lnState `json:"nodes"`
+		} `json:"workflowStates"`
+	}
+	if err := c.do(ctx, "States", statesQuery, map[string]interface{}{"teamKey": teamKey}, &data); err != nil {
+		return nil, fmt.Errorf("failed to list workflow states: %w", err)
+	}
+	return data.WorkflowStates.Nodes, nil
+}
+
+// ListMembers fetches all members of a team
+func (c *Client) ListMembers(ctx context.Context, teamKey string) ([]lnUser, error) {
+	var data struct {
+		Teams struct {
+			Nodes []struct {
+				Members struct {
+					Nodes []lnUser `json:"nodes"`
+				} `json:"members"`
+			} `json:"nodes"`
+		} `json:"teams"`
+	}
+	if err := c.do(ctx, "Members", membersQuery, map[string]interface{}{"teamKey": teamKey}, &data); err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	if len(data.Teams.Nodes) == 0 {
+		return nil, fmt.Errorf("team %q not found", teamKey)
+	}
+	return data.Teams.Nodes[0].Members.Nodes, nil
+}
+
+// ListLabels fetches the labels a team's issues can have: the team's own and
+// the workspace's
+func (c *Client) ListLabels(ctx context.Context, teamKey string) ([]lnLabel, error) {
+	var all []lnLabel
+	var after interface{} // nil encodes as null for the first page
+	for {
+		var data struct {
+			IssueLabels struct {
+				Nodes    []lnLabel `json:"nodes"`
+				PageInfo pageInfo  `json:"pageInfo"`
+			} `json:"issueLabels"`
+		}
+		if err := c.do(ctx, "Labels", labelsQuery, map[string]interface{}{"teamKey": teamKey, "after": after}, &data); err != nil {
+			return nil, fmt.Errorf("failed to list labels: %w", err)
+		}
+		all = append(all, data.IssueLabels.Nodes...)
+		if !data.IssueLabels.PageInfo.HasNextPage {
+			break
+		}
+		after = data.IssueLabels.PageInfo.EndCursor
+	}
+	return all, nil
+}
+
+// UpdateIssue updates an issue's fields (title, description, stateId, assigneeId, labelIds)
+func (c *Client) UpdateIssue(ctx context.Context, id string, input map[string]interface{}) error {
+	log.Printf("[DEBUG] UpdateIssue: Updating issue %s", id)
+
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+	err := c.do(ctx, "IssueUpdate", issueUpdateMutation, map[string]interface{}{
+		"id":    id,
+		"input": input,
+	}, &data)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+	if !data.IssueUpdate.Success {
+		return fmt.Errorf("failed to update issue: linear reported no success")
+	}
+	return nil
+}
+
+// AddComment adds a comment to an issue
+func (c *Client) AddComment(ctx context.Context, issueID, body string) error {
+	log.Printf("[DEBUG] AddComment: Adding comment to issue %s", issueID)
+
+	var data struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+	}
+	err := c.do(ctx, "CommentCreate", commentCreateMutation, map[string]interface{}{
+		"input": map[string]string{"issueId": issueID, "body": body},
+	}, &data)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	if !data.CommentCreate.Success {
+		return fmt.Errorf("failed to add comment: linear reported no success")
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package linear
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadConfig loads and validates Linear configuration from .takl/linear.json
+// in the specified project directory.
+func LoadConfig(projectPath string) (*LinearConfig, error) {
+	configPath := filepath.Join(projectPath, ".takl", "linear.json")
+
+	// Check file permissions (should be 0600 to protect the API key)
+	fi, statErr := os.Stat(configPath)
+	if statErr == nil && (fi.Mode().Perm()&0o077) != 0 {
+		return nil, fmt.Errorf("insecure permissions on %s; please run: chmod 600 %s", configPath, configPath)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read linear config at %s: %w\nPlease create .takl/linear.json with your Linear credentials", configPath, err)
+	}
+
+	var config LinearConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse linear config: %w", err)
+	}
+
+	// Validate required fields
+	if config.APIKey == "" || config.Team == "" {
+		return nil, fmt.Errorf("linear config is incomplete: api_key and team are required")
+	}
+
+	return &config, nil
+}
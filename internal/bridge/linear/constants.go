@@ -0,0 +1,19 @@
+package linear
+
+const (
+	// MaxResponseSize is the maximum size for Linear API responses (10MB)
+	MaxResponseSize = 10 << 20
+
+	// MaxErrorBodySize is the maximum bytes to read from error response bodies
+	MaxErrorBodySize = 1024
+
+	// PageSize is the number of issues to fetch per query.
+	// Kept well below Linear's 250 maximum because every issue embeds its comments.
+	PageSize = 50
+
+	// CommentPageSize is the number of comments fetched per issue and per follow-up query
+	CommentPageSize = 50
+
+	// MaxIssues is the maximum total number of issues to fetch
+	MaxIssues = 1000
+)
@@ -0,0 +1,83 @@
+package linear
+
+import "time"
+
+// lnIssue represents an issue from the Linear GraphQL API
+//
+// Example node from the issues query:
+//
+//	{
+//	  "id": "9cfb482a-81e3-4154-b5b9-2c805e70a02d",
+//	  "identifier": "ENG-123",
+//	  "title": "Fix login redirect",
+//	  "description": "Markdown body",
+//	  "createdAt": "2025-01-01T00:00:00.000Z",
+//	  "updatedAt": "2025-01-02T00:00:00.000Z",
+//	  "state": {"id": "...", "name": "In Progress", "type": "started"},
+//	  "creator": {"id": "...", "name": "Jane Doe", "email": "jane@example.com"},
+//	  "assignee": null,
+//	  "labels": {"nodes": [{"id": "...", "name": "bug"}]},
+//	  "comments": {"nodes": [...], "pageInfo": {"hasNextPage": false, "endCursor": "..."}}
+//	}
+//
+// Note: description, creator and assignee can be null.
+type lnIssue struct {
+	ID          string    `json:"id"`
+	Identifier  string    `json:"identifier"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	State       lnState   `json:"state"`
+	Creator     *lnUser   `json:"creator"`
+	Assignee    *lnUser   `json:"assignee"`
+	Labels      struct {
+		Nodes []lnLabel `json:"nodes"`
+	} `json:"labels"`
+	Comments lnCommentConnection `json:"comments"`
+}
+
+// lnState is a team workflow state.
+// Type is one of triage, backlog, unstarted, started, completed, canceled.
+type lnState struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type lnUser struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Active bool   `json:"active"`
+}
+
+type lnLabel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// lnComment is an issue comment; user is null for comments made by integrations
+type lnComment struct {
+	ID        string    `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	User      *lnUser   `json:"user"`
+}
+
+type lnCommentConnection struct {
+	Nodes    []lnComment `json:"nodes"`
+	PageInfo pageInfo    `json:"pageInfo"`
+}
+
+// pageInfo is the Relay-style cursor pagination block
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// graphQLError is one entry of a GraphQL "errors" array
+type graphQLError struct {
+	Message string `json:"message"`
+}
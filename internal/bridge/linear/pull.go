@@ -0,0 +1,199 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// formatUser returns the canonical "Name <email>" form of a Linear user
+func formatUser(u *lnUser) string {
+	if u == nil {
+		return ""
+	}
+	return (&issue.Member{DisplayName: u.Name, EmailAddress: u.Email}).FormatMember()
+}
+
+// ownsKey reports whether a local key belongs to the configured team (e.g. ENG-123)
+func ownsKey(team, key string) bool {
+	return strings.HasPrefix(key, team+"-")
+}
+
+// convertIssue converts a Linear issue and its comments to the shared issue model
+func convertIssue(li lnIssue, comments []lnComment) issue.Issue {
+	out := issue.Issue{
+		Key:      li.Identifier,
+		RemoteID: li.ID,
+		Title:    li.Title,
+		Status:   li.State.Name,
+		Reporter: formatUser(li.Creator),
+		Assignee: formatUser(li.Assignee),
+		Created:  li.CreatedAt,
+		Updated:  li.UpdatedAt,
+	}
+
+	if li.Description != nil {
+		out.Description = strings.TrimSpace(*li.Description)
+	}
+
+	for _, l := range li.Labels.Nodes {
+		out.Labels = append(out.Labels, l.Name)
+	}
+	out.Labels = issue.NormalizeLabels(out.Labels)
+
+	out.Comments = make([]issue.Comment, 0, len(comments))
+	for _, c := range comments {
+		author := formatUser(c.User)
+		if author == "" {
+			// Comments made by integrations have no user
+			author = "Linear"
+		}
+		var authorID string
+		if c.User != nil {
+			authorID = c.User.ID
+		}
+		out.Comments = append(out.Comments, issue.Comment{
+			ID:       c.ID,
+			Author:   author,
+			AuthorID: authorID,
+			Body:     strings.TrimSpace(c.Body),
+			Created:  c.CreatedAt,
+			Updated:  c.UpdatedAt,
+		})
+	}
+	// Keep files in chronological order regardless of the API's ordering
+	sort.SliceStable(out.Comments, func(i, j int) bool {
+		return out.Comments[i].Created.Before(out.Comments[j].Created)
+	})
+
+	return out
+}
+
+// allComments returns the issue's comments, fetching any pages beyond the first
+func allComments(ctx context.Context, client *Client, li lnIssue) ([]lnComment, error) {
+	comments := li.Comments.Nodes
+	if li.Comments.PageInfo.HasNextPage {
+		more, err := client.ListComments(ctx, li.ID, li.Comments.PageInfo.EndCursor)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, more...)
+	}
+	return comments, nil
+}
+
+// fetchIssue fetches a single issue with all its comments and converts it
+func fetchIssue(ctx context.Context, client *Client, id string) (*issue.Issue, error) {
+	li, err := client.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := allComments(ctx, client, *li)
+	if err != nil {
+		return nil, err
+	}
+
+	out := convertIssue(*li, comments)
+	return &out, nil
+}
+
+// Pull fetches issues from Linear and saves them to local storage
+func Pull(ctx context.Context, client *Client, storage *issue.Storage, config *LinearConfig) (*issue.PullResult, error) {
+	result := &issue.PullResult{
+		Errors: make([]string, 0),
+	}
+
+	// Refresh workflow states so push can validate status changes (non-fatal)
+	if _, err := RefreshWorkflowCache(ctx, client, storage.ProjectPath(), config.Team); err != nil {
+		log.Printf("[WARN] Pull: Failed to refresh workflow cache: %v", err)
+	}
+
+	lnIssues, err := client.ListIssues(ctx, config.Team, MaxIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	log.Printf("[DEBUG] Pull: Fetched %d issues from Linear", len(lnIssues))
+	result.Fetched = len(lnIssues)
+
+	// Get list of existing local issues
+	localIssues, err := storage.ListIssues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local issues: %w", err)
+	}
+
+	localMap := make(map[string]bool)
+	for _, key := range localIssues {
+		localMap[key] = true
+	}
+
+	fetchedKeys := make(map[string]bool)
+	for _, li := range lnIssues {
+		fetchedKeys[li.Identifier] = true
+	}
+
+	// Delete local issues that are no longer in the team (deleted or moved).
+	// Only keys owned by this bridge are considered.
+	for _, localKey := range localIssues {
+		if !ownsKey(config.Team, localKey) {
+			continue
+		}
+		if !fetchedKeys[localKey] {
+			log.Printf("[DEBUG] Pull: Deleting removed issue %s", localKey)
+			if err := storage.DeleteIssue(localKey); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to delete %s: %v", localKey, err))
+			} else {
+				result.Deleted++
+			}
+		}
+	}
+
+	for _, li := range lnIssues {
+		key := li.Identifier
+		isNew := !localMap[key]
+
+		comments, err := allComments(ctx, client, li)
+		if err != nil {
+			log.Printf("[ERROR] Pull: Failed to fetch comments for %s: %v", key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to fetch comments for %s: %v", key, err))
+			continue
+		}
+
+		converted := convertIssue(li, comments)
+
+		// Skip unchanged issues
+		if !isNew {
+			newHash := storage.ComputeHash(&converted)
+			if oldHash, ok := storage.ReadExistingHash(key); ok && oldHash == newHash {
+				log.Printf("[DEBUG] Pull: Skipping %s (unchanged)", key)
+				continue
+			}
+		}
+
+		if err := storage.SaveIssue(&converted); err != nil {
+			log.Printf("[ERROR] Pull: Failed to save %s: %v", key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to save %s: %v", key, err))
+			continue
+		}
+
+		if isNew {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	log.Printf("[DEBUG] Pull: Complete - Created: %d, Updated: %d, Deleted: %d, Errors: %d", result.Created, result.Updated, result.Deleted, len(result.Errors))
+
+	// Return error if all issues failed to save
+	if len(result.Errors) > 0 && result.Created == 0 && result.Updated == 0 {
+		return result, fmt.Errorf("failed to save any issues (%d errors)", len(result.Errors))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,386 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// fakeLinear is an in-memory stand-in for the Linear GraphQL API.
+// It dispatches on operationName rather than parsing the query.
+type fakeLinear struct {
+	mu       sync.Mutex
+	t        *testing.T
+	issues   map[string]*lnIssue // Key is identifier
+	comments map[string][]lnComment
+	states   []lnState
+	members  []lnUser
+	labels   []lnLabel
+	pageSize int
+	nextID   int
+	updates  []map[string]interface{}
+	server   *httptest.Server
+}
+
+func newFakeLinear(t *testing.T) *fakeLinear {
+	t.Helper()
+	f := &fakeLinear{
+		t:        t,
+		issues:   make(map[string]*lnIssue),
+		comments: make(map[string][]lnComment),
+		states: []lnState{
+			{ID: "s-todo", Name: "Todo", Type: "unstarted"},
+			{ID: "s-prog", Name: "In Progress", Type: "started"},
+			{ID: "s-done", Name: "Done", Type: "completed"},
+		},
+		pageSize: 2,
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeLinear) client() *Client {
+	return NewClient(f.server.URL, "lin_api_test")
+}
+
+func (f *fakeLinear) addIssue(number int, title string, mutate func(*lnIssue)) {
+	description := "Description of " + title
+	li := &lnIssue{
+		ID:          fmt.Sprintf("uuid-%d", number),
+		Identifier:  fmt.Sprintf("ENG-%d", number),
+		Title:       title,
+		Description: &description,
+		State:       f.states[0],
+		Creator:     &lnUser{ID: "u-1", Name: "Jane Doe", Email: "jane@example.com"},
+		CreatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 1, number, 0, 0, 0, 0, time.UTC),
+	}
+	if mutate != nil {
+		mutate(li)
+	}
+	f.issues[li.Identifier] = li
+}
+
+func (f *fakeLinear) addComment(key, body string, user *lnUser) {
+	f.nextID++
+	f.comments[key] = append(f.comments[key], lnComment{
+		ID:        fmt.Sprintf("c-%d", f.nextID),
+		Body:      body,
+		User:      user,
+		CreatedAt: time.Date(2025, 2, len(f.comments[key])+1, 0, 0, 0, 0, time.UTC),
+	})
+}
+
+// find looks up an issue by identifier or ID, like Linear's issue(id:) field
+func (f *fakeLinear) find(id string) *lnIssue {
+	if li, ok := f.issues[id]; ok {
+		return li
+	}
+	for _, li := range f.issues {
+		if li.ID == id {
+			return li
+		}
+	}
+	return nil
+}
+
+func (f *fakeLinear) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "lin_api_test" {
+		http.Error(w, `{"errors":[{"message":"Authentication required"}]}`, http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	vars := req.Variables
+
+	switch req.OperationName {
+	case "Issues":
+		keys := make([]string, 0, len(f.issues))
+		for k := range f.issues {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		list := make([]lnIssue, 0, len(keys))
+		for _, k := range keys {
+			list = append(list, f.withComments(*f.issues[k]))
+		}
+		nodes, info := page(list, f.pageSize, vars["after"])
+		f.writeData(w, map[string]interface{}{
+			"issues": map[string]interface{}{"nodes": nodes, "pageInfo": info},
+		})
+
+	case "Issue":
+		li := f.find(vars["id"].(string))
+		if li == nil {
+			f.writeErrors(w, "Entity not found")
+			return
+		}
+		f.writeData(w, map[string]interface{}{"issue": f.withComments(*li)})
+
+	case "Comments":
+		li := f.find(vars["id"].(string))
+		if li == nil {
+			f.writeErrors(w, "Entity not found")
+			return
+		}
+		nodes, info := page(f.comments[li.Identifier], f.pageSize, vars["after"])
+		f.writeData(w, map[string]interface{}{
+			"issue": map[string]interface{}{
+				"comments": map[string]interface{}{"nodes": nodes, "pageInfo": info},
+			},
+		})
+
+	case "States":
+		f.writeData(w, map[string]interface{}{
+			"workflowStates": map[string]interface{}{"nodes": f.states},
+		})
+
+	case "Members":
+		f.writeData(w, map[string]interface{}{
+			"teams": map[string]interface{}{
+				"nodes": []interface{}{map[string]interface{}{
+					"members": map[string]interface{}{"nodes": f.members},
+				}},
+			},
+		})
+
+	case "Labels":
+		f.writeData(w, map[string]interface{}{
+			"issueLabels": map[string]interface{}{"nodes": f.labels},
+		})
+
+	case "IssueUpdate":
+		li := f.find(vars["id"].(string))
+		if li == nil {
+			f.writeErrors(w, "Entity not found")
+			return
+		}
+		input, _ := vars["input"].(map[string]interface{})
+		f.updates = append(f.updates, input)
+		if v, ok := input["title"].(string); ok {
+			li.Title = v
+		}
+		if v, ok := input["description"].(string); ok {
+			li.Description = &v
+		}
+		if v, ok := input["stateId"].(string); ok {
+			for _, s := range f.states {
+				if s.ID == v {
+					li.State = s
+				}
+			}
+		}
+		if v, ok := input["assigneeId"]; ok {
+			li.Assignee = nil
+			for _, m := range f.members {
+				if m.ID == v {
+					li.Assignee = &m
+				}
+			}
+		}
+		if v, ok := input["labelIds"].([]interface{}); ok {
+			li.Labels.Nodes = nil
+			for _, id := range v {
+				for _, l := range f.labels {
+					if l.ID == id {
+						li.Labels.Nodes = append(li.Labels.Nodes, l)
+					}
+				}
+			}
+		}
+		li.UpdatedAt = li.UpdatedAt.Add(time.Hour)
+		f.writeData(w, map[string]interface{}{"issueUpdate": map[string]bool{"success": true}})
+
+	case "CommentCreate":
+		input, _ := vars["input"].(map[string]interface{})
+		li := f.find(input["issueId"].(string))
+		if li == nil {
+			f.writeErrors(w, "Entity not found")
+			return
+		}
+		f.addComment(li.Identifier, input["body"].(string), &lnUser{ID: "u-1", Name: "Jane Doe", Email: "jane@example.com"})
+		f.writeData(w, map[string]interface{}{"commentCreate": map[string]bool{"success": true}})
+
+	default:
+		f.writeErrors(w, "Unknown operation "+req.OperationName)
+	}
+}
+
+// withComments returns the issue with the first page of its comments attached
+func (f *fakeLinear) withComments(li lnIssue) lnIssue {
+	nodes, info := page(f.comments[li.Identifier], f.pageSize, nil)
+	li.Comments = lnCommentConnection{Nodes: nodes, PageInfo: info}
+	return li
+}
+
+// page returns one page of items after the given cursor; cursors are offsets
+func page[T any](items []T, size int, after interface{}) ([]T, pageInfo) {
+	start := 0
+	if s, ok := after.(string); ok && s != "" {
+		start, _ = strconv.Atoi(s)
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], pageInfo{HasNextPage: end < len(items), EndCursor: strconv.Itoa(end)}
+}
+
+func (f *fakeLinear) writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": data}); err != nil {
+		f.t.Errorf("failed to encode fake response: %v", err)
+	}
+}
+
+func (f *fakeLinear) writeErrors(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":   nil,
+		"errors": []graphQLError{{Message: message}},
+	}); err != nil {
+		f.t.Errorf("failed to encode fake response: %v", err)
+	}
+}
+
+// TestListIssues_Pagination tests that every page is fetched by following cursors
+func TestListIssues_Pagination(t *testing.T) {
+	f := newFakeLinear(t)
+	for i := 1; i <= 5; i++ {
+		f.addIssue(i, fmt.Sprintf("Issue %d", i), nil)
+	}
+
+	issues, err := f.client().ListIssues(context.Background(), "ENG", 0)
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(issues) != 5 {
+		t.Errorf("Expected 5 issues, got %d", len(issues))
+	}
+}
+
+// TestClient_GraphQLError tests that errors in a 200 response are surfaced
+func TestClient_GraphQLError(t *testing.T) {
+	f := newFakeLinear(t)
+
+	_, err := f.client().GetIssue(context.Background(), "ENG-404")
+	if err == nil || !strings.Contains(err.Error(), "Entity not found") {
+		t.Errorf("Expected GraphQL error to be returned, got %v", err)
+	}
+}
+
+// TestPull_MapsFields tests that pull maps Linear fields into the markdown
+// model, follows comment pages, and caches the team's workflow states
+func TestPull_MapsFields(t *testing.T) {
+	f := newFakeLinear(t)
+	f.addIssue(1, "First", func(li *lnIssue) {
+		li.State = f.states[1]
+		li.Assignee = &lnUser{ID: "u-2", Name: "Adam Smith", Email: "adam@example.com"}
+		li.Labels.Nodes = []lnLabel{{Name: "bug"}, {Name: "api"}}
+	})
+	reviewer := &lnUser{ID: "u-3", Name: "Reviewer"}
+	f.addComment("ENG-1", "Comment 1", reviewer)
+	f.addComment("ENG-1", "Comment 2", reviewer)
+	f.addComment("ENG-1", "Comment 3", nil)
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("Expected 1 created issue, got %d", result.Created)
+	}
+
+	got, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if got.RemoteID != "uuid-1" {
+		t.Errorf("Expected remote ID uuid-1, got %q", got.RemoteID)
+	}
+	if got.Status != "In Progress" {
+		t.Errorf("Expected status %q, got %q", "In Progress", got.Status)
+	}
+	if got.Reporter != "Jane Doe <jane@example.com>" {
+		t.Errorf("Expected reporter %q, got %q", "Jane Doe <jane@example.com>", got.Reporter)
+	}
+	if got.Assignee != "Adam Smith <adam@example.com>" {
+		t.Errorf("Expected assignee %q, got %q", "Adam Smith <adam@example.com>", got.Assignee)
+	}
+	if strings.Join(got.Labels, ",") != "api,bug" {
+		t.Errorf("Expected labels [api bug], got %v", got.Labels)
+	}
+	if len(got.Comments) != 3 {
+		t.Fatalf("Expected 3 comments across pages, got %d", len(got.Comments))
+	}
+	if got.Comments[2].Body != "Comment 3" || got.Comments[2].Author != "Linear" {
+		t.Errorf("Expected last comment by Linear, got %+v", got.Comments[2])
+	}
+
+	cache, err := LoadWorkflowCache(storage.ProjectPath())
+	if err != nil {
+		t.Fatalf("LoadWorkflowCache failed: %v", err)
+	}
+	if s := cache.FindByName("Done"); s == nil || s.ID != "s-done" || s.Category != "done" {
+		t.Errorf("Expected Done state in workflow cache, got %+v", s)
+	}
+}
+
+// TestPull_DeletesOnlyOwnKeys tests that pull removes vanished team issues
+// but leaves issues from other teams and bridges alone
+func TestPull_DeletesOnlyOwnKeys(t *testing.T) {
+	f := newFakeLinear(t)
+	f.addIssue(1, "Keep", nil)
+
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	for _, key := range []string{"ENG-2", "OPS-7"} {
+		if err := storage.SaveIssue(&issue.Issue{Key: key, Title: key}); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+
+	result, err := Pull(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.Deleted != 1 {
+		t.Errorf("Expected 1 deleted issue, got %d", result.Deleted)
+	}
+
+	keys, _ := storage.ListIssues()
+	if strings.Join(keys, ",") != "ENG-1,OPS-7" {
+		t.Errorf("Expected keys [ENG-1 OPS-7], got %v", keys)
+	}
+}
@@ -0,0 +1,199 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Push pushes local changes to Linear with strict conflict detection.
+// Fails for an issue if the remote has ANY changes since the last pull.
+// If issueKey is provided, only that issue will be pushed.
+func Push(ctx context.Context, client *Client, storage *issue.Storage, config *LinearConfig, issueKey string) (*issue.PushResult, error) {
+	result := &issue.PushResult{
+		Conflicts: make([]issue.ConflictInfo, 0),
+		Errors:    make([]string, 0),
+	}
+
+	// Get local issues (all or specific one)
+	var localIssues []*issue.Issue
+	if issueKey != "" {
+		single, err := storage.ReadIssue(issueKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read issue %s: %w", issueKey, err)
+		}
+		localIssues = []*issue.Issue{single}
+	} else {
+		all, err := storage.ListAllIssues()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list local issues: %w", err)
+		}
+		// Only issues owned by this bridge
+		for _, local := range all {
+			if ownsKey(config.Team, local.Key) {
+				localIssues = append(localIssues, local)
+			}
+		}
+	}
+
+	result.Scanned = len(localIssues)
+
+	for _, localIssue := range localIssues {
+		localHash := storage.ComputeHash(localIssue)
+		baseHash := localIssue.Hash
+		if localHash == baseHash {
+			log.Printf("[DEBUG] Push: Skipping %s (no local changes)", localIssue.Key)
+			result.Skipped++
+			continue
+		}
+
+		// Fetch current remote version for conflict detection
+		remoteIssue, err := fetchIssue(ctx, client, localIssue.Key)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: failed to fetch remote: %v", localIssue.Key, err))
+			continue
+		}
+
+		if storage.ComputeHash(remoteIssue) != baseHash {
+			log.Printf("[WARN] Push: Conflict detected for %s (remote modified)", localIssue.Key)
+			result.Conflicts = append(result.Conflicts, issue.ConflictInfo{
+				IssueKey: localIssue.Key,
+				Updated:  remoteIssue.Updated,
+			})
+			continue
+		}
+
+		if err := pushIssue(ctx, client, storage, config.Team, localIssue, remoteIssue); err != nil {
+			log.Printf("[ERROR] Push: Failed to push %s: %v", localIssue.Key, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", localIssue.Key, err))
+			continue
+		}
+
+		result.Pushed++
+	}
+
+	log.Printf("[DEBUG] Push: Complete - Scanned: %d, Pushed: %d, Skipped: %d, Conflicts: %d, Errors: %d",
+		result.Scanned, result.Pushed, result.Skipped, len(result.Conflicts), len(result.Errors))
+
+	if len(result.Conflicts) > 0 {
+		return result, fmt.Errorf("cannot push - %d issue(s) have conflicts", len(result.Conflicts))
+	}
+
+	return result, nil
+}
+
+// pushIssue pushes changes for a single issue to Linear.
+// Compares local vs remote and updates only what changed.
+func pushIssue(ctx context.Context, client *Client, storage *issue.Storage, team string, local, remote *issue.Issue) error {
+	input := make(map[string]interface{})
+
+	if local.Title != remote.Title {
+		input["title"] = local.Title
+	}
+
+	if local.Description != remote.Description {
+		input["description"] = local.Description
+	}
+
+	// Status changes are validated against the cached team workflow states
+	if local.Status != remote.Status {
+		workflowCache, err := LoadWorkflowCache(storage.ProjectPath())
+		if err != nil {
+			log.Printf("[WARN] pushIssue: Failed to load workflow cache: %v", err)
+			workflowCache = issue.NewWorkflowCache()
+		}
+
+		status := workflowCache.FindByName(local.Status)
+		if status == nil {
+			return fmt.Errorf("invalid status %q: not found in team workflow (run 'takl pull' to refresh workflow states)", local.Status)
+		}
+		input["stateId"] = status.ID
+	}
+
+	// Assignees are resolved among the team's members
+	if local.Assignee != remote.Assignee {
+		if local.Assignee == "" {
+			input["assigneeId"] = nil
+		} else {
+			id, err := resolveMember(ctx, client, team, local.Assignee)
+			if err != nil {
+				return fmt.Errorf("invalid assignee: %w", err)
+			}
+			input["assigneeId"] = id
+		}
+	}
+
+	// Labels are set as a whole, by the IDs of existing team or workspace labels
+	if !slices.Equal(issue.NormalizeLabels(local.Labels), issue.NormalizeLabels(remote.Labels)) {
+		ids, err := resolveLabels(ctx, client, team, local.Labels)
+		if err != nil {
+			return err
+		}
+		input["labelIds"] = ids
+	}
+
+	if len(input) > 0 {
+		if err := client.UpdateIssue(ctx, remote.RemoteID, input); err != nil {
+			return err
+		}
+	}
+
+	// We only support adding new comments, not editing existing ones
+	for i := len(remote.Comments); i < len(local.Comments); i++ {
+		if err := client.AddComment(ctx, remote.RemoteID, local.Comments[i].Body); err != nil {
+			return fmt.Errorf("failed to add comment #%d: %w", i+1, err)
+		}
+	}
+
+	// Refresh the local file so the stored hash matches the new remote state
+	updated, err := fetchIssue(ctx, client, local.Key)
+	if err != nil {
+		log.Printf("[WARN] pushIssue: Failed to fetch updated issue, keeping local hash: %v", err)
+		return nil
+	}
+	if err := storage.SaveIssue(updated); err != nil {
+		log.Printf("[WARN] pushIssue: Failed to save updated issue: %v", err)
+	}
+
+	return nil
+}
+
+// resolveMember returns the Linear user ID of a team member given as
+// "Name <email>", an email or a name
+func resolveMember(ctx context.Context, client *Client, team, user string) (string, error) {
+	members, err := teamMembers(ctx, client, team)
+	if err != nil {
+		return "", err
+	}
+	cache := issue.NewMemberCache()
+	for _, m := range members {
+		cache.Add(m)
+	}
+	member, err := cache.ParseUser(user)
+	if err != nil {
+		return "", err
+	}
+	return member.AccountID, nil
+}
+
+// resolveLabels returns the IDs of the named labels, matched case-insensitively.
+// Labels that do not exist are an error; push does not create them.
+func resolveLabels(ctx context.Context, client *Client, team string, names []string) ([]string, error) {
+	labels, err := client.ListLabels(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(names))
+	for _, name := range issue.NormalizeLabels(names) {
+		i := slices.IndexFunc(labels, func(l lnLabel) bool { return strings.EqualFold(l.Name, name) })
+		if i == -1 {
+			return nil, fmt.Errorf("invalid label %q: not found in team %s (create it in Linear first)", name, team)
+		}
+		ids = append(ids, labels[i].ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,210 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// pullOne pulls the fake team into fresh storage and returns the storage
+func pullOne(t *testing.T, f *fakeLinear) *issue.Storage {
+	t.Helper()
+	storage, err := issue.NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	if _, err := Pull(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}); err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	return storage
+}
+
+// TestPush_UpdatesFields tests that local edits are sent as one issueUpdate
+// with the state resolved to its ID, and new comments are created
+func TestPush_UpdatesFields(t *testing.T) {
+	f := newFakeLinear(t)
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Title = "Renamed"
+	edited.Status = "Done"
+	edited.Comments = append(edited.Comments, issue.Comment{Author: "me", Body: "Shipped"})
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}, "")
+	if err != nil {
+		t.Fatalf("Push failed: %v (errors: %v)", err, result.Errors)
+	}
+	if result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %d (errors: %v)", result.Pushed, result.Errors)
+	}
+
+	if len(f.updates) != 1 {
+		t.Fatalf("Expected 1 issueUpdate, got %d", len(f.updates))
+	}
+	update := f.updates[0]
+	if update["title"] != "Renamed" || update["stateId"] != "s-done" {
+		t.Errorf("Unexpected update: %v", update)
+	}
+	if _, ok := update["description"]; ok {
+		t.Errorf("Unchanged description should not be sent")
+	}
+
+	refreshed, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if len(refreshed.Comments) != 1 || refreshed.Comments[0].Body != "Shipped" {
+		t.Errorf("Expected the posted comment, got %v", refreshed.Comments)
+	}
+	if storage.ComputeHash(refreshed) != refreshed.Hash {
+		t.Errorf("Expected refreshed file to have no pending local changes")
+	}
+}
+
+// TestPush_Assignee tests that a reassignment is sent as the user ID of the
+// team member
+func TestPush_Assignee(t *testing.T) {
+	f := newFakeLinear(t)
+	f.members = []lnUser{{ID: "u-2", Name: "Bob Jones", Email: "bob@example.com", Active: true}}
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Assignee = "bob@example.com"
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}, "")
+	if err != nil || result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %+v (err: %v)", result, err)
+	}
+	if len(f.updates) != 1 || f.updates[0]["assigneeId"] != "u-2" {
+		t.Errorf("Expected assigneeId u-2, got %v", f.updates)
+	}
+
+	refreshed, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if refreshed.Assignee != "Bob Jones <bob@example.com>" || storage.ComputeHash(refreshed) != refreshed.Hash {
+		t.Errorf("Expected the reassigned issue saved with a fresh hash, got %+v", refreshed)
+	}
+}
+
+// TestPush_Labels tests that labels are pushed by ID, and that a label missing
+// in Linear fails the issue without touching the local file
+func TestPush_Labels(t *testing.T) {
+	f := newFakeLinear(t)
+	f.labels = []lnLabel{{ID: "l-1", Name: "Bug"}, {ID: "l-2", Name: "Backend"}}
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Labels = []string{"bug", "frontend"}
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}, "")
+	if err != nil || result.Pushed != 0 || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], `"frontend"`) {
+		t.Fatalf("Expected an error for the unknown label, got %+v (err: %v)", result, err)
+	}
+	if len(f.updates) != 0 {
+		t.Errorf("Expected no updates, got %v", f.updates)
+	}
+	if kept, _ := storage.ReadIssue("ENG-1"); !slices.Equal(kept.Labels, edited.Labels) {
+		t.Errorf("Expected the local labels kept, got %v", kept.Labels)
+	}
+
+	edited.Labels = []string{"Backend", "bug"}
+	writeLocalEdit(t, storage, edited)
+	result, err = Push(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}, "")
+	if err != nil || result.Pushed != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %+v (err: %v)", result, err)
+	}
+	if len(f.updates) != 1 || fmt.Sprint(f.updates[0]["labelIds"]) != "[l-2 l-1]" {
+		t.Errorf("Expected labelIds [l-2 l-1], got %v", f.updates)
+	}
+
+	refreshed, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if !slices.Equal(refreshed.Labels, []string{"Backend", "Bug"}) || storage.ComputeHash(refreshed) != refreshed.Hash {
+		t.Errorf("Expected the relabelled issue saved with a fresh hash, got %+v", refreshed)
+	}
+}
+
+// TestPush_Conflict tests that remote edits since the last pull block the push
+func TestPush_Conflict(t *testing.T) {
+	f := newFakeLinear(t)
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Title = "Local title"
+	writeLocalEdit(t, storage, edited)
+
+	// Remote changes after pull
+	f.issues["ENG-1"].Title = "Remote title"
+
+	result, err := Push(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}, "")
+	if err == nil {
+		t.Fatalf("Expected conflict error")
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].IssueKey != "ENG-1" {
+		t.Errorf("Expected conflict for ENG-1, got %v", result.Conflicts)
+	}
+	if len(f.updates) != 0 {
+		t.Errorf("Expected no updates on conflict, got %d", len(f.updates))
+	}
+}
+
+// TestPush_InvalidStatus tests that statuses missing from the workflow cache are rejected
+func TestPush_InvalidStatus(t *testing.T) {
+	f := newFakeLinear(t)
+	f.addIssue(1, "First", nil)
+	storage := pullOne(t, f)
+
+	edited, err := storage.ReadIssue("ENG-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	edited.Status = "Shipped"
+	writeLocalEdit(t, storage, edited)
+
+	result, err := Push(context.Background(), f.client(), storage, &LinearConfig{Team: "ENG"}, "ENG-1")
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "invalid status") {
+		t.Errorf("Expected invalid status error, got %v", result.Errors)
+	}
+	if len(f.updates) != 0 {
+		t.Errorf("Expected no updates, got %d", len(f.updates))
+	}
+}
+
+// writeLocalEdit saves an edited issue while keeping its base hash, the way
+// a hand edit of the markdown file would
+func writeLocalEdit(t *testing.T, storage *issue.Storage, edited *issue.Issue) {
+	t.Helper()
+	if err := storage.WriteIssue(edited); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package linear
+
+import "fmt"
+
+// DefaultBaseURL is the Linear GraphQL endpoint used when base_url is not configured
+const DefaultBaseURL = "https://api.linear.app/graphql"
+
+// LinearConfig holds Linear connection configuration
+type LinearConfig struct {
+	BaseURL string `yaml:"base_url,omitempty" json:"base_url,omitempty"` // GraphQL endpoint
+	APIKey  string `yaml:"api_key" json:"api_key"`                       // Personal API key (lin_api_...) or OAuth access token
+	Team    string `yaml:"team" json:"team"`                             // Team key, also the issue key prefix (e.g. "ENG" → ENG-123)
+}
+
+// String returns a sanitized string representation (hides API key)
+func (c LinearConfig) String() string {
+	key := "***REDACTED***"
+	if len(c.APIKey) > 8 {
+		key = c.APIKey[:8] + "***"
+	}
+	return fmt.Sprintf("LinearConfig{BaseURL: %s, Team: %s, APIKey: %s}", c.BaseURL, c.Team, key)
+}
+
+// Endpoint returns the configured GraphQL endpoint or the public Linear API
+func (c LinearConfig) Endpoint() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return DefaultBaseURL
+}
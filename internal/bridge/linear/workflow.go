@@ -0,0 +1,67 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gurisko/takl/internal/issue"
+)
+
+const workflowCacheFilename = "linear-workflow.json"
+
+// stateCategory maps a Linear workflow state type onto the status categories
+// used by the workflow cache
+func stateCategory(stateType string) string {
+	switch stateType {
+	case "triage", "backlog", "unstarted":
+		return "new"
+	case "started":
+		return "indeterminate"
+	case "completed", "canceled":
+		return "done"
+	default:
+		return "undefined"
+	}
+}
+
+// LoadWorkflowCache loads the workflow cache from .takl/linear-workflow.json
+func LoadWorkflowCache(projectPath string) (*issue.WorkflowCache, error) {
+	return issue.LoadWorkflowCache(projectPath, workflowCacheFilename)
+}
+
+// RefreshWorkflowCache fetches the team's workflow states and replaces the local cache.
+// Returns the cache (loaded from disk if fetch fails) and any error.
+// This function is non-fatal - it will return a cache even if fetching fails.
+func RefreshWorkflowCache(ctx context.Context, client *Client, projectPath, teamKey string) (*issue.WorkflowCache, error) {
+	log.Printf("[DEBUG] RefreshWorkflowCache: Fetching workflow states")
+
+	states, err := client.ListStates(ctx, teamKey)
+	if err != nil {
+		log.Printf("[WARN] RefreshWorkflowCache: Failed to fetch workflow states: %v", err)
+		cache, loadErr := LoadWorkflowCache(projectPath)
+		if loadErr != nil {
+			log.Printf("[WARN] RefreshWorkflowCache: Failed to load existing cache: %v", loadErr)
+			return issue.NewWorkflowCache(), fmt.Errorf("failed to fetch states: %w", err)
+		}
+		return cache, fmt.Errorf("failed to fetch states (using cached data): %w", err)
+	}
+
+	// Create new cache from fetched data (don't merge with old cache)
+	cache := issue.NewWorkflowCache()
+	for _, s := range states {
+		cache.AddStatus(&issue.StatusInfo{
+			ID:       s.ID,
+			Name:     s.Name,
+			Category: stateCategory(s.Type),
+		})
+	}
+
+	if err := issue.SaveWorkflowCache(projectPath, workflowCacheFilename, cache); err != nil {
+		log.Printf("[WARN] RefreshWorkflowCache: Failed to save cache: %v", err)
+		return cache, fmt.Errorf("failed to save cache: %w", err)
+	}
+
+	log.Printf("[DEBUG] RefreshWorkflowCache: Successfully cached %d states", len(states))
+	return cache, nil
+}
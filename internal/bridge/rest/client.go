@@ -0,0 +1,141 @@
+// Package rest holds the HTTP plumbing shared by the bridges of JSON REST
+// APIs that paginate with Link headers (GitHub and GitLab).
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxResponseSize is the maximum size for API responses (10MB)
+	MaxResponseSize = 10 << 20
+
+	// MaxErrorBodySize is the maximum bytes to read from error response bodies
+	MaxErrorBodySize = 1024
+)
+
+// Client executes authenticated JSON requests against one API base URL
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	name       string      // API name for logs and errors, e.g. "GitHub"
+	header     http.Header // Authentication and content negotiation, set on every request
+}
+
+// NewClient creates a client for the API at baseURL, sending header with
+// every request
+func NewClient(name, baseURL string, header http.Header) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		name:       name,
+		header:     header,
+	}
+}
+
+// BaseURL returns the API base URL without a trailing slash
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Do executes an HTTP request with authentication.
+// path may be an API path or an absolute URL from a pagination Link header.
+// Responses with an error status are returned as errors.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	endpoint := c.baseURL + path
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		// Only follow links that point back at the configured API
+		if !strings.HasPrefix(path, c.baseURL+"/") {
+			return nil, fmt.Errorf("refusing to follow link outside %s: %s", c.baseURL, path)
+		}
+		endpoint = path
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodySize))
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s API error %d: %s", strings.ToLower(c.name), resp.StatusCode, string(body))
+	}
+
+	return resp, nil
+}
+
+// linkNextRegex extracts the rel="next" URL from a Link header
+var linkNextRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// NextLink returns the rel="next" URL from the Link header, or "" on the last page
+func NextLink(resp *http.Response) string {
+	if m := linkNextRegex.FindStringSubmatch(resp.Header.Get("Link")); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// NextPage returns the path or URL of the page after the one requested at
+// path, or "" on the last page
+type NextPage func(resp *http.Response, path string) string
+
+// GetPaginated fetches all pages of a list endpoint, decoding each page with decode.
+// Stops early once limit items have been decoded (limit <= 0 means unlimited).
+func (c *Client) GetPaginated(ctx context.Context, path string, limit int, next NextPage, decode func(io.Reader) (int, error)) error {
+	total := 0
+	pageNum := 1
+	for path != "" {
+		log.Printf("[DEBUG] %s: Fetching page %d of %s", c.name, pageNum, path)
+
+		resp, err := c.Do(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return err
+		}
+
+		n, err := decode(io.LimitReader(resp.Body, MaxResponseSize))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		total += n
+		if limit > 0 && total >= limit {
+			break
+		}
+
+		path = next(resp, path)
+		pageNum++
+	}
+	return nil
+}
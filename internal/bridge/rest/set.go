@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"sort"
+	"strings"
+)
+
+// SameSet compares two string slices as sets
+func SameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return strings.Join(SortedCopy(a), "\x00") == strings.Join(SortedCopy(b), "\x00")
+}
+
+// SortedCopy returns a sorted copy of the slice (never nil, so it encodes as [])
+func SortedCopy(items []string) []string {
+	out := make([]string, len(items))
+	copy(out, items)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,173 @@
+//go:build unix
+
+package daemon
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/gurisko/takl/internal/bridge/linear"
+	"github.com/gurisko/takl/internal/issue"
+)
+
+const (
+	// changesPollInterval is how often a waiting changes request checks the issue files
+	changesPollInterval = 500 * time.Millisecond
+
+	// maxChangesWait caps how long a changes request waits; it stays below the
+	// CLI's response header timeout
+	maxChangesWait = 25 * time.Second
+)
+
+// Response types
+
+type WorkflowResponse struct {
+	Statuses []*issue.StatusInfo `json:"statuses"`
+}
+
+type MembersResponse struct {
+	Members []*issue.Member `json:"members"`
+}
+
+type ChangesResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Changed     bool   `json:"changed"`
+}
+
+// workflowLoaders load the cached workflow of bridges that have one
+var workflowLoaders = map[string]func(projectPath string) (*issue.WorkflowCache, error){
+	issue.BridgeJira:   jira.LoadWorkflowCache,
+	issue.BridgeLinear: linear.LoadWorkflowCache,
+}
+
+// memberLoaders load the cached members of bridges that have one
+var memberLoaders = map[string]func(projectPath string) (*issue.MemberCache, error){
+	issue.BridgeJira: jira.LoadMembersCache,
+}
+
+// Handler methods
+
+// handleWorkflow handles GET /api/workflow
+// Returns the cached workflow statuses of the project's bridge without
+// contacting the tracker; empty for bridges without a workflow
+func (d *Daemon) handleWorkflow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectPath := r.URL.Query().Get("project_path")
+	if projectPath == "" {
+		writeError(w, "project_path query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	resp := WorkflowResponse{Statuses: make([]*issue.StatusInfo, 0)}
+	if load, ok := workflowLoaders[d.bridgeType(projectPath)]; ok {
+		cache, err := load(projectPath)
+		if err != nil {
+			writeError(w, "failed to load workflow cache: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, status := range cache.Statuses {
+			resp.Statuses = append(resp.Statuses, status)
+		}
+		sortStatuses(resp.Statuses)
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// handleMembers handles GET /api/members
+// Returns the cached members of the project's bridge by display name without
+// contacting the tracker; empty for bridges without a member cache
+func (d *Daemon) handleMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectPath := r.URL.Query().Get("project_path")
+	if projectPath == "" {
+		writeError(w, "project_path query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	resp := MembersResponse{Members: make([]*issue.Member, 0)}
+	if load, ok := memberLoaders[d.bridgeType(projectPath)]; ok {
+		cache, err := load(projectPath)
+		if err != nil {
+			writeError(w, "failed to load member cache: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, member := range cache.Members {
+			if member.Active {
+				resp.Members = append(resp.Members, member)
+			}
+		}
+		sort.Slice(resp.Members, func(i, j int) bool {
+			return strings.ToLower(resp.Members[i].DisplayName) < strings.ToLower(resp.Members[j].DisplayName)
+		})
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// handleChanges handles GET /api/changes
+// Reports the fingerprint of the project's issue files (see Storage.Fingerprint).
+// With since set, waits up to wait seconds (default and max 25) for the
+// fingerprint to differ from it, so clients can long-poll for changes.
+func (d *Daemon) handleChanges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	projectPath := query.Get("project_path")
+	if projectPath == "" {
+		writeError(w, "project_path query parameter is required", http.StatusBadRequest)
+		return
+	}
+	wait := maxChangesWait
+	if v := query.Get("wait"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds < 0 {
+			writeError(w, "wait query parameter must be a number of seconds", http.StatusBadRequest)
+			return
+		}
+		wait = min(time.Duration(seconds)*time.Second, maxChangesWait)
+	}
+
+	storage, err := issue.OpenStorage(projectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	since := query.Get("since")
+	deadline := time.Now().Add(wait)
+	ticker := time.NewTicker(changesPollInterval)
+	defer ticker.Stop()
+	for {
+		fingerprint, err := storage.Fingerprint()
+		if err != nil {
+			writeError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if since == "" || fingerprint != since || !time.Now().Before(deadline) {
+			writeJSON(w, ChangesResponse{Fingerprint: fingerprint, Changed: since != "" && fingerprint != since}, http.StatusOK)
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
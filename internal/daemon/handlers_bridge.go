@@ -0,0 +1,320 @@
+//go:build unix
+
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gurisko/takl/internal/bridge/github"
+	"github.com/gurisko/takl/internal/bridge/gitlab"
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/gurisko/takl/internal/bridge/linear"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/gurisko/takl/internal/limits"
+)
+
+// bridgeFactory creates a bridge from the configuration stored in a project
+type bridgeFactory func(projectPath string) (issue.Bridge, error)
+
+// bridgeFactories maps bridge types to their constructors
+var bridgeFactories = map[string]bridgeFactory{
+	issue.BridgeJira: func(projectPath string) (issue.Bridge, error) {
+		config, err := jira.LoadConfig(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		return jira.NewBridge(config), nil
+	},
+	issue.BridgeGitHub: func(projectPath string) (issue.Bridge, error) {
+		config, err := github.LoadConfig(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		return github.NewBridge(config), nil
+	},
+	issue.BridgeGitLab: func(projectPath string) (issue.Bridge, error) {
+		config, err := gitlab.LoadConfig(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		return gitlab.NewBridge(config), nil
+	},
+	issue.BridgeLinear: func(projectPath string) (issue.Bridge, error) {
+		config, err := linear.LoadConfig(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		return linear.NewBridge(config), nil
+	},
+	issue.BridgeLocal: func(projectPath string) (issue.Bridge, error) {
+		return issue.LocalBridge{}, nil
+	},
+}
+
+// bridgeConfigFiles lists the config files used to detect the bridge of
+// projects that were registered without one, in order of precedence
+var bridgeConfigFiles = []struct {
+	bridge string
+	file   string
+}{
+	{issue.BridgeJira, "jira.json"},
+	{issue.BridgeGitHub, "github.json"},
+	{issue.BridgeGitLab, "gitlab.json"},
+	{issue.BridgeLinear, "linear.json"},
+}
+
+// validBridge reports whether name is a known bridge type
+func validBridge(name string) bool {
+	_, ok := bridgeFactories[name]
+	return ok
+}
+
+// bridgeType returns the bridge type for a project: the one set in the
+// registry, else the first bridge whose config file exists, else local
+func (d *Daemon) bridgeType(projectPath string) string {
+	if project, ok := d.registry.FindByPath(projectPath); ok && project.Bridge != "" {
+		return project.Bridge
+	}
+	for _, c := range bridgeConfigFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, ".takl", c.file)); err == nil {
+			return c.bridge
+		}
+	}
+	return issue.BridgeLocal
+}
+
+// bridgeFor creates the bridge for a project from its stored configuration.
+// name selects the bridge; empty means the project's bridge type.
+func (d *Daemon) bridgeFor(projectPath, name string) (issue.Bridge, error) {
+	if name == "" {
+		name = d.bridgeType(projectPath)
+	}
+	factory, ok := bridgeFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bridge %q", name)
+	}
+	return factory(projectPath)
+}
+
+// bridgeRequest is the JSON payload for bridge requests.
+// Credentials are resolved by the daemon, never sent by the CLI.
+type bridgeRequest struct {
+	ProjectPath string `json:"project_path"`
+	IssueKey    string `json:"issue_key,omitempty"` // Optional: push only this issue
+	Bridge      string `json:"bridge,omitempty"`    // Optional: use this bridge instead of the project's
+}
+
+// decodeBridgePayload decodes and validates a bridge request body.
+// Writes the error response and returns false on failure.
+func decodeBridgePayload(w http.ResponseWriter, r *http.Request) (*bridgeRequest, bool) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	var req bridgeRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	if req.ProjectPath == "" {
+		writeError(w, "project_path is required", http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+// decodeBridgeRequest decodes and validates a generic bridge request and
+// creates the project's bridge. Writes the error response and returns false on failure.
+func (d *Daemon) decodeBridgeRequest(w http.ResponseWriter, r *http.Request) (*bridgeRequest, issue.Bridge, bool) {
+	req, ok := decodeBridgePayload(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if req.Bridge != "" && !validBridge(req.Bridge) {
+		writeError(w, fmt.Sprintf("unknown bridge %q", req.Bridge), http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	bridge, err := d.bridgeFor(req.ProjectPath, req.Bridge)
+	if err != nil {
+		writeError(w, "failed to load bridge config: "+err.Error(), http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	return req, bridge, true
+}
+
+// handleBridgePull handles POST /api/bridge/pull
+func (d *Daemon) handleBridgePull(w http.ResponseWriter, r *http.Request) {
+	req, bridge, ok := d.decodeBridgeRequest(w, r)
+	if !ok {
+		return
+	}
+	servePull(w, r, bridge, req.ProjectPath)
+}
+
+// handleBridgePush handles POST /api/bridge/push
+func (d *Daemon) handleBridgePush(w http.ResponseWriter, r *http.Request) {
+	req, bridge, ok := d.decodeBridgeRequest(w, r)
+	if !ok {
+		return
+	}
+	servePush(w, r, bridge, req.ProjectPath, req.IssueKey)
+}
+
+// handleBridgeMembers handles POST /api/bridge/members
+func (d *Daemon) handleBridgeMembers(w http.ResponseWriter, r *http.Request) {
+	req, bridge, ok := d.decodeBridgeRequest(w, r)
+	if !ok {
+		return
+	}
+	serveMembers(w, r, bridge, req.ProjectPath)
+}
+
+// handleBridgeWorkflow handles POST /api/bridge/workflow
+func (d *Daemon) handleBridgeWorkflow(w http.ResponseWriter, r *http.Request) {
+	req, bridge, ok := d.decodeBridgeRequest(w, r)
+	if !ok {
+		return
+	}
+	serveWorkflow(w, r, bridge, req.ProjectPath)
+}
+
+// servePull runs a pull through the bridge and writes the result
+func servePull(w http.ResponseWriter, r *http.Request, bridge issue.Bridge, projectPath string) {
+	storage, err := issue.NewStorage(projectPath)
+	if err != nil {
+		writeError(w, "failed to initialize storage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result, err := bridge.Pull(r.Context(), storage)
+	if err != nil {
+		if errors.Is(err, issue.ErrNoRemote) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "pull failed: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, result, http.StatusOK)
+}
+
+// servePush runs a push through the bridge and writes the result.
+// Conflicts are reported as 409 with the push result as the body.
+func servePush(w http.ResponseWriter, r *http.Request, bridge issue.Bridge, projectPath, issueKey string) {
+	// Open storage (read-only check - issues directory must exist)
+	storage, err := issue.OpenStorage(projectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result, err := bridge.Push(r.Context(), storage, issueKey)
+	if err != nil {
+		if result != nil && len(result.Conflicts) > 0 {
+			// Return 409 Conflict with result details
+			writeJSON(w, result, http.StatusConflict)
+			return
+		}
+		if errors.Is(err, issue.ErrNoRemote) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "push failed: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, result, http.StatusOK)
+}
+
+// serveMembers writes the bridge's assignable members
+func serveMembers(w http.ResponseWriter, r *http.Request, bridge issue.Bridge, projectPath string) {
+	members, err := bridge.Members(r.Context(), projectPath)
+	if err != nil {
+		writeError(w, "failed to refresh cache: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, members, http.StatusOK)
+}
+
+// serveWorkflow writes the bridge's statuses in workflow order
+func serveWorkflow(w http.ResponseWriter, r *http.Request, bridge issue.Bridge, projectPath string) {
+	statuses, err := bridge.Workflow(r.Context(), projectPath)
+	if err != nil {
+		writeError(w, "failed to refresh cache: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	// Sort statuses deterministically by category, then name, then ID
+	sortStatuses(statuses)
+
+	writeJSON(w, statuses, http.StatusOK)
+}
+
+// bridgeErrorStatus maps a bridge error to the HTTP status reported to the CLI.
+// Tracker auth and permission failures keep their status so the CLI can point
+// at the credentials; other tracker errors are reported as 502 Bad Gateway.
+func bridgeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, issue.ErrNotFound), jira.IsNotFound(err),
+		errors.Is(err, jira.ErrNoBoard), errors.Is(err, jira.ErrNoSprint):
+		return http.StatusNotFound
+	case jira.IsAuth(err), errors.Is(err, jira.ErrNotLoggedIn):
+		return http.StatusUnauthorized
+	case jira.IsPermission(err):
+		return http.StatusForbidden
+	case jira.IsRateLimited(err):
+		return http.StatusTooManyRequests
+	}
+
+	var apiErr *jira.APIError
+	if errors.As(err, &apiErr) {
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// sortStatuses sorts statuses by category order (To Do → In Progress → Done → Undefined),
+// then by name alphabetically, then by ID for stability
+func sortStatuses(statuses []*issue.StatusInfo) {
+	// Define workflow category order
+	categoryOrder := map[string]int{
+		"new":           0, // To Do
+		"indeterminate": 1, // In Progress
+		"done":          2, // Done
+		"undefined":     3, // Undefined
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		catI := categoryOrder[statuses[i].Category]
+		catJ := categoryOrder[statuses[j].Category]
+
+		// First: sort by category
+		if catI != catJ {
+			return catI < catJ
+		}
+
+		// Second: sort by name within category
+		if statuses[i].Name != statuses[j].Name {
+			return statuses[i].Name < statuses[j].Name
+		}
+
+		// Third: sort by ID for stability (when name and category are equal)
+		return statuses[i].ID < statuses[j].ID
+	})
+}
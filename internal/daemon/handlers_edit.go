@@ -0,0 +1,478 @@
+//go:build unix
+
+package daemon
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/gurisko/takl/internal/limits"
+)
+
+// draftKeyPrefix prefixes the keys of new local-only issues (DRAFT-1, ...);
+// bridges that create issues on push replace the key with the tracker's
+const draftKeyPrefix = "DRAFT"
+
+// Request/Response types
+
+type EditResponse struct {
+	IssueKey string `json:"issue_key"`
+	Markdown string `json:"markdown"`
+	Base     string `json:"base,omitempty"` // Digest of the file as read; sent back on save
+}
+
+type SaveEditRequest struct {
+	ProjectPath string `json:"project_path"`
+	IssueKey    string `json:"issue_key"`
+	Markdown    string `json:"markdown"`
+	Base        string `json:"base,omitempty"`   // Base from GET /api/edit; required unless Create
+	Create      bool   `json:"create,omitempty"` // New issue; the file must not exist
+}
+
+type LintRequest struct {
+	ProjectPath string   `json:"project_path"`
+	Keys        []string `json:"keys,omitempty"` // Issues to lint; all if empty
+	Fix         bool     `json:"fix,omitempty"`
+}
+
+type LintResponse struct {
+	Checked int                 `json:"checked"`
+	Results []*issue.LintResult `json:"results"` // Files with problems or fixes
+}
+
+type MigrateRequest struct {
+	ProjectPath string `json:"project_path"`
+	DryRun      bool   `json:"dry_run,omitempty"` // Report what would be migrated only
+}
+
+type MigrateResponse struct {
+	Checked       int              `json:"checked"`
+	FormatVersion int              `json:"format_version"`
+	Migrated      []MigratedIssue  `json:"migrated"`
+	Failed        []MigrateFailure `json:"failed,omitempty"`
+}
+
+type MigratedIssue struct {
+	Key  string `json:"key"`
+	From int    `json:"from"` // Format version the file had
+}
+
+type MigrateFailure struct {
+	Key   string `json:"key"`
+	Error string `json:"error"`
+}
+
+type TemplatesResponse struct {
+	Templates []*issue.Template `json:"templates"`
+}
+
+// ValidationErrorResponse is returned with 422 when an edited issue is invalid
+type ValidationErrorResponse struct {
+	Error    string          `json:"error"`
+	Problems []issue.Problem `json:"problems"`
+}
+
+// Handler methods
+
+// handleEditIssue handles GET /api/edit
+// Returns the markdown of an issue file for editing, or with new=true the
+// markdown of a new issue (keyed issue_key if given, else the next DRAFT-n)
+// made from the named template, if any (see issue.Template)
+func (d *Daemon) handleEditIssue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	projectPath := query.Get("project_path")
+	issueKey := query.Get("issue_key")
+	if projectPath == "" {
+		writeError(w, "project_path query parameter is required", http.StatusBadRequest)
+		return
+	}
+	create, _ := strconv.ParseBool(query.Get("new"))
+	if !create && issueKey == "" {
+		writeError(w, "issue_key query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	if !create {
+		storage, err := issue.OpenStorage(projectPath)
+		if err != nil {
+			writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		markdown, err := storage.ReadMarkdown(issueKey)
+		if err != nil {
+			if errors.Is(err, issue.ErrNotFound) {
+				writeError(w, "issue not found: "+issueKey, http.StatusNotFound)
+				return
+			}
+			writeError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, EditResponse{IssueKey: issueKey, Markdown: markdown, Base: editBase(markdown)}, http.StatusOK)
+		return
+	}
+
+	storage, err := issue.NewStorage(projectPath)
+	if err != nil {
+		writeError(w, "failed to initialize storage: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if issueKey != "" && !issue.ValidKey(issueKey) {
+		writeError(w, "invalid issue key: "+issueKey, http.StatusBadRequest)
+		return
+	}
+	if issueKey == "" {
+		if issueKey, err = storage.NextDraftKey(draftKeyPrefix); err != nil {
+			writeError(w, "failed to list issues: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else if _, err := storage.ReadMarkdown(issueKey); err == nil {
+		writeError(w, "issue already exists: "+issueKey, http.StatusConflict)
+		return
+	}
+
+	bridgeType := d.bridgeType(projectPath)
+	status := "To Do"
+	if load, ok := workflowLoaders[bridgeType]; ok {
+		if cache, err := load(projectPath); err == nil && len(cache.Statuses) > 0 {
+			statuses := make([]*issue.StatusInfo, 0, len(cache.Statuses))
+			for _, s := range cache.Statuses {
+				statuses = append(statuses, s)
+			}
+			sortStatuses(statuses)
+			status = statuses[0].Name
+		}
+	}
+
+	tmpl := issue.DefaultTemplate(bridgeType, status)
+	if name := query.Get("template"); name != "" {
+		if tmpl, err = issue.LoadTemplate(projectPath, name); err != nil {
+			if errors.Is(err, issue.ErrTemplateNotFound) {
+				writeError(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if tmpl.Bridge != "" && tmpl.Bridge != bridgeType {
+			writeError(w, fmt.Sprintf("template %s is for %s projects", name, tmpl.Bridge), http.StatusBadRequest)
+			return
+		}
+	}
+
+	vars := issue.TemplateVars{
+		Key:   issueKey,
+		Today: time.Now().Format("2006-01-02"),
+		Me:    d.currentUser(projectPath, bridgeType),
+	}
+	markdown, err := tmpl.Instantiate(vars, status)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, EditResponse{IssueKey: issueKey, Markdown: markdown}, http.StatusOK)
+}
+
+// handleListTemplates handles GET /api/templates
+// Lists the project's issue templates, leaving out those bound to other bridges
+func (d *Daemon) handleListTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	projectPath := r.URL.Query().Get("project_path")
+	if projectPath == "" {
+		writeError(w, "project_path query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	templates, err := issue.LoadTemplates(projectPath, d.bridgeType(projectPath))
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, TemplatesResponse{Templates: templates}, http.StatusOK)
+}
+
+// handleSaveEdit handles POST /api/edit
+// Validates edited markdown against the schema and the bridge caches and
+// writes the issue file. Invalid markdown is reported as 422 with the
+// problems, and an issue file that changed since it was read for editing
+// (e.g. by a pull) as 409; nothing is written then.
+func (d *Daemon) handleSaveEdit(w http.ResponseWriter, r *http.Request) {
+	var req SaveEditRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" || req.IssueKey == "" || req.Markdown == "" {
+		writeError(w, "project_path, issue_key and markdown are required", http.StatusBadRequest)
+		return
+	}
+	if !issue.ValidKey(req.IssueKey) {
+		writeError(w, "invalid issue key: "+req.IssueKey, http.StatusBadRequest)
+		return
+	}
+	if !req.Create && req.Base == "" {
+		writeError(w, "base is required", http.StatusBadRequest)
+		return
+	}
+
+	var storage *issue.Storage
+	var err error
+	if req.Create {
+		storage, err = issue.NewStorage(req.ProjectPath)
+	} else {
+		storage, err = issue.OpenStorage(req.ProjectPath)
+	}
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schema, err := d.schemaFor(req.ProjectPath)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	edited, problems := storage.ValidateMarkdown(req.Markdown, schema)
+
+	if edited != nil {
+		if edited.Key != "" && edited.Key != req.IssueKey {
+			problems = append(problems, issue.Problem{Message: fmt.Sprintf("jira_key cannot be changed from %s", req.IssueKey)})
+		}
+
+		if req.Create {
+			if _, err := storage.ReadMarkdown(req.IssueKey); err == nil {
+				writeError(w, "issue already exists: "+req.IssueKey, http.StatusConflict)
+				return
+			}
+			if edited.RemoteID != "" {
+				problems = append(problems, issue.Problem{Message: "jira_id must be empty for a new issue"})
+			}
+			now := time.Now().UTC().Truncate(time.Second)
+			if edited.Created.IsZero() {
+				edited.Created = now
+			}
+			if edited.Updated.IsZero() {
+				edited.Updated = now
+			}
+			edited.Hash = ""
+		} else {
+			current, err := storage.ReadMarkdown(req.IssueKey)
+			if err == nil && editBase(current) != req.Base {
+				writeError(w, req.IssueKey+" changed since it was opened for editing", http.StatusConflict)
+				return
+			}
+			existing, err := storage.ReadIssue(req.IssueKey)
+			if err != nil {
+				if errors.Is(err, issue.ErrNotFound) {
+					writeError(w, "issue not found: "+req.IssueKey, http.StatusNotFound)
+					return
+				}
+				writeError(w, "failed to read issue: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if edited.RemoteID != existing.RemoteID {
+				problems = append(problems, issue.Problem{Message: "jira_id cannot be changed"})
+			}
+			// Keep the base hash so the change is detected as a local edit on push
+			edited.Hash = existing.Hash
+		}
+	}
+
+	if len(problems) > 0 {
+		writeJSON(w, ValidationErrorResponse{
+			Error:    fmt.Sprintf("%s has %d problem(s)", req.IssueKey, len(problems)),
+			Problems: problems,
+		}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := storage.WriteIssue(edited); err != nil {
+		writeError(w, "failed to save issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if req.Create {
+		status = http.StatusCreated
+	}
+	writeJSON(w, ShowIssueResponse{Issue: edited}, status)
+}
+
+// handleLint handles POST /api/lint
+// Checks issue files against the schema and the bridge caches, fixing the
+// problems that can be normalized with fix
+func (d *Daemon) handleLint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req LintRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" {
+		writeError(w, "project_path is required", http.StatusBadRequest)
+		return
+	}
+
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	schema, err := d.schemaFor(req.ProjectPath)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	keys := req.Keys
+	if len(keys) == 0 {
+		if keys, err = storage.ListIssues(); err != nil {
+			writeError(w, "failed to list issues: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	resp := LintResponse{Results: []*issue.LintResult{}}
+	for _, key := range keys {
+		resp.Checked++
+		if !issue.ValidKey(key) {
+			resp.Results = append(resp.Results, &issue.LintResult{
+				Key:      key,
+				Problems: []issue.Problem{{Message: "file name is not a valid issue key"}},
+			})
+			continue
+		}
+		result, err := storage.LintIssue(key, schema, req.Fix)
+		if err != nil {
+			if errors.Is(err, issue.ErrNotFound) {
+				writeError(w, "issue not found: "+key, http.StatusNotFound)
+				return
+			}
+			writeError(w, fmt.Sprintf("failed to lint %s: %v", key, err), http.StatusInternalServerError)
+			return
+		}
+		if len(result.Problems) > 0 || len(result.Fixed) > 0 {
+			resp.Results = append(resp.Results, result)
+		}
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// handleMigrate handles POST /api/migrate
+// Upgrades the issue files of a project to the current format version
+func (d *Daemon) handleMigrate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req MigrateRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" {
+		writeError(w, "project_path is required", http.StatusBadRequest)
+		return
+	}
+
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	keys, err := storage.ListIssues()
+	if err != nil {
+		writeError(w, "failed to list issues: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := MigrateResponse{FormatVersion: issue.FormatVersion, Migrated: []MigratedIssue{}}
+	for _, key := range keys {
+		resp.Checked++
+		var from int
+		if req.DryRun {
+			var content string
+			if content, err = storage.ReadMarkdown(key); err == nil {
+				_, from, err = issue.MigrateMarkdown(content)
+			}
+		} else {
+			from, err = storage.MigrateIssue(key)
+		}
+		if err != nil {
+			resp.Failed = append(resp.Failed, MigrateFailure{Key: key, Error: err.Error()})
+			continue
+		}
+		if from < issue.FormatVersion {
+			resp.Migrated = append(resp.Migrated, MigratedIssue{Key: key, From: from})
+		}
+	}
+
+	writeJSON(w, resp, http.StatusOK)
+}
+
+// schemaFor loads the caches issue files of a project are validated against
+func (d *Daemon) schemaFor(projectPath string) (issue.Schema, error) {
+	bridgeType := d.bridgeType(projectPath)
+	schema := issue.Schema{Bridge: bridgeType}
+	if load, ok := workflowLoaders[bridgeType]; ok {
+		cache, err := load(projectPath)
+		if err != nil {
+			return schema, fmt.Errorf("failed to load workflow cache: %w", err)
+		}
+		schema.Workflow = cache
+	}
+	if load, ok := memberLoaders[bridgeType]; ok {
+		cache, err := load(projectPath)
+		if err != nil {
+			return schema, fmt.Errorf("failed to load member cache: %w", err)
+		}
+		schema.Members = cache
+	}
+	return schema, nil
+}
+
+// currentUser returns the user running takl for templates ({{.Me}}): for Jira,
+// the account email of the project, as a member if cached; otherwise the
+// login name
+func (d *Daemon) currentUser(projectPath, bridgeType string) string {
+	if bridgeType == issue.BridgeJira {
+		if email := jira.AccountEmail(projectPath); email != "" {
+			if members, err := jira.LoadMembersCache(projectPath); err == nil {
+				if member := members.FindByEmail(email); member != nil {
+					return member.FormatMember()
+				}
+			}
+			return email
+		}
+	}
+	return commentAuthor()
+}
+
+// editBase returns the digest of an issue file that a save must be based on
+func editBase(markdown string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(markdown)))
+}
@@ -3,11 +3,17 @@
 package daemon
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
-	"sort"
+	"os/user"
 	"strings"
+	"time"
 
-	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/gurisko/takl/internal/limits"
 )
 
 // Request/Response types
@@ -21,8 +27,8 @@ type ListIssuesRequest struct {
 }
 
 type ListIssuesResponse struct {
-	Issues []*jira.Issue `json:"issues"`
-	Count  int           `json:"count"`
+	Issues []*issue.Issue `json:"issues"`
+	Count  int            `json:"count"`
 }
 
 type ShowIssueRequest struct {
@@ -31,7 +37,23 @@ type ShowIssueRequest struct {
 }
 
 type ShowIssueResponse struct {
-	Issue *jira.Issue `json:"issue"`
+	Issue *issue.Issue `json:"issue"`
+}
+
+// UpdateIssueRequest sets fields of an issue file; nil fields are left alone
+type UpdateIssueRequest struct {
+	ProjectPath string  `json:"project_path"`
+	Status      *string `json:"status,omitempty"`   // Must be a workflow cache status, if the bridge has one
+	Assignee    *string `json:"assignee,omitempty"` // Resolved through the member cache, if any; empty to unassign
+	Comment     string  `json:"comment,omitempty"`  // Appended as a new comment
+}
+
+type LinkIssueRequest struct {
+	ProjectPath string `json:"project_path"`
+	IssueKey    string `json:"issue_key"`
+	Relation    string `json:"relation"` // e.g. "blocks", "is-blocked-by", "relates-to"
+	Target      string `json:"target"`
+	Remove      bool   `json:"remove,omitempty"`
 }
 
 // Handler methods
@@ -53,17 +75,27 @@ func (d *Daemon) handleListIssues(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Open storage (read-only)
-	storage, err := jira.OpenStorage(projectPath)
+	storage, err := issue.OpenStorage(projectPath)
 	if err != nil {
-		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		status := http.StatusBadRequest
+		if errors.Is(err, issue.ErrNoIssuesDir) {
+			status = http.StatusNotFound
+		}
+		writeError(w, "failed to open storage: "+err.Error(), status)
 		return
 	}
 
 	// Build filter from query parameters
-	filter := jira.IssueFilter{
-		Status:   query.Get("status"),
-		Assignee: query.Get("assignee"),
-		Search:   query.Get("search"),
+	filter := issue.IssueFilter{
+		Status:     query.Get("status"),
+		Assignee:   query.Get("assignee"),
+		Search:     query.Get("search"),
+		Type:       query.Get("type"),
+		Priority:   query.Get("priority"),
+		Component:  query.Get("component"),
+		FixVersion: query.Get("fix_version"),
+		Sprint:     query.Get("sprint"),
+		Parent:     query.Get("parent"),
 	}
 
 	// Parse labels (comma-separated) and trim whitespace
@@ -82,13 +114,11 @@ func (d *Daemon) handleListIssues(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Sort by Updated desc, then by JiraKey for stable ordering
-	sort.Slice(issues, func(i, j int) bool {
-		if !issues[i].Updated.Equal(issues[j].Updated) {
-			return issues[i].Updated.After(issues[j].Updated)
-		}
-		return issues[i].JiraKey < issues[j].JiraKey
-	})
+	// Sort by the requested order (Updated desc by default), then by Key for stable ordering
+	if err := issue.SortIssues(issues, query.Get("sort")); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	resp := ListIssuesResponse{
 		Issues: issues,
@@ -121,16 +151,16 @@ func (d *Daemon) handleShowIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Open storage (read-only)
-	storage, err := jira.OpenStorage(projectPath)
+	storage, err := issue.OpenStorage(projectPath)
 	if err != nil {
 		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// Read issue
-	issue, err := storage.ReadIssue(issueKey)
+	found, err := storage.ReadIssue(issueKey)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, issue.ErrNotFound) {
 			writeError(w, "issue not found: "+issueKey, http.StatusNotFound)
 			return
 		}
@@ -139,7 +169,241 @@ func (d *Daemon) handleShowIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := ShowIssueResponse{
-		Issue: issue,
+		Issue: found,
 	}
 	writeJSON(w, resp, http.StatusOK)
 }
+
+// handleUpdateIssue handles POST /api/issues/{key}
+// Changes the status or assignee or adds a comment on the issue file; the
+// changes are sent to the tracker on the next push
+func (d *Daemon) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	issueKey := strings.TrimPrefix(r.URL.Path, "/api/issues/")
+	if issueKey == "" || issueKey == r.URL.Path {
+		writeError(w, "issue key is required", http.StatusBadRequest)
+		return
+	}
+	if !issue.ValidKey(issueKey) {
+		writeError(w, "invalid issue key: "+issueKey, http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateIssueRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" {
+		writeError(w, "project_path is required", http.StatusBadRequest)
+		return
+	}
+	comment := strings.TrimSpace(req.Comment)
+	if req.Status == nil && req.Assignee == nil && comment == "" {
+		writeError(w, "nothing to update: set status, assignee or comment", http.StatusBadRequest)
+		return
+	}
+
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	found, err := storage.ReadIssue(issueKey)
+	if err != nil {
+		if errors.Is(err, issue.ErrNotFound) {
+			writeError(w, "issue not found: "+issueKey, http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to read issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bridgeType := d.bridgeType(req.ProjectPath)
+	if req.Status != nil {
+		status, err := resolveStatus(bridgeType, req.ProjectPath, *req.Status)
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		found.Status = status
+	}
+	if req.Assignee != nil {
+		assignee, err := resolveAssignee(bridgeType, req.ProjectPath, *req.Assignee)
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// GitHub and GitLab issues keep their assignees as a list of logins
+		switch {
+		case bridgeType != issue.BridgeGitHub && bridgeType != issue.BridgeGitLab:
+			found.Assignee = assignee
+		case assignee == "":
+			found.Assignees = nil
+		default:
+			found.Assignees = []string{assignee}
+		}
+	}
+	if comment != "" {
+		found.Comments = append(found.Comments, issue.Comment{
+			Author:  commentAuthor(),
+			Body:    comment,
+			Created: time.Now().Truncate(time.Second),
+		})
+	}
+
+	// Keep the base hash so the change is detected as a local edit on push
+	if err := storage.WriteIssue(found); err != nil {
+		writeError(w, "failed to save issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, ShowIssueResponse{Issue: found}, http.StatusOK)
+}
+
+// resolveStatus returns the workflow cache name of status (case-insensitive).
+// Any non-empty status is accepted for bridges without a cached workflow.
+func resolveStatus(bridgeType, projectPath, status string) (string, error) {
+	status = strings.TrimSpace(status)
+	if status == "" {
+		return "", errors.New("status cannot be empty")
+	}
+	load, ok := workflowLoaders[bridgeType]
+	if !ok {
+		return status, nil
+	}
+	cache, err := load(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load workflow cache: %w", err)
+	}
+	if len(cache.Statuses) == 0 {
+		return status, nil
+	}
+
+	resolved, err := cache.ResolveStatus(status)
+	if err != nil {
+		return "", err
+	}
+	return resolved.Name, nil
+}
+
+// resolveAssignee returns the canonical form of assignee from the member
+// cache ("Display Name <email>"). Any name is accepted for bridges without a
+// cached member list; empty unassigns.
+func resolveAssignee(bridgeType, projectPath, assignee string) (string, error) {
+	assignee = strings.TrimSpace(assignee)
+	load, ok := memberLoaders[bridgeType]
+	if assignee == "" || !ok {
+		return assignee, nil
+	}
+	cache, err := load(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load member cache: %w", err)
+	}
+	if len(cache.Members) == 0 {
+		return assignee, nil
+	}
+	member, err := cache.ParseUser(assignee)
+	if err != nil {
+		return "", err
+	}
+	return member.FormatMember(), nil
+}
+
+// commentAuthor names the author of comments added locally; the tracker
+// records the real author when the comment is pushed
+func commentAuthor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "me"
+}
+
+// handleLinkIssue handles POST /api/links
+// Adds (or removes) a link on the issue file; the link is created remotely on the next push
+func (d *Daemon) handleLinkIssue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req LinkIssueRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" || req.IssueKey == "" || req.Target == "" {
+		writeError(w, "project_path, issue_key and target are required", http.StatusBadRequest)
+		return
+	}
+	for _, key := range []string{req.IssueKey, req.Target} {
+		if !issue.ValidKey(key) {
+			writeError(w, "invalid issue key: "+key, http.StatusBadRequest)
+			return
+		}
+	}
+	if strings.EqualFold(req.IssueKey, req.Target) {
+		writeError(w, "cannot link an issue to itself", http.StatusBadRequest)
+		return
+	}
+
+	linkType, direction, err := issue.ParseRelation(req.Relation)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	link := issue.Link{Type: linkType, Direction: direction, Key: req.Target}
+
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	found, err := storage.ReadIssue(req.IssueKey)
+	if err != nil {
+		if errors.Is(err, issue.ErrNotFound) {
+			writeError(w, "issue not found: "+req.IssueKey, http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to read issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if req.Remove {
+		kept := found.Links[:0]
+		for _, l := range found.Links {
+			if !issue.SameLink(l, link) {
+				kept = append(kept, l)
+			}
+		}
+		if len(kept) == len(found.Links) {
+			writeError(w, "no such link on "+req.IssueKey, http.StatusNotFound)
+			return
+		}
+		found.Links = kept
+	} else {
+		if found.HasLink(link) {
+			writeError(w, "link already exists", http.StatusConflict)
+			return
+		}
+		found.Links = append(found.Links, link)
+	}
+
+	// Keep the base hash so the change is detected as a local edit on push
+	if err := storage.WriteIssue(found); err != nil {
+		writeError(w, "failed to save issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, ShowIssueResponse{Issue: found}, http.StatusOK)
+}
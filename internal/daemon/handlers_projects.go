@@ -10,14 +10,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gurisko/takl/internal/issue"
 	"github.com/gurisko/takl/internal/registry"
 )
 
 // Request/Response types
 
 type RegisterProjectRequest struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Bridge string `json:"bridge,omitempty"`
 }
 
 type RegisterProjectResponse struct {
@@ -58,12 +60,17 @@ func (d *Daemon) handleRegisterProject(w http.ResponseWriter, r *http.Request) {
 		writeError(w, "path is required", http.StatusBadRequest)
 		return
 	}
+	if req.Bridge != "" && !validBridge(req.Bridge) {
+		writeError(w, fmt.Sprintf("unknown bridge %q", req.Bridge), http.StatusBadRequest)
+		return
+	}
 
 	// Create project
 	project := &registry.Project{
 		Name:         req.Name,
 		Path:         req.Path,
 		RegisteredAt: time.Now().UTC(),
+		Bridge:       req.Bridge,
 	}
 
 	// Register and save atomically
@@ -80,6 +87,14 @@ func (d *Daemon) handleRegisterProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Local projects never pull, so create their issue storage up front
+	if project.Bridge == issue.BridgeLocal {
+		if _, err := issue.NewStorage(project.Path); err != nil {
+			writeError(w, fmt.Sprintf("failed to initialize storage: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	// Return response with Location header
 	resp := RegisterProjectResponse{
 		Project: project,
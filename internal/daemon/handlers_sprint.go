@@ -0,0 +1,160 @@
+//go:build unix
+
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gurisko/takl/internal/bridge/jira"
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/gurisko/takl/internal/limits"
+)
+
+// Request/Response types
+
+type SprintMoveRequest struct {
+	ProjectPath string   `json:"project_path"`
+	IssueKeys   []string `json:"issue_keys"`
+	Target      string   `json:"target"` // active, next, backlog, or a sprint name or ID
+}
+
+type SprintRankRequest struct {
+	ProjectPath string   `json:"project_path"`
+	IssueKeys   []string `json:"issue_keys"`
+	Before      string   `json:"before,omitempty"` // Rank before this issue
+	After       string   `json:"after,omitempty"`  // Rank after this issue
+}
+
+type SprintResponse struct {
+	Sprint  *jira.Sprint         `json:"sprint"`
+	Columns []*issue.BoardColumn `json:"columns"` // Issues by status category
+}
+
+type SprintMoveResponse struct {
+	Sprint *jira.Sprint `json:"sprint"` // Null when moved to the backlog
+}
+
+// Handler methods
+
+// handleJiraSprint handles POST /api/jira/sprint
+// Returns the board's active sprint with its local issues grouped by status
+// category, in rank order
+func (d *Daemon) handleJiraSprint(w http.ResponseWriter, r *http.Request) {
+	req, bridge, ok := decodeJiraRequest(w, r)
+	if !ok {
+		return
+	}
+
+	sprint, err := bridge.ActiveSprint(r.Context())
+	if err != nil {
+		writeError(w, "failed to fetch active sprint: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	issues, err := storage.ListFilteredIssues(issue.IssueFilter{Sprint: sprint.Name})
+	if err != nil {
+		writeError(w, "failed to list issues: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := issue.SortIssues(issues, issue.SortRank); err != nil {
+		writeError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	workflow, err := jira.LoadWorkflowCache(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to load workflow cache: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, SprintResponse{Sprint: sprint, Columns: issue.BoardColumns(issues, workflow, false)}, http.StatusOK)
+}
+
+// handleJiraSprintMove handles POST /api/jira/sprint/move
+// Moves issues to a sprint or the backlog in Jira and records it in the issue files
+func (d *Daemon) handleJiraSprintMove(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req SprintMoveRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" || len(req.IssueKeys) == 0 || req.Target == "" {
+		writeError(w, "project_path, issue_keys and target are required", http.StatusBadRequest)
+		return
+	}
+
+	bridge, ok := loadJiraBridge(w, req.ProjectPath)
+	if !ok {
+		return
+	}
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sprint, err := bridge.MoveToSprint(r.Context(), storage, req.IssueKeys, req.Target)
+	if err != nil {
+		writeError(w, "failed to move issues: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, SprintMoveResponse{Sprint: sprint}, http.StatusOK)
+}
+
+// handleJiraSprintRank handles POST /api/jira/sprint/rank
+// Ranks issues before or after another issue and records their new rank
+func (d *Daemon) handleJiraSprintRank(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req SprintRankRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" || len(req.IssueKeys) == 0 {
+		writeError(w, "project_path and issue_keys are required", http.StatusBadRequest)
+		return
+	}
+	if (req.Before == "") == (req.After == "") {
+		writeError(w, "exactly one of before and after is required", http.StatusBadRequest)
+		return
+	}
+
+	bridge, ok := loadJiraBridge(w, req.ProjectPath)
+	if !ok {
+		return
+	}
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	issues, err := bridge.Rank(r.Context(), storage, req.IssueKeys, req.Before, req.After)
+	if err != nil {
+		writeError(w, "failed to rank issues: "+err.Error(), bridgeErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, ListIssuesResponse{Issues: issues, Count: len(issues)}, http.StatusOK)
+}
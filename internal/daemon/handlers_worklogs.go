@@ -0,0 +1,169 @@
+//go:build unix
+
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gurisko/takl/internal/issue"
+	"github.com/gurisko/takl/internal/limits"
+)
+
+// Request/Response types
+
+type LogWorkRequest struct {
+	ProjectPath string    `json:"project_path"`
+	IssueKey    string    `json:"issue_key"`
+	TimeSpent   string    `json:"time_spent"` // Work duration, e.g. "1h30m"
+	Comment     string    `json:"comment,omitempty"`
+	Started     time.Time `json:"started,omitempty"` // Defaults to now
+}
+
+// TimesheetEntry is a worklog with the issue and project it was logged on
+type TimesheetEntry struct {
+	Project  string `json:"project"` // Registered project name
+	IssueKey string `json:"issue_key"`
+	Title    string `json:"title"`
+	issue.Worklog
+}
+
+type TimesheetResponse struct {
+	Entries      []TimesheetEntry `json:"entries"`
+	TotalSeconds int              `json:"total_seconds"`
+}
+
+// Handler methods
+
+// handleLogWork handles POST /api/worklogs
+// Appends a worklog to the issue file; it is logged remotely on the next push
+func (d *Daemon) handleLogWork(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req LogWorkRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, limits.JSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ProjectPath == "" || req.IssueKey == "" || req.TimeSpent == "" {
+		writeError(w, "project_path, issue_key and time_spent are required", http.StatusBadRequest)
+		return
+	}
+
+	seconds, err := issue.ParseWorkDuration(req.TimeSpent)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	started := req.Started
+	if started.IsZero() {
+		started = time.Now()
+	}
+
+	storage, err := issue.OpenStorage(req.ProjectPath)
+	if err != nil {
+		writeError(w, "failed to open storage: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	found, err := storage.ReadIssue(req.IssueKey)
+	if err != nil {
+		if errors.Is(err, issue.ErrNotFound) {
+			writeError(w, "issue not found: "+req.IssueKey, http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to read issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Minute precision, as stored in markdown and accepted by Jira
+	found.Worklogs = append(found.Worklogs, issue.Worklog{
+		Started:   started.Truncate(time.Minute),
+		TimeSpent: seconds,
+		Comment:   strings.TrimSpace(req.Comment),
+	})
+
+	// Keep the base hash so the change is detected as a local edit on push
+	if err := storage.WriteIssue(found); err != nil {
+		writeError(w, "failed to save issue: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, ShowIssueResponse{Issue: found}, http.StatusOK)
+}
+
+// handleTimesheet handles GET /api/timesheet
+// Collects the worklogs started in [since, until) across all registered
+// projects. Query parameters: since and until (RFC3339, until optional) and
+// author (case-insensitive substring, optional).
+func (d *Daemon) handleTimesheet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	since, err := time.Parse(time.RFC3339, query.Get("since"))
+	if err != nil {
+		writeError(w, "since query parameter must be an RFC3339 timestamp", http.StatusBadRequest)
+		return
+	}
+	var until time.Time
+	if v := query.Get("until"); v != "" {
+		if until, err = time.Parse(time.RFC3339, v); err != nil {
+			writeError(w, "until query parameter must be an RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+	}
+	author := strings.ToLower(query.Get("author"))
+
+	resp := TimesheetResponse{Entries: make([]TimesheetEntry, 0)}
+	for _, project := range d.registry.List() {
+		// Projects that were never pulled have no issues yet
+		storage, err := issue.OpenStorage(project.Path)
+		if err != nil {
+			log.Printf("[DEBUG] handleTimesheet: Skipping %s: %v", project.Name, err)
+			continue
+		}
+		issues, err := storage.ListAllIssues()
+		if err != nil {
+			writeError(w, "failed to list issues of "+project.Name+": "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for _, i := range issues {
+			for _, wl := range i.Worklogs {
+				if wl.Started.Before(since) || !until.IsZero() && !wl.Started.Before(until) {
+					continue
+				}
+				if author != "" && !strings.Contains(strings.ToLower(wl.Author), author) {
+					continue
+				}
+				resp.Entries = append(resp.Entries, TimesheetEntry{
+					Project:  project.Name,
+					IssueKey: i.Key,
+					Title:    i.Title,
+					Worklog:  wl,
+				})
+				resp.TotalSeconds += wl.TimeSpent
+			}
+		}
+	}
+
+	sort.SliceStable(resp.Entries, func(i, j int) bool {
+		return resp.Entries[i].Started.Before(resp.Entries[j].Started)
+	})
+
+	writeJSON(w, resp, http.StatusOK)
+}
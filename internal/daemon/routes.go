@@ -26,15 +26,57 @@ func (d *Daemon) setupRoutes(mux *http.ServeMux) {
 	})
 	mux.HandleFunc("/api/projects/", d.handleProjectByID)
 
+	// Generic bridge endpoints (bridge chosen per project)
+	mux.HandleFunc("/api/bridge/pull", d.handleBridgePull)
+	mux.HandleFunc("/api/bridge/push", d.handleBridgePush)
+	mux.HandleFunc("/api/bridge/members", d.handleBridgeMembers)
+	mux.HandleFunc("/api/bridge/workflow", d.handleBridgeWorkflow)
+
 	// Jira bridge endpoints
 	mux.HandleFunc("/api/jira/pull", d.handleJiraPull)
 	mux.HandleFunc("/api/jira/push", d.handleJiraPush)
 	mux.HandleFunc("/api/jira/members", d.handleJiraMembers)
 	mux.HandleFunc("/api/jira/workflow", d.handleJiraWorkflow)
+	mux.HandleFunc("/api/jira/fields", d.handleJiraFields)
+	mux.HandleFunc("/api/jira/sprint", d.handleJiraSprint)
+	mux.HandleFunc("/api/jira/sprint/move", d.handleJiraSprintMove)
+	mux.HandleFunc("/api/jira/sprint/rank", d.handleJiraSprintRank)
 
 	// Issue browsing endpoints
 	mux.HandleFunc("/api/issues", d.handleListIssues)
-	mux.HandleFunc("/api/issues/", d.handleShowIssue)
+	mux.HandleFunc("/api/issues/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			d.handleShowIssue(w, r)
+		case http.MethodPost:
+			d.handleUpdateIssue(w, r)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/links", d.handleLinkIssue)
+	mux.HandleFunc("/api/edit", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			d.handleEditIssue(w, r)
+		case http.MethodPost:
+			d.handleSaveEdit(w, r)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/lint", d.handleLint)
+	mux.HandleFunc("/api/migrate", d.handleMigrate)
+	mux.HandleFunc("/api/templates", d.handleListTemplates)
+	mux.HandleFunc("/api/workflow", d.handleWorkflow)
+	mux.HandleFunc("/api/members", d.handleMembers)
+	mux.HandleFunc("/api/changes", d.handleChanges)
+
+	// Time tracking endpoints
+	mux.HandleFunc("/api/worklogs", d.handleLogWork)
+	mux.HandleFunc("/api/timesheet", d.handleTimesheet)
 }
 
 func (d *Daemon) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,163 @@
+package issue
+
+import (
+	"sort"
+	"strings"
+)
+
+// Status categories of the workflow cache, in board order
+const (
+	CategoryNew           = "new"           // To Do
+	CategoryIndeterminate = "indeterminate" // In Progress
+	CategoryDone          = "done"          // Done
+	CategoryUndefined     = "undefined"     // Statuses the workflow does not know
+)
+
+// categoryNames are the column names of the categories, in board order
+var categoryNames = []struct{ category, name string }{
+	{CategoryNew, "To Do"},
+	{CategoryIndeterminate, "In Progress"},
+	{CategoryDone, "Done"},
+	{CategoryUndefined, "Other"},
+}
+
+// fallbackCategories categorize common status names missing from the workflow
+// cache, e.g. GitHub and GitLab states, which have no workflow
+var fallbackCategories = map[string]string{
+	"open": CategoryNew, "opened": CategoryNew, "to do": CategoryNew, "todo": CategoryNew, "backlog": CategoryNew,
+	"in progress": CategoryIndeterminate, "started": CategoryIndeterminate,
+	"closed": CategoryDone, "done": CategoryDone, "completed": CategoryDone, "resolved": CategoryDone,
+}
+
+// StatusCategory returns the category of a status by name (case-insensitive),
+// from the workflow cache or else from common status names
+func StatusCategory(workflow *WorkflowCache, status string) string {
+	if workflow != nil {
+		for _, s := range workflow.Statuses {
+			if strings.EqualFold(s.Name, status) && s.Category != "" {
+				return s.Category
+			}
+		}
+	}
+	if category, ok := fallbackCategories[strings.ToLower(strings.TrimSpace(status))]; ok {
+		return category
+	}
+	return CategoryUndefined
+}
+
+// BoardColumn is a column of a board: a status category, or a single status
+type BoardColumn struct {
+	Name     string   `json:"name"`     // Category name (To Do, In Progress, ...) or status name
+	Category string   `json:"category"` // new, indeterminate, done or undefined
+	Issues   []*Issue `json:"issues"`
+}
+
+// BoardLane is a swimlane of a board: the columns of one assignee's issues
+type BoardLane struct {
+	Assignee string         `json:"assignee"` // Empty for unassigned issues
+	Columns  []*BoardColumn `json:"columns"`
+}
+
+// BoardColumns groups issues into columns, keeping their order. By default
+// there is a column per category (To Do, In Progress and Done, plus Other for
+// undefined statuses if any); byStatus makes a column per status in use,
+// ordered by category and then name.
+func BoardColumns(issues []*Issue, workflow *WorkflowCache, byStatus bool) []*BoardColumn {
+	columns := boardLayout(issues, workflow, byStatus)
+	fillColumns(columns, issues, workflow, byStatus)
+	return columns
+}
+
+// BoardLanes groups issues into a swimlane per assignee, ordered by name with
+// unassigned issues last. All lanes have the same columns (see BoardColumns),
+// so they line up.
+func BoardLanes(issues []*Issue, workflow *WorkflowCache, byStatus bool) []*BoardLane {
+	layout := boardLayout(issues, workflow, byStatus)
+
+	byAssignee := make(map[string][]*Issue)
+	var assignees []string
+	for _, i := range issues {
+		a := boardAssignee(i)
+		if _, ok := byAssignee[a]; !ok {
+			assignees = append(assignees, a)
+		}
+		byAssignee[a] = append(byAssignee[a], i)
+	}
+	sort.Slice(assignees, func(i, j int) bool {
+		if assignees[i] == "" || assignees[j] == "" {
+			return assignees[j] == ""
+		}
+		return strings.ToLower(assignees[i]) < strings.ToLower(assignees[j])
+	})
+
+	lanes := make([]*BoardLane, 0, len(assignees))
+	for _, a := range assignees {
+		columns := make([]*BoardColumn, len(layout))
+		for n, c := range layout {
+			columns[n] = &BoardColumn{Name: c.Name, Category: c.Category, Issues: make([]*Issue, 0)}
+		}
+		fillColumns(columns, byAssignee[a], workflow, byStatus)
+		lanes = append(lanes, &BoardLane{Assignee: a, Columns: columns})
+	}
+	return lanes
+}
+
+// boardLayout returns the empty columns of a board of issues
+func boardLayout(issues []*Issue, workflow *WorkflowCache, byStatus bool) []*BoardColumn {
+	var columns []*BoardColumn
+	if !byStatus {
+		for _, c := range categoryNames {
+			columns = append(columns, &BoardColumn{Name: c.name, Category: c.category, Issues: make([]*Issue, 0)})
+		}
+		// Other only when needed
+		for _, i := range issues {
+			if StatusCategory(workflow, i.Status) == CategoryUndefined {
+				return columns
+			}
+		}
+		return columns[:len(columns)-1]
+	}
+
+	seen := make(map[string]bool)
+	for _, i := range issues {
+		name := strings.ToLower(i.Status)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		columns = append(columns, &BoardColumn{Name: i.Status, Category: StatusCategory(workflow, i.Status), Issues: make([]*Issue, 0)})
+	}
+
+	order := make(map[string]int)
+	for n, c := range categoryNames {
+		order[c.category] = n
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		if columns[i].Category != columns[j].Category {
+			return order[columns[i].Category] < order[columns[j].Category]
+		}
+		return columns[i].Name < columns[j].Name
+	})
+	return columns
+}
+
+// fillColumns appends each issue to its column in layout
+func fillColumns(columns []*BoardColumn, issues []*Issue, workflow *WorkflowCache, byStatus bool) {
+	for _, i := range issues {
+		category := StatusCategory(workflow, i.Status)
+		for _, c := range columns {
+			if byStatus && strings.EqualFold(c.Name, i.Status) || !byStatus && c.Category == category {
+				c.Issues = append(c.Issues, i)
+				break
+			}
+		}
+	}
+}
+
+// boardAssignee returns the assignee of an issue for swimlanes
+func boardAssignee(i *Issue) string {
+	if i.Assignee != "" {
+		return i.Assignee
+	}
+	return strings.Join(i.Assignees, ", ")
+}
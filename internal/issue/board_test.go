@@ -0,0 +1,99 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+)
+
+// columnKeys returns the columns of a board as "Name: KEY,KEY; ..."
+func columnKeys(columns []*BoardColumn) string {
+	out := make([]string, 0, len(columns))
+	for _, c := range columns {
+		out = append(out, c.Name+": "+keys(c.Issues))
+	}
+	return strings.Join(out, "; ")
+}
+
+func boardFixture() ([]*Issue, *WorkflowCache) {
+	workflow := NewWorkflowCache()
+	workflow.AddStatus(&StatusInfo{ID: "1", Name: "Backlog", Category: CategoryNew})
+	workflow.AddStatus(&StatusInfo{ID: "2", Name: "In Review", Category: CategoryIndeterminate})
+	workflow.AddStatus(&StatusInfo{ID: "3", Name: "Building", Category: CategoryIndeterminate})
+	workflow.AddStatus(&StatusInfo{ID: "4", Name: "Shipped", Category: CategoryDone})
+
+	issues := []*Issue{
+		{Key: "P-1", Status: "Building", Assignee: "Ann"},
+		{Key: "P-2", Status: "backlog", Assignee: "bob"},
+		{Key: "P-3", Status: "In Review"},
+		{Key: "P-4", Status: "Shipped", Assignee: "Ann"},
+		{Key: "P-5", Status: "Closed", Assignees: []string{"carol"}},
+	}
+	return issues, workflow
+}
+
+// TestStatusCategory tests workflow lookups and the fallback for common names
+func TestStatusCategory(t *testing.T) {
+	_, workflow := boardFixture()
+
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"In Review", CategoryIndeterminate},
+		{"in review", CategoryIndeterminate},
+		{"open", CategoryNew},
+		{"Closed", CategoryDone},
+		{"Blocked", CategoryUndefined},
+	}
+	for _, tt := range tests {
+		if got := StatusCategory(workflow, tt.status); got != tt.want {
+			t.Errorf("StatusCategory(%q): expected %q, got %q", tt.status, tt.want, got)
+		}
+	}
+	if got := StatusCategory(nil, "opened"); got != CategoryNew {
+		t.Errorf("Expected fallback without a workflow, got %q", got)
+	}
+}
+
+// TestBoardColumns tests category and per-status columns
+func TestBoardColumns(t *testing.T) {
+	issues, workflow := boardFixture()
+
+	want := "To Do: P-2; In Progress: P-1,P-3; Done: P-4,P-5"
+	if got := columnKeys(BoardColumns(issues, workflow, false)); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	want = "backlog: P-2; Building: P-1; In Review: P-3; Closed: P-5; Shipped: P-4"
+	if got := columnKeys(BoardColumns(issues, workflow, true)); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Other appears only for undefined statuses
+	issues = append(issues, &Issue{Key: "P-6", Status: "Blocked"})
+	want = "To Do: P-2; In Progress: P-1,P-3; Done: P-4,P-5; Other: P-6"
+	if got := columnKeys(BoardColumns(issues, workflow, false)); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestBoardLanes tests that swimlanes are sorted by assignee, unassigned last,
+// and share the board's columns
+func TestBoardLanes(t *testing.T) {
+	issues, workflow := boardFixture()
+
+	lanes := BoardLanes(issues, workflow, false)
+	var got []string
+	for _, lane := range lanes {
+		got = append(got, lane.Assignee+" | "+columnKeys(lane.Columns))
+	}
+	want := []string{
+		"Ann | To Do: ; In Progress: P-1; Done: P-4",
+		"bob | To Do: P-2; In Progress: ; Done: ",
+		"carol | To Do: ; In Progress: ; Done: P-5",
+		" | To Do: ; In Progress: P-3; Done: ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected lanes:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
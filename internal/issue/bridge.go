@@ -0,0 +1,71 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bridge types as stored in the project registry
+const (
+	BridgeJira   = "jira"
+	BridgeGitHub = "github"
+	BridgeGitLab = "gitlab"
+	BridgeLinear = "linear"
+	BridgeLocal  = "local"
+)
+
+// Bridge synchronizes local issue storage with a remote tracker
+type Bridge interface {
+	// Pull fetches remote issues and saves them to storage
+	Pull(ctx context.Context, storage *Storage) (*PullResult, error)
+
+	// Push pushes local changes to the remote with strict conflict detection.
+	// If key is non-empty, only that issue is pushed.
+	Push(ctx context.Context, storage *Storage, key string) (*PushResult, error)
+
+	// Members returns the users that can be assigned issues
+	Members(ctx context.Context, projectPath string) ([]*Member, error)
+
+	// Workflow returns the statuses an issue can be in
+	Workflow(ctx context.Context, projectPath string) ([]*StatusInfo, error)
+}
+
+// PullResult represents the result of pulling issues
+type PullResult struct {
+	Fetched int      `json:"fetched"`
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Deleted int      `json:"deleted"`
+	Kept    []string `json:"kept,omitempty"` // Out of scope but kept for their unpushed local edits
+	Errors  []string `json:"errors"`
+}
+
+// PushResult represents the result of pushing local changes to the remote
+type PushResult struct {
+	Scanned   int            `json:"scanned"`   // Total local issues scanned
+	Pushed    int            `json:"pushed"`    // Successfully pushed to the remote
+	Created   int            `json:"created"`   // Local-only issues created on the remote
+	Skipped   int            `json:"skipped"`   // No local changes
+	Conflicts []ConflictInfo `json:"conflicts"` // Issues with conflicts
+	Errors    []string       `json:"errors"`    // Other errors
+}
+
+// ConflictInfo describes a conflict for a specific issue
+type ConflictInfo struct {
+	IssueKey string    `json:"issue_key"`
+	Updated  time.Time `json:"updated"` // When the remote was last updated
+}
+
+// FormatConflictError formats conflict information into a user-friendly error message
+func FormatConflictError(conflicts []ConflictInfo) string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("Cannot push - %d issue(s) have conflicts:\n", len(conflicts)))
+	for _, c := range conflicts {
+		buf.WriteString(fmt.Sprintf("  - %s: Remote modified (last updated: %s)\n",
+			c.IssueKey, c.Updated.Format("2006-01-02 15:04")))
+	}
+	buf.WriteString("\nRun 'takl pull' to fetch remote changes, then push again.")
+	return buf.String()
+}
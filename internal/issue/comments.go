@@ -0,0 +1,270 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Comments are fenced by HTML comment markers, which carry their metadata and
+// are hidden when the file is rendered:
+//
+//	<!-- takl:comment id="10001" author="Ann Lee <ann@example.com>" author_id="5b10ac" created="2025-03-02T10:00:00Z" -->
+//	## Comment by Ann Lee <ann@example.com> at 2025-03-02T10:00:00Z
+//
+//	The body, which may contain anything, including headings.
+//
+//	<!-- takl:end-comment -->
+//
+// The heading is for reading only; the marker is authoritative. Body lines
+// that start with a marker prefix are escaped with a backslash. Comments
+// without markers, as in format version 2 files or added by hand, are read
+// from their heading.
+const (
+	commentStartPrefix = "<!-- takl:comment "
+	commentEndMarker   = "<!-- takl:end-comment -->"
+	commentHeading     = "## Comment by "
+)
+
+// markerLineRegex matches body lines that would be read as markers, with any
+// backslashes already escaping them
+var markerLineRegex = regexp.MustCompile(`^\\*<!-- takl:`)
+
+// markerAttrRegex matches one key="value" attribute of a comment marker, the
+// value quoted as a Go string
+var markerAttrRegex = regexp.MustCompile(`^\s*([a-z_]+)=("(?:[^"\\]|\\.)*")`)
+
+// writeComment writes a comment with its markers
+func writeComment(buf *strings.Builder, c Comment) {
+	buf.WriteString(commentStartPrefix)
+	for _, attr := range []struct{ key, value string }{
+		{"id", c.ID},
+		{"author", c.Author},
+		{"author_id", c.AuthorID},
+		{"created", formatMarkerTime(c.Created)},
+		{"updated", formatMarkerTime(c.Updated)},
+	} {
+		if attr.value != "" || attr.key == "author" {
+			fmt.Fprintf(buf, "%s=%s ", attr.key, quoteMarkerValue(attr.value))
+		}
+	}
+	buf.WriteString("-->\n")
+
+	fmt.Fprintf(buf, "%s%s at %s\n\n", commentHeading, strings.Join(strings.Fields(c.Author), " "), c.Created.Format(time.RFC3339))
+	for _, line := range strings.Split(c.Body, "\n") {
+		if markerLineRegex.MatchString(line) {
+			line = `\` + line
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("\n" + commentEndMarker + "\n\n")
+}
+
+// parseComments parses the comments section
+func (s *Storage) parseComments(issue *Issue, content string) {
+	lines := strings.Split(content, "\n")
+	var unfenced []string
+	for i := 0; i < len(lines); i++ {
+		if isCommentStart(lines[i]) {
+			if end := commentEnd(lines, i+1); end != -1 {
+				issue.Comments = append(issue.Comments, parseLegacyComments(strings.Join(unfenced, "\n"))...)
+				unfenced = nil
+				if c, err := parseFencedComment(lines[i], lines[i+1:end]); err == nil {
+					issue.Comments = append(issue.Comments, c)
+				}
+				i = end
+				continue
+			}
+		}
+		unfenced = append(unfenced, lines[i])
+	}
+	issue.Comments = append(issue.Comments, parseLegacyComments(strings.Join(unfenced, "\n"))...)
+}
+
+// parseFencedComment parses a comment from its start marker and the lines up
+// to its end marker
+func parseFencedComment(marker string, lines []string) (Comment, error) {
+	attrs, err := parseMarkerAttrs(marker)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	// The heading fills in what a hand-written marker leaves out
+	var heading Comment
+	if len(lines) > 0 && strings.HasPrefix(lines[0], commentHeading) {
+		heading, _ = parseCommentHeading(lines[0][len(commentHeading):])
+		lines = lines[1:]
+	}
+	c := Comment{ID: attrs["id"], AuthorID: attrs["author_id"], Author: heading.Author, Created: heading.Created}
+	if author, ok := attrs["author"]; ok {
+		c.Author = author
+	}
+	if created, ok := attrs["created"]; ok {
+		if c.Created, err = time.Parse(time.RFC3339Nano, created); err != nil {
+			return Comment{}, fmt.Errorf("invalid created time %q", created)
+		}
+	}
+	if updated, ok := attrs["updated"]; ok {
+		if c.Updated, err = time.Parse(time.RFC3339Nano, updated); err != nil {
+			return Comment{}, fmt.Errorf("invalid updated time %q", updated)
+		}
+	}
+
+	// The body is set off by one blank line on each side
+	if len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	body := make([]string, len(lines))
+	for n, line := range lines {
+		if strings.HasPrefix(line, `\`) && markerLineRegex.MatchString(line) {
+			line = line[1:]
+		}
+		body[n] = line
+	}
+	c.Body = strings.Join(body, "\n")
+	return c, nil
+}
+
+// parseLegacyComments parses comments without markers, each under a
+// "## Comment by <author> at <RFC 3339>" heading
+func parseLegacyComments(content string) []Comment {
+	var comments []Comment
+
+	// Split by ## Comment markers (prefix a newline so the first marker splits too)
+	for _, cs := range strings.Split("\n"+strings.TrimSpace(content), "\n"+commentHeading) {
+		cs = strings.TrimSpace(cs)
+		if cs == "" {
+			continue
+		}
+
+		header, body, ok := strings.Cut(cs, "\n")
+		if !ok {
+			continue
+		}
+		c, ok := parseCommentHeading(header)
+		if !ok {
+			continue
+		}
+		c.Body = strings.TrimSpace(body)
+		comments = append(comments, c)
+	}
+	return comments
+}
+
+// parseCommentHeading parses "<author> at <timestamp>", the timestamp left
+// zero if it is not RFC 3339
+func parseCommentHeading(header string) (Comment, bool) {
+	author, timestamp, ok := strings.Cut(header, " at ")
+	if !ok {
+		return Comment{}, false
+	}
+	created, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		created = time.Time{}
+	}
+	return Comment{Author: author, Created: created}, true
+}
+
+// isCommentStart reports whether line is a comment start marker
+func isCommentStart(line string) bool {
+	return strings.HasPrefix(line, commentStartPrefix) && strings.HasSuffix(line, "-->")
+}
+
+// commentEnd returns the index of the first end marker in lines from start,
+// or -1 if there is none
+func commentEnd(lines []string, start int) int {
+	for i := start; i < len(lines); i++ {
+		if lines[i] == commentEndMarker {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseMarkerAttrs parses the attributes of a comment start marker
+func parseMarkerAttrs(marker string) (map[string]string, error) {
+	rest := strings.TrimSuffix(strings.TrimPrefix(marker, commentStartPrefix), "-->")
+	attrs := make(map[string]string)
+	for strings.TrimSpace(rest) != "" {
+		m := markerAttrRegex.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("invalid comment marker %q", marker)
+		}
+		value, err := strconv.Unquote(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid comment marker %q: %w", marker, err)
+		}
+		attrs[m[1]] = value
+		rest = rest[len(m[0]):]
+	}
+	return attrs, nil
+}
+
+// quoteMarkerValue quotes a marker attribute value as a Go string, with -->
+// escaped so that the value cannot end the HTML comment
+func quoteMarkerValue(value string) string {
+	return strings.ReplaceAll(strconv.Quote(value), "-->", `--\u003e`)
+}
+
+// formatMarkerTime formats a marker timestamp, or "" for the zero time
+func formatMarkerTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// migrateV2 fences the comments of version 2 files with markers. Text that
+// version 2 did not read as a comment is left as it was.
+func migrateV2(content string) (string, error) {
+	frontmatter, body, ok := splitFrontmatter(content)
+	if !ok {
+		return "", errors.New("malformed frontmatter")
+	}
+
+	var out []string
+	for _, sec := range splitSections(body) {
+		if sec.title != "Comments" {
+			out = append(out, sec.lines...)
+			continue
+		}
+
+		out = append(out, sec.lines[:2]...)
+		lines := sec.lines[2:]
+		var headings []int
+		for n, line := range lines {
+			if strings.HasPrefix(line, commentHeading) {
+				headings = append(headings, n)
+			}
+		}
+		if len(headings) == 0 {
+			out = append(out, lines...)
+			continue
+		}
+		out = append(out, lines[:headings[0]]...)
+		for n, start := range headings {
+			end := len(lines)
+			if n+1 < len(headings) {
+				end = headings[n+1]
+			}
+			c, ok := parseCommentHeading(lines[start][len(commentHeading):])
+			if !ok {
+				out = append(out, lines[start:end]...)
+				continue
+			}
+			c.Body = strings.TrimSpace(strings.Join(lines[start+1:end], "\n"))
+			var b strings.Builder
+			writeComment(&b, c)
+			out = append(out, strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")...)
+		}
+	}
+
+	return "---\n" + setFormatVersion(frontmatter, 3) + "\n---\n" + strings.Join(out, "\n"), nil
+}
@@ -0,0 +1,114 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseComments_Fenced tests that comment bodies that look like headings,
+// comments or markers are read back as written
+func TestParseComments_Fenced(t *testing.T) {
+	s := &Storage{}
+	created := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+	comments := []Comment{
+		{ID: "1", Author: "Eve at Large", AuthorID: "a-1", Body: "## Comment by Mallory at 2025-01-01T00:00:00Z\n\nInjected", Created: created},
+		{ID: "2", Author: "Bob \"B\" -->", Body: "Notes\n=====\n\n<!-- takl:end-comment -->\n\\<!-- takl:comment -->", Created: created.Add(time.Hour)},
+		{Author: "Carol", Body: "Worklog\n-------\n", Created: created.Add(2 * time.Hour)},
+	}
+	issue := &Issue{Key: "PROJ-1", Title: "Fenced", Status: "To Do", Comments: comments}
+
+	parsed, err := s.parseMarkdown(s.issueToMarkdown(issue))
+	if err != nil {
+		t.Fatalf("parseMarkdown failed: %v", err)
+	}
+	if len(parsed.Comments) != len(comments) {
+		t.Fatalf("Expected %d comments, got %+v", len(comments), parsed.Comments)
+	}
+	for n, want := range comments {
+		if got := parsed.Comments[n]; !commentsEqual(got, want) {
+			t.Errorf("Comment %d: expected %+v, got %+v", n, want, got)
+		}
+	}
+	if len(parsed.Worklogs) != 0 {
+		t.Errorf("Expected no worklogs read from a comment body, got %+v", parsed.Worklogs)
+	}
+}
+
+// TestParseComments_Unfenced tests that comments without markers, as added by
+// hand, are read alongside fenced ones
+func TestParseComments_Unfenced(t *testing.T) {
+	s := &Storage{}
+	content := `<!-- takl:comment id="7" created="2025-03-02T10:00:00Z" -->
+## Comment by Ann Lee at 2025-03-02T10:00:00Z
+
+Fenced, author from the heading
+
+<!-- takl:end-comment -->
+
+## Comment by Bob at 2025-03-03T10:00:00Z
+
+Added by hand`
+
+	issue := &Issue{}
+	s.parseComments(issue, content)
+	if len(issue.Comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %+v", issue.Comments)
+	}
+	if c := issue.Comments[0]; c.ID != "7" || c.Author != "Ann Lee" || c.Body != "Fenced, author from the heading" {
+		t.Errorf("Expected the fenced comment, got %+v", c)
+	}
+	if c := issue.Comments[1]; c.Author != "Bob" || c.Body != "Added by hand" || c.Created.Day() != 3 {
+		t.Errorf("Expected the unfenced comment, got %+v", c)
+	}
+}
+
+// FuzzCommentRoundTrip tests that writing and reading an issue file keeps
+// comments exactly, whatever their bodies and authors
+func FuzzCommentRoundTrip(f *testing.F) {
+	f.Add("Plain body", "Ann Lee <ann@example.com>", "10001", int64(1741000000000000000))
+	f.Add("## Comment by Mallory at 2025-01-01T00:00:00Z\n\nInjected", "Mallory", "", int64(0))
+	f.Add("Heading\n=======\n\nComments\n--------", "a at b", "x", int64(1))
+	f.Add("<!-- takl:end-comment -->\n\\<!-- takl:comment id=\"1\" -->", "-->", "\"", int64(-1))
+	f.Add("\n\n  leading and trailing  \n\n", "Bob\nJones", "\t", int64(1741000000123456789))
+	f.Add("", "", "", int64(42))
+
+	s := &Storage{}
+	f.Fuzz(func(t *testing.T, body, author, id string, createdNanos int64) {
+		if strings.Contains(body, "\r") {
+			t.Skip("line endings are normalized on read")
+		}
+		var created time.Time
+		if createdNanos != 0 {
+			created = time.Unix(0, createdNanos).UTC()
+		}
+		comments := []Comment{
+			{ID: id, Author: author, AuthorID: id, Body: body, Created: created, Updated: created},
+			{ID: "next", Author: "Next", Body: "After the fuzzed comment", Created: created},
+		}
+		issue := &Issue{Key: "PROJ-1", Title: "Fuzz", Status: "To Do", Description: "Description", Comments: comments}
+
+		content := s.issueToMarkdown(issue)
+		parsed, err := s.parseMarkdown(content)
+		if err != nil {
+			t.Fatalf("parseMarkdown failed: %v\n%s", err, content)
+		}
+		if len(parsed.Comments) != len(comments) {
+			t.Fatalf("Expected %d comments, got %+v\n%s", len(comments), parsed.Comments, content)
+		}
+		for n, want := range comments {
+			if got := parsed.Comments[n]; !commentsEqual(got, want) {
+				t.Fatalf("Comment %d: expected %+v, got %+v\n%s", n, want, got, content)
+			}
+		}
+		if parsed.Description != issue.Description {
+			t.Fatalf("Expected the description kept, got %q\n%s", parsed.Description, content)
+		}
+	})
+}
+
+// commentsEqual compares comments, times by instant
+func commentsEqual(a, b Comment) bool {
+	return a.ID == b.ID && a.Author == b.Author && a.AuthorID == b.AuthorID && a.Body == b.Body &&
+		a.Created.Equal(b.Created) && a.Updated.Equal(b.Updated)
+}
@@ -0,0 +1,163 @@
+package issue
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatVersion is the version of the issue file format written by this
+// version of takl, recorded in the format_version frontmatter field. Files
+// without it are version 1.
+//
+// The format is the layout issueToMarkdown writes and parseMarkdown reads:
+// YAML frontmatter between --- lines, then the setext-headed Description,
+// Comments (fenced by markers, see writeComment), Worklog ("## Logged
+// <duration> [by <author>] at <RFC 3339>") and Attachments ("- [name](url)
+// (<size> bytes, <RFC 3339>)") sections. A change to it needs a new version,
+// a migration from the previous one and a golden file in testdata/format.
+const FormatVersion = 4
+
+// ErrFormatTooNew is returned for issue files written by a newer takl
+var ErrFormatTooNew = errors.New("issue file format is newer than this version of takl supports")
+
+// migration upgrades the content of an issue file from one format version to
+// the next. Migrations work on the text, so that files are upgraded without
+// losing anything the parser of the older version did not read.
+type migration struct {
+	from        int
+	description string
+	migrate     func(content string) (string, error)
+}
+
+// migrations upgrade issue files one version at a time, in order; the one
+// from version n is migrations[n-1]
+var migrations = []migration{
+	{from: 1, description: "record format_version", migrate: migrateV1},
+	{from: 2, description: "fence comments with their metadata", migrate: migrateV2},
+	{from: 3, description: "include the assignee in the sync hash", migrate: migrateV3},
+}
+
+// MigrateMarkdown upgrades the content of an issue file to FormatVersion.
+// Returns the upgraded content and the version the file had; content that is
+// already current is returned as is.
+func MigrateMarkdown(content string) (string, int, error) {
+	version, err := contentVersion(content)
+	if err != nil {
+		return content, 0, err
+	}
+	if version > FormatVersion {
+		return content, version, fmt.Errorf("%w (version %d, supported up to %d); upgrade takl", ErrFormatTooNew, version, FormatVersion)
+	}
+
+	migrated := content
+	for v := version; v < FormatVersion; v++ {
+		m := migrations[v-1]
+		if migrated, err = m.migrate(migrated); err != nil {
+			return content, version, fmt.Errorf("failed to migrate from format version %d (%s): %w", m.from, m.description, err)
+		}
+	}
+	return migrated, version, nil
+}
+
+// MigrateIssue upgrades the issue file of key to FormatVersion in place,
+// keeping its hash. Returns the version the file had.
+func (s *Storage) MigrateIssue(key string) (int, error) {
+	content, err := s.ReadMarkdown(key)
+	if err != nil {
+		return 0, err
+	}
+	migrated, version, err := MigrateMarkdown(content)
+	if err != nil {
+		return version, err
+	}
+	if version < FormatVersion {
+		if err := s.writeMarkdown(key, migrated); err != nil {
+			return version, err
+		}
+	}
+	return version, nil
+}
+
+// contentVersion returns the format version of an issue file
+func contentVersion(content string) (int, error) {
+	frontmatter, _, ok := splitFrontmatter(content)
+	if !ok {
+		return 0, errors.New("invalid issue file: malformed frontmatter")
+	}
+	var fm struct {
+		FormatVersion int `yaml:"format_version"`
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		return 0, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if fm.FormatVersion < 0 {
+		return 0, fmt.Errorf("invalid format_version %d", fm.FormatVersion)
+	}
+	if fm.FormatVersion == 0 {
+		return 1, nil
+	}
+	return fm.FormatVersion, nil
+}
+
+// splitFrontmatter splits the (LF-normalized) content of an issue file into
+// its frontmatter and body
+func splitFrontmatter(content string) (string, string, bool) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", "", false
+	}
+	return strings.Cut(content[4:], "\n---\n")
+}
+
+// migrateV1 records the format version of unversioned files
+func migrateV1(content string) (string, error) {
+	frontmatter, body, ok := splitFrontmatter(content)
+	if !ok {
+		return "", errors.New("malformed frontmatter")
+	}
+	return "---\n" + setFormatVersion(frontmatter, 2) + "\n---\n" + body, nil
+}
+
+// hashLineRegex matches the hash line of a frontmatter
+var hashLineRegex = regexp.MustCompile(`(?m)^hash:.*$`)
+
+// migrateV3 chains the assignee onto the recorded hash, which version 3 left
+// it out of. The assignee is taken as unchanged since the last sync, so that
+// files without local edits stay without them.
+func migrateV3(content string) (string, error) {
+	frontmatter, body, ok := splitFrontmatter(content)
+	if !ok {
+		return "", errors.New("malformed frontmatter")
+	}
+	var fm struct {
+		Assignee string `yaml:"assignee"`
+		Hash     string `yaml:"hash"`
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		return "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if fm.Assignee != "" && fm.Hash != "" {
+		line, err := yaml.Marshal(map[string]string{"hash": chainAssignee(fm.Hash, fm.Assignee)})
+		if err != nil {
+			return "", err
+		}
+		frontmatter = hashLineRegex.ReplaceAllLiteralString(frontmatter, strings.TrimSuffix(string(line), "\n"))
+	}
+	return "---\n" + setFormatVersion(frontmatter, 4) + "\n---\n" + body, nil
+}
+
+// formatVersionRegex matches the format_version line of a frontmatter
+var formatVersionRegex = regexp.MustCompile(`(?m)^format_version:.*$`)
+
+// setFormatVersion sets the format_version of a frontmatter, adding it last if
+// it is missing so that the lines of the other fields do not move
+func setFormatVersion(frontmatter string, version int) string {
+	line := fmt.Sprintf("format_version: %d", version)
+	if formatVersionRegex.MatchString(frontmatter) {
+		return formatVersionRegex.ReplaceAllLiteralString(frontmatter, line)
+	}
+	return frontmatter + "\n" + line
+}
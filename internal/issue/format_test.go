@@ -0,0 +1,196 @@
+package issue
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden file of the current format version")
+
+// goldenPath returns the golden file of a format version
+func goldenPath(version int) string {
+	return filepath.Join("testdata", "format", fmt.Sprintf("v%d.md", version))
+}
+
+// goldenIssue returns the issue the golden file of a format version holds,
+// with a value in each field the version stores
+func goldenIssue(version int) *Issue {
+	at := func(day, hour int) time.Time { return time.Date(2025, 3, day, hour, 0, 0, 0, time.UTC) }
+	issue := &Issue{
+		Key:               "PROJ-7",
+		RemoteID:          "10007",
+		Title:             "Checkout fails: card declined",
+		Status:            "In Progress",
+		Assignee:          "Alice Smith <alice@example.com>",
+		Reporter:          "Bob Jones <bob@example.com>",
+		Created:           at(1, 9),
+		Updated:           at(4, 17),
+		Labels:            []string{"backend", "payments"},
+		Hash:              "0f3c1a",
+		Type:              "Bug",
+		Priority:          "High",
+		Components:        []string{"API"},
+		FixVersions:       []string{"2.4"},
+		Sprint:            "Sprint 12",
+		DueDate:           "2025-03-20",
+		Parent:            "PROJ-1",
+		StoryPoints:       3,
+		Rank:              "0|i0001:",
+		OriginalEstimate:  "1d",
+		RemainingEstimate: "4h",
+		Custom:            map[string]interface{}{"team": "Payments"},
+		Links:             []Link{{Type: "Blocks", Direction: LinkOutward, Key: "PROJ-9", ID: "501"}},
+		Description:       "Cards are declined at checkout.\n\n## Steps\n\n1. Add an item\n2. Pay",
+		Comments: []Comment{
+			{ID: "20001", Author: "Bob Jones", AuthorID: "5b10ac8d", Body: "Seen on staging.", Created: at(2, 10)},
+			{ID: "20002", Author: "Alice Smith", AuthorID: "61a2f0c1", Body: "Fixed in the gateway client.\n\n```\nretry = 3\n```", Created: at(3, 11), Updated: at(3, 12)},
+		},
+		Worklogs: []Worklog{
+			{Author: "Alice Smith", Started: at(3, 9), TimeSpent: 2 * 3600, Comment: "Debugging"},
+		},
+		Attachments: []Attachment{
+			{Filename: "trace (1).log", URL: "https://example.com/files/trace%20(1).log", Size: 2048, Created: at(2, 12)},
+		},
+		FormatVersion: FormatVersion,
+	}
+
+	// The hash covers the assignee from version 4; older hashes are upgraded
+	if version < 4 {
+		issue.Hash = chainAssignee(issue.Hash, issue.Assignee)
+	}
+
+	// Comment metadata is stored from version 3
+	if version < 3 {
+		for n := range issue.Comments {
+			c := &issue.Comments[n]
+			c.ID, c.AuthorID, c.Updated = "", "", time.Time{}
+		}
+	}
+	return issue
+}
+
+// TestFormat_Golden tests that the golden file of every format version reads
+// as the golden issue, so that files written by older versions keep working
+func TestFormat_Golden(t *testing.T) {
+	s := &Storage{}
+	for version := 1; version <= FormatVersion; version++ {
+		want := goldenIssue(version)
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			data, err := os.ReadFile(goldenPath(version))
+			if err != nil {
+				t.Fatalf("Expected a golden file for format version %d: %v", version, err)
+			}
+			got, err := s.parseMarkdown(string(data))
+			if err != nil {
+				t.Fatalf("parseMarkdown failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected the golden issue\n%+v\ngot\n%+v", want, got)
+			}
+		})
+	}
+}
+
+// TestFormat_Write tests that issues are written exactly as the golden file of
+// the current format version. Run with -update after a deliberate format
+// change, and add a migration and keep the old golden file.
+func TestFormat_Write(t *testing.T) {
+	got := (&Storage{}).issueToMarkdown(goldenIssue(FormatVersion))
+	path := goldenPath(FormatVersion)
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("Expected %s, got:\n%s", path, got)
+	}
+}
+
+// TestMigrateMarkdown tests upgrading the golden files of older versions and
+// rejecting files from newer ones
+func TestMigrateMarkdown(t *testing.T) {
+	current, err := os.ReadFile(goldenPath(FormatVersion))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	for version := 1; version <= FormatVersion; version++ {
+		data, err := os.ReadFile(goldenPath(version))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		migrated, from, err := MigrateMarkdown(string(data))
+		if err != nil {
+			t.Fatalf("v%d: MigrateMarkdown failed: %v", version, err)
+		}
+		if from != version {
+			t.Errorf("v%d: Expected version %d, got %d", version, version, from)
+		}
+		if version == FormatVersion && migrated != string(current) {
+			t.Errorf("Expected current files unchanged, got:\n%s", migrated)
+		}
+		if _, again, _ := MigrateMarkdown(migrated); again != FormatVersion {
+			t.Errorf("v%d: Expected the migrated file at version %d, got %d", version, FormatVersion, again)
+		}
+	}
+
+	newer := strings.Replace(string(current), fmt.Sprintf("format_version: %d", FormatVersion), fmt.Sprintf("format_version: %d", FormatVersion+1), 1)
+	if _, _, err := MigrateMarkdown(newer); !errors.Is(err, ErrFormatTooNew) {
+		t.Errorf("Expected ErrFormatTooNew, got %v", err)
+	}
+}
+
+// TestMigrateIssue tests that migrating rewrites old files only, keeping
+// their content and hash
+func TestMigrateIssue(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	old, err := os.ReadFile(goldenPath(1))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	path := filepath.Join(s.issuesDir, "PROJ-7.md")
+	if err := os.WriteFile(path, old, 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	from, err := s.MigrateIssue("PROJ-7")
+	if err != nil {
+		t.Fatalf("MigrateIssue failed: %v", err)
+	}
+	if from != 1 {
+		t.Errorf("Expected version 1, got %d", from)
+	}
+	migrated, _ := os.ReadFile(path)
+	if !strings.Contains(string(migrated), fmt.Sprintf("\nformat_version: %d\n---\n", FormatVersion)) {
+		t.Errorf("Expected the file with format_version added, got:\n%s", migrated)
+	}
+	parsed, err := s.ReadIssue("PROJ-7")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, goldenIssue(1)) {
+		t.Errorf("Expected the golden issue, got %+v", parsed)
+	}
+
+	if from, err := s.MigrateIssue("PROJ-7"); err != nil || from != FormatVersion {
+		t.Errorf("Expected the file current, got version %d (%v)", from, err)
+	}
+	if again, _ := os.ReadFile(path); string(again) != string(migrated) {
+		t.Errorf("Expected a current file unchanged")
+	}
+}
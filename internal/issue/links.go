@@ -0,0 +1,206 @@
+package issue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Link directions, from the point of view of the issue holding the link
+const (
+	LinkOutward = "outward" // This issue <outward phrase> Key, e.g. "blocks"
+	LinkInward  = "inward"  // This issue <inward phrase> Key, e.g. "is blocked by"
+)
+
+// LinkTypeSubtask is the link type of subtasks; they are listed on the parent as
+// outward links and created by setting the subtask's parent, not by linking
+const LinkTypeSubtask = "Subtask"
+
+// Link is a relation between this issue and another one
+type Link struct {
+	Type      string `yaml:"type" json:"type"`                 // Link type name (Blocks, Relates, ...)
+	Direction string `yaml:"direction" json:"direction"`       // LinkOutward or LinkInward
+	Key       string `yaml:"key" json:"key"`                   // Key of the other issue
+	ID        string `yaml:"id,omitempty" json:"id,omitempty"` // Remote link ID; empty until pushed
+}
+
+// LinkType names a kind of link and its phrases in both directions
+type LinkType struct {
+	Name    string
+	Outward string
+	Inward  string
+}
+
+// LinkTypes are the link types known to takl, matching Jira's defaults
+var LinkTypes = []LinkType{
+	{Name: "Blocks", Outward: "blocks", Inward: "is blocked by"},
+	{Name: "Relates", Outward: "relates to", Inward: "relates to"},
+	{Name: "Duplicate", Outward: "duplicates", Inward: "is duplicated by"},
+	{Name: "Cloners", Outward: "clones", Inward: "is cloned by"},
+	{Name: LinkTypeSubtask, Outward: "has subtask", Inward: "is subtask of"},
+}
+
+// Relation returns the phrase describing the link, e.g. "is blocked by"
+func (l Link) Relation() string {
+	for _, t := range LinkTypes {
+		if strings.EqualFold(t.Name, l.Type) {
+			if l.Direction == LinkInward {
+				return t.Inward
+			}
+			return t.Outward
+		}
+	}
+	return fmt.Sprintf("%s (%s)", l.Type, l.Direction)
+}
+
+// ParseRelation resolves a relation phrase ("blocks", "is-blocked-by",
+// "relates-to") or a link type name to the link type and direction
+func ParseRelation(relation string) (linkType, direction string, err error) {
+	phrase := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(relation), "-", " "))
+	for _, t := range LinkTypes {
+		if t.Name == LinkTypeSubtask {
+			continue
+		}
+		switch phrase {
+		case t.Outward, strings.ToLower(t.Name):
+			return t.Name, LinkOutward, nil
+		case t.Inward:
+			return t.Name, LinkInward, nil
+		}
+	}
+
+	phrases := make([]string, 0, 2*len(LinkTypes))
+	for _, t := range LinkTypes {
+		if t.Name == LinkTypeSubtask {
+			continue
+		}
+		phrases = append(phrases, strings.ReplaceAll(t.Outward, " ", "-"))
+		if t.Inward != t.Outward {
+			phrases = append(phrases, strings.ReplaceAll(t.Inward, " ", "-"))
+		}
+	}
+	return "", "", fmt.Errorf("unknown relation %q: must be one of %s", relation, strings.Join(phrases, ", "))
+}
+
+// linkKey identifies a link regardless of its remote ID
+func linkKey(l Link) string {
+	return strings.ToLower(l.Type) + "|" + l.Direction + "|" + l.Key
+}
+
+// SameLink reports whether two links describe the same relation
+func SameLink(a, b Link) bool {
+	return linkKey(a) == linkKey(b)
+}
+
+// HasLink reports whether the issue has a link equivalent to l
+func (i *Issue) HasLink(l Link) bool {
+	for _, existing := range i.Links {
+		if SameLink(existing, l) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortLinks orders links by type, direction and key for stable files and hashes
+func sortLinks(links []Link) {
+	sort.SliceStable(links, func(i, j int) bool {
+		return linkKey(links[i]) < linkKey(links[j])
+	})
+}
+
+// blockedBy reports whether link l on the issue means the issue is blocked by l.Key
+func blockedBy(l Link) bool {
+	return strings.EqualFold(l.Type, "Blocks") && l.Direction == LinkInward
+}
+
+// blocks reports whether link l on the issue means the issue blocks l.Key
+func blocks(l Link) bool {
+	return strings.EqualFold(l.Type, "Blocks") && l.Direction == LinkOutward
+}
+
+// DepNode is an issue in a blocking tree, with the issues blocking it as children
+type DepNode struct {
+	Key      string     `json:"key"`
+	Title    string     `json:"title,omitempty"`
+	Status   string     `json:"status,omitempty"`
+	Missing  bool       `json:"missing,omitempty"` // Not found locally
+	Cycle    bool       `json:"cycle,omitempty"`   // Already on the path from the root
+	Repeat   bool       `json:"repeat,omitempty"`  // Already expanded elsewhere in the tree
+	Blockers []*DepNode `json:"blockers,omitempty"`
+}
+
+// BlockingTree builds the tree of issues blocking key, transitively. Blocking
+// links are read from both ends, so a link stored only on the blocker counts.
+// Returns the tree and every cycle found, each as a path of keys from and back
+// to the same issue.
+func BlockingTree(issues []*Issue, key string) (*DepNode, [][]string) {
+	byKey := make(map[string]*Issue, len(issues))
+	blockers := make(map[string]map[string]bool)
+	addEdge := func(blocked, blocker string) {
+		if blockers[blocked] == nil {
+			blockers[blocked] = make(map[string]bool)
+		}
+		blockers[blocked][blocker] = true
+	}
+	for _, i := range issues {
+		byKey[i.Key] = i
+		for _, l := range i.Links {
+			switch {
+			case blockedBy(l):
+				addEdge(i.Key, l.Key)
+			case blocks(l):
+				addEdge(l.Key, i.Key)
+			}
+		}
+	}
+
+	var cycles [][]string
+	expanded := make(map[string]bool)
+	onPath := make(map[string]bool)
+	var path []string
+
+	var build func(key string) *DepNode
+	build = func(key string) *DepNode {
+		node := &DepNode{Key: key}
+		if i, ok := byKey[key]; ok {
+			node.Title = i.Title
+			node.Status = i.Status
+		} else {
+			node.Missing = true
+		}
+
+		if onPath[key] {
+			node.Cycle = true
+			start := 0
+			for path[start] != key {
+				start++
+			}
+			cycle := append(append([]string{}, path[start:]...), key)
+			cycles = append(cycles, cycle)
+			return node
+		}
+		if expanded[key] {
+			node.Repeat = len(blockers[key]) > 0
+			return node
+		}
+		expanded[key] = true
+
+		onPath[key] = true
+		path = append(path, key)
+		keys := make([]string, 0, len(blockers[key]))
+		for blocker := range blockers[key] {
+			keys = append(keys, blocker)
+		}
+		sort.Strings(keys)
+		for _, blocker := range keys {
+			node.Blockers = append(node.Blockers, build(blocker))
+		}
+		path = path[:len(path)-1]
+		onPath[key] = false
+
+		return node
+	}
+
+	return build(key), cycles
+}
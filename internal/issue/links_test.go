@@ -0,0 +1,116 @@
+package issue
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseRelation tests relation phrases and type names in both directions
+func TestParseRelation(t *testing.T) {
+	tests := []struct {
+		relation      string
+		wantType      string
+		wantDirection string
+	}{
+		{"blocks", "Blocks", LinkOutward},
+		{"is-blocked-by", "Blocks", LinkInward},
+		{"is blocked by", "Blocks", LinkInward},
+		{"relates-to", "Relates", LinkOutward},
+		{"Duplicates", "Duplicate", LinkOutward},
+		{"is-cloned-by", "Cloners", LinkInward},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relation, func(t *testing.T) {
+			linkType, direction, err := ParseRelation(tt.relation)
+			if err != nil {
+				t.Fatalf("ParseRelation failed: %v", err)
+			}
+			if linkType != tt.wantType || direction != tt.wantDirection {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.wantType, tt.wantDirection, linkType, direction)
+			}
+		})
+	}
+
+	for _, relation := range []string{"", "owns", "has-subtask"} {
+		if _, _, err := ParseRelation(relation); err == nil {
+			t.Errorf("Expected error for relation %q", relation)
+		}
+	}
+}
+
+// TestLinks_RoundTripAndHash tests that links survive a save and read and that
+// the remote link ID does not affect the hash
+func TestLinks_RoundTripAndHash(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	links := []Link{
+		{Type: "Relates", Direction: LinkOutward, Key: "PROJ-9"},
+		{Type: "Blocks", Direction: LinkInward, Key: "PROJ-2", ID: "10001"},
+	}
+	if err := s.SaveIssue(&Issue{Key: "PROJ-1", Title: "Linked", Links: links}); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+
+	got, err := s.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	want := []Link{links[1], links[0]} // Sorted by type
+	if !reflect.DeepEqual(got.Links, want) {
+		t.Errorf("Expected links %v, got %v", want, got.Links)
+	}
+
+	withoutID := *got
+	withoutID.Links = []Link{links[0], {Type: "Blocks", Direction: LinkInward, Key: "PROJ-2"}}
+	if s.ComputeHash(&withoutID) != got.Hash {
+		t.Errorf("Expected link IDs and order to be ignored by the hash")
+	}
+}
+
+// TestBlockingTree tests transitive blockers read from both ends of links,
+// repeated subtrees and cycle detection
+func TestBlockingTree(t *testing.T) {
+	blockedBy := func(key string) Link { return Link{Type: "Blocks", Direction: LinkInward, Key: key} }
+	blocks := func(key string) Link { return Link{Type: "Blocks", Direction: LinkOutward, Key: key} }
+
+	issues := []*Issue{
+		{Key: "A", Title: "Root", Links: []Link{blockedBy("B"), blockedBy("C"), {Type: "Relates", Direction: LinkOutward, Key: "Z"}}},
+		{Key: "B", Links: []Link{blockedBy("D")}},
+		{Key: "C", Links: []Link{blockedBy("D")}},
+		{Key: "D", Links: []Link{blocks("X")}},
+		{Key: "E", Links: []Link{blocks("D")}}, // Stored only on the blocker
+	}
+
+	tree, cycles := BlockingTree(issues, "A")
+	if len(cycles) != 0 {
+		t.Errorf("Expected no cycles, got %v", cycles)
+	}
+
+	var render func(n *DepNode, depth int) string
+	render = func(n *DepNode, depth int) string {
+		out := strings.Repeat(" ", depth) + n.Key
+		if n.Repeat {
+			out += "*"
+		}
+		for _, b := range n.Blockers {
+			out += "\n" + render(b, depth+1)
+		}
+		return out
+	}
+	want := "A\n B\n  D\n   E\n C\n  D*"
+	if got := render(tree, 0); got != want {
+		t.Errorf("Expected tree\n%s\ngot\n%s", want, got)
+	}
+
+	// E now also waits for A, closing A → B → D → E → A
+	issues[4].Links = append(issues[4].Links, blockedBy("A"))
+	_, cycles = BlockingTree(issues, "A")
+	if len(cycles) != 1 || strings.Join(cycles[0], ",") != "A,B,D,E,A" {
+		t.Errorf("Expected cycle A,B,D,E,A, got %v", cycles)
+	}
+}
@@ -0,0 +1,233 @@
+package issue
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxFixPasses bounds the fix passes of LintIssue; a fix may reveal another,
+// e.g. the value of a renamed assignee key is then normalized
+const maxFixPasses = 3
+
+// LintResult is the outcome of linting an issue file
+type LintResult struct {
+	Key      string    `json:"key"`
+	Problems []Problem `json:"problems,omitempty"` // Problems left in the file
+	Fixed    []Problem `json:"fixed,omitempty"`    // Problems fixed, with their lines before the fix
+}
+
+// LintMarkdown checks the content of the issue file of key like
+// ValidateMarkdown, and also that jira_key matches the file name, that status
+// and assignee are spelled as in the caches, that labels are unique (and for
+// Jira have no spaces) and that there is one Description section. Problems
+// are sorted by line; those that can be normalized are marked Fixable. Returns the content with them
+// fixed, or "" if none are fixable, and the problems found.
+func (s *Storage) LintMarkdown(key, content string, schema Schema) (string, []Problem) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	parsed, problems := s.ValidateMarkdown(content, schema)
+	if parsed == nil {
+		return "", problems
+	}
+
+	raw, body, _ := strings.Cut(content[4:], "\n---\n")
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", problems
+	}
+	mapping := doc.Content[0]
+	frontmatterChanged, bodyChanged := false, false
+
+	// Misspelled keys, unless the key they were meant for is also there
+	for n := 0; n+1 < len(mapping.Content); n += 2 {
+		node := mapping.Content[n]
+		if FrontmatterKeys[node.Value] {
+			continue
+		}
+		suggestion := closestKey(node.Value)
+		if suggestion == "" || mappingValue(mapping, suggestion) != nil {
+			continue
+		}
+		for i := range problems {
+			if problems[i].Line == node.Line+1 && strings.HasPrefix(problems[i].Message, "unknown field") {
+				problems[i].Fixable = true
+			}
+		}
+		node.Value = suggestion
+		frontmatterChanged = true
+	}
+
+	if key != "" && parsed.Key != "" && parsed.Key != key {
+		problems = append(problems, Problem{
+			Line:    keyLine(mapping, "jira_key"),
+			Message: fmt.Sprintf("jira_key %s does not match the file name %s.md", parsed.Key, key),
+		})
+	}
+
+	// Status and assignee as normalized by ValidateMarkdown
+	for _, field := range []struct{ key, value string }{
+		{"status", parsed.Status},
+		{"assignee", parsed.Assignee},
+	} {
+		node := mappingValue(mapping, field.key)
+		if field.value == "" || node == nil || node.Kind != yaml.ScalarNode || node.Value == field.value {
+			continue
+		}
+		problems = append(problems, Problem{
+			Line:    keyLine(mapping, field.key),
+			Message: fmt.Sprintf("%s %q should be %q", field.key, node.Value, field.value),
+			Fixable: true,
+		})
+		node.Value, node.Style = field.value, 0
+		frontmatterChanged = true
+	}
+
+	if node := mappingValue(mapping, "labels"); node != nil && node.Kind == yaml.SequenceNode {
+		seen := make(map[string]bool)
+		labels := node.Content[:0]
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				labels = append(labels, item)
+				continue
+			}
+			label := item.Value
+			switch {
+			case strings.TrimSpace(label) == "":
+				problems = append(problems, Problem{Line: item.Line + 1, Message: "empty label", Fixable: true})
+				frontmatterChanged = true
+				continue
+			case schema.Bridge == BridgeJira && strings.ContainsAny(label, " \t"):
+				label = strings.Join(strings.Fields(label), "-")
+				problems = append(problems, Problem{
+					Line:    item.Line + 1,
+					Message: fmt.Sprintf("label %q has spaces, which Jira does not allow (use %q)", item.Value, label),
+					Fixable: true,
+				})
+				item.Value, item.Style = label, 0
+				frontmatterChanged = true
+			}
+			if seen[label] {
+				problems = append(problems, Problem{Line: item.Line + 1, Message: fmt.Sprintf("duplicate label %q", label), Fixable: true})
+				frontmatterChanged = true
+				continue
+			}
+			seen[label] = true
+			labels = append(labels, item)
+		}
+		node.Content = labels
+	}
+
+	// Only the last Description section is read; the others would be lost
+	bodyStart := strings.Count(raw, "\n") + 4
+	sections := splitSections(body)
+	first := -1
+	for i, sec := range sections {
+		if sec.title != "Description" {
+			continue
+		}
+		if first == -1 {
+			first = i
+			continue
+		}
+		problems = append(problems, Problem{
+			Line:    bodyStart + sec.line,
+			Message: "duplicate Description section (only the last one is kept)",
+			Fixable: true,
+		})
+		bodyChanged = true
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+
+	if !frontmatterChanged && !bodyChanged {
+		return "", problems
+	}
+	if frontmatterChanged {
+		var out bytes.Buffer
+		enc := yaml.NewEncoder(&out)
+		enc.SetIndent(4)
+		if err := enc.Encode(&doc); err != nil {
+			return "", problems
+		}
+		raw = strings.TrimSuffix(out.String(), "\n")
+	}
+	if bodyChanged {
+		body = mergeDescriptions(sections)
+	}
+	return "---\n" + raw + "\n---\n" + body, problems
+}
+
+// LintIssue lints the issue file of key (see LintMarkdown). With fix, the
+// fixable problems are fixed in the file, keeping its hash so that the fixes
+// are pushed as local edits.
+func (s *Storage) LintIssue(key string, schema Schema, fix bool) (*LintResult, error) {
+	content, err := s.ReadMarkdown(key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &LintResult{Key: key}
+	fixed, problems := s.LintMarkdown(key, content, schema)
+	for pass := 0; fix && fixed != "" && pass < maxFixPasses; pass++ {
+		for _, p := range problems {
+			if p.Fixable {
+				result.Fixed = append(result.Fixed, p)
+			}
+		}
+		content = fixed
+		fixed, problems = s.LintMarkdown(key, content, schema)
+	}
+	if len(result.Fixed) > 0 {
+		if err := s.writeMarkdown(key, content); err != nil {
+			return nil, err
+		}
+	}
+	result.Problems = problems
+	return result, nil
+}
+
+// mergeDescriptions joins the sections back into a body, with the content of
+// all Description sections moved into the first one
+func mergeDescriptions(sections []section) string {
+	first := -1
+	var contents []string
+	for i, sec := range sections {
+		if sec.title != "Description" {
+			continue
+		}
+		if first == -1 {
+			first = i
+		}
+		if content := strings.TrimSpace(strings.Join(sec.lines[2:], "\n")); content != "" {
+			contents = append(contents, content)
+		}
+	}
+
+	var lines []string
+	for i, sec := range sections {
+		switch {
+		case i == first:
+			lines = append(lines, sec.lines[:2]...)
+			lines = append(lines, "")
+			if len(contents) > 0 {
+				lines = append(lines, strings.Join(contents, "\n\n"), "")
+			}
+		case sec.title != "Description":
+			lines = append(lines, sec.lines...)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// keyLine returns the file line of a frontmatter key, or 0 if it is missing
+func keyLine(mapping *yaml.Node, key string) int {
+	for n := 0; n+1 < len(mapping.Content); n += 2 {
+		if mapping.Content[n].Value == key {
+			return mapping.Content[n].Line + 1
+		}
+	}
+	return 0
+}
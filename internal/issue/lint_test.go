@@ -0,0 +1,163 @@
+package issue
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// lintIssueFile is an issue file with a problem of each kind lint fixes
+const lintIssueFile = `---
+jira_key: PROJ-1
+jira_id: "10001"
+title: Fix login
+status: in progress
+asignee: alice@example.com
+labels:
+    - needs review
+    - backend
+    - backend
+hash: abc123
+---
+
+Description
+===========
+
+The login form fails.
+
+Comments
+========
+
+## Comment by Bob at 2025-01-02T10:00:00Z
+
+Seen on staging.
+
+Description
+===========
+
+Also on mobile.
+`
+
+// TestLintMarkdown tests the problems lint finds, and that fixing them leaves
+// none and keeps the rest of the file
+func TestLintMarkdown(t *testing.T) {
+	s := &Storage{}
+	schema := newTestSchema()
+	schema.Bridge = BridgeJira
+
+	fixed, problems := s.LintMarkdown("PROJ-1", lintIssueFile, schema)
+	want := []string{
+		`line 5: status "in progress" should be "In Progress"`,
+		`line 6: unknown field "asignee" (did you mean "assignee"?)`,
+		`line 8: label "needs review" has spaces, which Jira does not allow (use "needs-review")`,
+		`line 10: duplicate label "backend"`,
+		`line 26: duplicate Description section (only the last one is kept)`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %v", len(want), problems)
+	}
+	for n, p := range problems {
+		if p.String() != want[n] {
+			t.Errorf("Expected %q, got %q", want[n], p.String())
+		}
+		if !p.Fixable {
+			t.Errorf("Expected %q to be fixable", p)
+		}
+	}
+
+	// The renamed assignee is normalized on the next pass
+	refixed, problems := s.LintMarkdown("PROJ-1", fixed, schema)
+	if len(problems) != 1 || problems[0].String() != `line 6: assignee "alice@example.com" should be "Alice Smith <alice@example.com>"` {
+		t.Fatalf("Expected the assignee to be normalized, got %v in:\n%s", problems, fixed)
+	}
+	if again, problems := s.LintMarkdown("PROJ-1", refixed, schema); again != "" || len(problems) > 0 {
+		t.Fatalf("Expected no problems after fixing, got %v in:\n%s", problems, refixed)
+	}
+
+	parsed, err := s.parseMarkdown(refixed)
+	if err != nil {
+		t.Fatalf("parseMarkdown failed: %v", err)
+	}
+	if parsed.Description != "The login form fails.\n\nAlso on mobile." {
+		t.Errorf("Expected the descriptions merged, got %q", parsed.Description)
+	}
+	if len(parsed.Labels) != 2 || parsed.Labels[0] != "needs-review" || parsed.Labels[1] != "backend" {
+		t.Errorf("Expected labels needs-review and backend, got %v", parsed.Labels)
+	}
+	// The file is unversioned, so its hash is upgraded to cover the assignee on read
+	if parsed.Hash != chainAssignee("abc123", parsed.Assignee) || parsed.RemoteID != "10001" || len(parsed.Comments) != 1 {
+		t.Errorf("Expected the other fields kept, got %+v", parsed)
+	}
+}
+
+// TestLintMarkdown_NotFixable tests problems that are left to the user
+func TestLintMarkdown_NotFixable(t *testing.T) {
+	s := &Storage{}
+
+	content := strings.Replace(validIssueFile, "status: To Do", "status: Nope\nflavor: mint", 1)
+	content = strings.Replace(content, "alice@example.com", "Alice Smith <alice@example.com>", 1)
+	fixed, problems := s.LintMarkdown("PROJ-2", content, newTestSchema())
+	if fixed != "" {
+		t.Errorf("Expected nothing fixed, got:\n%s", fixed)
+	}
+	want := []string{
+		`line 2: jira_key PROJ-1 does not match the file name PROJ-2.md`,
+		`line 5: unknown status "Nope" (known: In Progress, To Do)`,
+		`line 6: unknown field "flavor"`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %v", len(want), problems)
+	}
+	for n, p := range problems {
+		if p.String() != want[n] || p.Fixable {
+			t.Errorf("Expected %q, not fixable, got %q (fixable %v)", want[n], p.String(), p.Fixable)
+		}
+	}
+
+	// Labels with spaces are fine outside Jira
+	content = strings.Replace(validIssueFile, "status: To Do", "status: To Do\nlabels: [good first issue]", 1)
+	if _, problems := s.LintMarkdown("PROJ-1", content, Schema{Bridge: BridgeGitHub}); len(problems) > 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
+
+// TestLintIssue_Fix tests that fixes are written to the file
+func TestLintIssue_Fix(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	path := filepath.Join(s.issuesDir, "PROJ-1.md")
+	if err := os.WriteFile(path, []byte(lintIssueFile), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	schema := newTestSchema()
+	schema.Bridge = BridgeJira
+
+	result, err := s.LintIssue("PROJ-1", schema, false)
+	if err != nil {
+		t.Fatalf("LintIssue failed: %v", err)
+	}
+	if len(result.Problems) != 5 || len(result.Fixed) != 0 {
+		t.Fatalf("Expected 5 problems and no fixes, got %+v", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != lintIssueFile {
+		t.Errorf("Expected the file unchanged without fix")
+	}
+
+	result, err = s.LintIssue("PROJ-1", schema, true)
+	if err != nil {
+		t.Fatalf("LintIssue failed: %v", err)
+	}
+	if len(result.Problems) != 0 || len(result.Fixed) != 6 {
+		t.Fatalf("Expected 6 fixes and no problems left, got %+v", result)
+	}
+	parsed, err := s.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if parsed.Status != "In Progress" || parsed.Assignee != "Alice Smith <alice@example.com>" || parsed.Hash != chainAssignee("abc123", parsed.Assignee) {
+		t.Errorf("Expected the fixed file with its hash, got %+v", parsed)
+	}
+}
@@ -0,0 +1,37 @@
+package issue
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoRemote is returned by bridges that have no remote tracker to sync with
+var ErrNoRemote = errors.New("project has no remote tracker")
+
+// LocalBridge is the bridge for local-only projects.
+// Issues live only in .takl/issues; pull and push are not supported.
+type LocalBridge struct{}
+
+// Pull always fails with ErrNoRemote
+func (LocalBridge) Pull(ctx context.Context, storage *Storage) (*PullResult, error) {
+	return nil, ErrNoRemote
+}
+
+// Push always fails with ErrNoRemote
+func (LocalBridge) Push(ctx context.Context, storage *Storage, key string) (*PushResult, error) {
+	return nil, ErrNoRemote
+}
+
+// Members returns no members; local projects accept any assignee
+func (LocalBridge) Members(ctx context.Context, projectPath string) ([]*Member, error) {
+	return []*Member{}, nil
+}
+
+// Workflow returns a fixed To Do / In Progress / Done workflow
+func (LocalBridge) Workflow(ctx context.Context, projectPath string) ([]*StatusInfo, error) {
+	return []*StatusInfo{
+		{ID: "todo", Name: "To Do", Category: "new"},
+		{ID: "in-progress", Name: "In Progress", Category: "indeterminate"},
+		{ID: "done", Name: "Done", Category: "done"},
+	}, nil
+}
@@ -0,0 +1,427 @@
+package issue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultConflictMarkerSize is the conflict marker length git uses when %L is not provided
+const DefaultConflictMarkerSize = 7
+
+// MergeResult represents the outcome of a three-way merge of an issue file
+type MergeResult struct {
+	Content   string   // Merged markdown file content
+	Conflicts []string // Sections left with conflict markers ("description" or "comment <id>")
+}
+
+// HasConflicts reports whether the merge left unresolved conflicts
+func (r *MergeResult) HasConflicts() bool {
+	return len(r.Conflicts) > 0
+}
+
+// MergeIssueFiles performs a three-way merge of issue markdown files.
+//
+// Merge rules:
+//   - Labels and assignees are merged as sets (additions and removals from both sides apply)
+//   - Comments and attachments are unioned (comments keyed by ID or timestamp)
+//   - Worklogs are merged as sets (keyed by start time, author and time spent)
+//   - Scalars take the changed side; when both sides changed, the side with
+//     the later "updated" timestamp wins
+//   - Description conflicts, and comments edited differently on both sides,
+//     are left with git-style conflict markers
+//
+// An empty base is treated as an empty issue (file added on both sides).
+func MergeIssueFiles(base, ours, theirs string, markerSize int) (*MergeResult, error) {
+	s := &Storage{}
+
+	baseIssue := &Issue{}
+	if strings.TrimSpace(base) != "" {
+		parsed, err := s.parseMarkdown(base)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse base: %w", err)
+		}
+		baseIssue = parsed
+	}
+
+	oursIssue, err := s.parseMarkdown(ours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ours: %w", err)
+	}
+
+	theirsIssue, err := s.parseMarkdown(theirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse theirs: %w", err)
+	}
+
+	if markerSize <= 0 {
+		markerSize = DefaultConflictMarkerSize
+	}
+
+	merged, conflicts := mergeIssues(baseIssue, oursIssue, theirsIssue, markerSize)
+
+	return &MergeResult{
+		Content:   s.issueToMarkdown(merged),
+		Conflicts: conflicts,
+	}, nil
+}
+
+// mergeIssues merges three parsed issues and returns the merged issue and
+// the names of sections that could not be resolved automatically
+func mergeIssues(base, ours, theirs *Issue, markerSize int) (*Issue, []string) {
+	// Ties go to ours, matching git's default preference
+	oursLater := !theirs.Updated.After(ours.Updated)
+
+	merged := &Issue{
+		Key:       mergeScalar(base.Key, ours.Key, theirs.Key, oursLater),
+		RemoteID:  mergeScalar(base.RemoteID, ours.RemoteID, theirs.RemoteID, oursLater),
+		Title:     mergeScalar(base.Title, ours.Title, theirs.Title, oursLater),
+		Status:    mergeScalar(base.Status, ours.Status, theirs.Status, oursLater),
+		Assignee:  mergeScalar(base.Assignee, ours.Assignee, theirs.Assignee, oursLater),
+		Reporter:  mergeScalar(base.Reporter, ours.Reporter, theirs.Reporter, oursLater),
+		Hash:      mergeScalar(base.Hash, ours.Hash, theirs.Hash, oursLater),
+		Created:   mergeTime(base.Created, ours.Created, theirs.Created, oursLater),
+		Updated:   ours.Updated,
+		Labels:    mergeLabels(base.Labels, ours.Labels, theirs.Labels),
+		Assignees: mergeLabels(base.Assignees, ours.Assignees, theirs.Assignees),
+		Milestone: mergeScalar(base.Milestone, ours.Milestone, theirs.Milestone, oursLater),
+
+		Type:        mergeScalar(base.Type, ours.Type, theirs.Type, oursLater),
+		Priority:    mergeScalar(base.Priority, ours.Priority, theirs.Priority, oursLater),
+		Components:  mergeLabels(base.Components, ours.Components, theirs.Components),
+		FixVersions: mergeLabels(base.FixVersions, ours.FixVersions, theirs.FixVersions),
+		Sprint:      mergeScalar(base.Sprint, ours.Sprint, theirs.Sprint, oursLater),
+		Rank:        mergeScalar(base.Rank, ours.Rank, theirs.Rank, oursLater),
+		DueDate:     mergeScalar(base.DueDate, ours.DueDate, theirs.DueDate, oursLater),
+		Parent:      mergeScalar(base.Parent, ours.Parent, theirs.Parent, oursLater),
+		StoryPoints: mergeScalar(base.StoryPoints, ours.StoryPoints, theirs.StoryPoints, oursLater),
+
+		OriginalEstimate:  mergeScalar(base.OriginalEstimate, ours.OriginalEstimate, theirs.OriginalEstimate, oursLater),
+		RemainingEstimate: mergeScalar(base.RemainingEstimate, ours.RemainingEstimate, theirs.RemainingEstimate, oursLater),
+
+		Custom: mergeCustom(base.Custom, ours.Custom, theirs.Custom, oursLater),
+		Links:  mergeLinks(base.Links, ours.Links, theirs.Links),
+	}
+	if theirs.Updated.After(ours.Updated) {
+		merged.Updated = theirs.Updated
+	}
+
+	merged.Worklogs = mergeWorklogs(base.Worklogs, ours.Worklogs, theirs.Worklogs)
+	merged.Attachments = mergeAttachments(base.Attachments, ours.Attachments, theirs.Attachments)
+
+	var conflicts []string
+	switch {
+	case ours.Description == theirs.Description:
+		merged.Description = ours.Description
+	case ours.Description == base.Description:
+		merged.Description = theirs.Description
+	case theirs.Description == base.Description:
+		merged.Description = ours.Description
+	default:
+		merged.Description = conflictBlock(ours.Description, theirs.Description, markerSize)
+		conflicts = append(conflicts, "description")
+	}
+
+	var commentConflicts []string
+	merged.Comments, commentConflicts = mergeComments(base.Comments, ours.Comments, theirs.Comments, markerSize)
+	conflicts = append(conflicts, commentConflicts...)
+
+	return merged, conflicts
+}
+
+// mergeScalar resolves a single value three ways, falling back to the later side
+func mergeScalar[T comparable](base, ours, theirs T, oursLater bool) T {
+	switch {
+	case ours == theirs:
+		return ours
+	case ours == base:
+		return theirs
+	case theirs == base:
+		return ours
+	case oursLater:
+		return ours
+	default:
+		return theirs
+	}
+}
+
+// mergeCustom merges custom field maps key by key, comparing values by their
+// canonical encoding. A key removed on one side stays removed unless the other side changed it.
+func mergeCustom(base, ours, theirs map[string]interface{}, oursLater bool) map[string]interface{} {
+	keys := make(map[string]bool)
+	for _, m := range []map[string]interface{}{base, ours, theirs} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+
+	var merged map[string]interface{}
+	for k := range keys {
+		encoded := mergeScalar(encodeCustom(base, k), encodeCustom(ours, k), encodeCustom(theirs, k), oursLater)
+		var value interface{}
+		switch encoded {
+		case "":
+			continue
+		case encodeCustom(ours, k):
+			value = ours[k]
+		default:
+			value = theirs[k]
+		}
+		if merged == nil {
+			merged = make(map[string]interface{})
+		}
+		merged[k] = value
+	}
+	return merged
+}
+
+// encodeCustom returns the canonical encoding of a custom value, or "" when absent
+func encodeCustom(m map[string]interface{}, key string) string {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return CanonicalValue(v)
+}
+
+// mergeTime resolves a timestamp three ways, falling back to the later side
+func mergeTime(base, ours, theirs time.Time, oursLater bool) time.Time {
+	switch {
+	case ours.Equal(theirs):
+		return ours
+	case ours.Equal(base):
+		return theirs
+	case theirs.Equal(base):
+		return ours
+	case oursLater:
+		return ours
+	default:
+		return theirs
+	}
+}
+
+// mergeLabels merges label sets: a label is kept if both sides have it, or if
+// one side added it; a label removed by either side is dropped
+func mergeLabels(base, ours, theirs []string) []string {
+	inBase := toSet(base)
+	inOurs := toSet(ours)
+	inTheirs := toSet(theirs)
+
+	var merged []string
+	for label := range unionSets(inOurs, inTheirs) {
+		switch {
+		case inOurs[label] && inTheirs[label]:
+			merged = append(merged, label)
+		case !inBase[label]:
+			// Added on one side
+			merged = append(merged, label)
+		}
+		// Otherwise present in base and removed on one side
+	}
+
+	sort.Strings(merged)
+	return merged
+}
+
+// mergeLinks merges links as sets like labels, keeping a remote ID from
+// whichever side has one
+func mergeLinks(base, ours, theirs []Link) []Link {
+	byKey := make(map[string]Link)
+	keysOf := func(links []Link) []string {
+		keys := make([]string, 0, len(links))
+		for _, l := range links {
+			k := linkKey(l)
+			if existing, ok := byKey[k]; !ok || existing.ID == "" {
+				byKey[k] = l
+			}
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	var merged []Link
+	for _, k := range mergeLabels(keysOf(base), keysOf(ours), keysOf(theirs)) {
+		merged = append(merged, byKey[k])
+	}
+	return merged
+}
+
+// mergeWorklogs merges worklogs as a set, ordered by start time
+func mergeWorklogs(base, ours, theirs []Worklog) []Worklog {
+	byKey := make(map[string]Worklog)
+	keysOf := func(worklogs []Worklog) []string {
+		keys := make([]string, 0, len(worklogs))
+		for _, w := range worklogs {
+			k := worklogKey(w)
+			byKey[k] = w
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	var merged []Worklog
+	for _, k := range mergeLabels(keysOf(base), keysOf(ours), keysOf(theirs)) {
+		merged = append(merged, byKey[k])
+	}
+	return merged
+}
+
+// commentKey identifies a comment across versions of the same file.
+// Comments written before format version 3 and local ones not yet pushed have
+// no ID, so the timestamp and author are used when the ID is missing.
+func commentKey(c Comment) string {
+	if c.ID != "" {
+		return "id:" + c.ID
+	}
+	return "ts:" + c.Created.UTC().Format(time.RFC3339) + "|" + c.Author
+}
+
+// mergeComments unions comments from both sides, honoring deletions of
+// comments that the other side left untouched, and orders them by creation
+// time. A comment edited differently on both sides gets both bodies in
+// conflict markers and is returned among the conflicts.
+func mergeComments(base, ours, theirs []Comment, markerSize int) ([]Comment, []string) {
+	baseByKey := make(map[string]Comment, len(base))
+	for _, c := range base {
+		baseByKey[commentKey(c)] = c
+	}
+	oursByKey := make(map[string]Comment, len(ours))
+	for _, c := range ours {
+		oursByKey[commentKey(c)] = c
+	}
+	theirsByKey := make(map[string]Comment, len(theirs))
+	for _, c := range theirs {
+		theirsByKey[commentKey(c)] = c
+	}
+
+	var merged []Comment
+	var conflicts []string
+	seen := make(map[string]bool)
+	add := func(key string) {
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		b, inBase := baseByKey[key]
+		o, inOurs := oursByKey[key]
+		t, inTheirs := theirsByKey[key]
+
+		switch {
+		case inOurs && inTheirs:
+			switch {
+			case o.Body == t.Body || (inBase && t.Body == b.Body):
+				merged = append(merged, o)
+			case inBase && o.Body == b.Body:
+				// Edited on their side only
+				merged = append(merged, t)
+			default:
+				o.Body = conflictBlock(o.Body, t.Body, markerSize)
+				merged = append(merged, o)
+				conflicts = append(conflicts, commentConflict(o))
+			}
+		case inOurs:
+			// Deleted on their side if it existed in base unchanged
+			if !inBase || o.Body != b.Body {
+				merged = append(merged, o)
+			}
+		case inTheirs:
+			if !inBase || t.Body != b.Body {
+				merged = append(merged, t)
+			}
+		}
+	}
+
+	for _, c := range ours {
+		add(commentKey(c))
+	}
+	for _, c := range theirs {
+		add(commentKey(c))
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Created.Before(merged[j].Created)
+	})
+	return merged, conflicts
+}
+
+// commentConflict names a conflicting comment by its ID, or by its author and
+// timestamp if it has none
+func commentConflict(c Comment) string {
+	if c.ID != "" {
+		return "comment " + c.ID
+	}
+	return fmt.Sprintf("comment by %s at %s", c.Author, c.Created.UTC().Format(time.RFC3339))
+}
+
+// mergeAttachments unions attachments from both sides by URL, honoring deletions
+func mergeAttachments(base, ours, theirs []Attachment) []Attachment {
+	inBase := make(map[string]bool, len(base))
+	for _, a := range base {
+		inBase[a.URL] = true
+	}
+	inOurs := make(map[string]bool, len(ours))
+	for _, a := range ours {
+		inOurs[a.URL] = true
+	}
+	inTheirs := make(map[string]bool, len(theirs))
+	for _, a := range theirs {
+		inTheirs[a.URL] = true
+	}
+
+	var merged []Attachment
+	seen := make(map[string]bool)
+	for _, list := range [][]Attachment{ours, theirs} {
+		for _, a := range list {
+			if seen[a.URL] {
+				continue
+			}
+			seen[a.URL] = true
+			if inBase[a.URL] && !(inOurs[a.URL] && inTheirs[a.URL]) {
+				// Removed on one side
+				continue
+			}
+			merged = append(merged, a)
+		}
+	}
+	return merged
+}
+
+// conflictBlock wraps both versions of a text in git-style conflict markers
+func conflictBlock(ours, theirs string, markerSize int) string {
+	var buf strings.Builder
+	buf.WriteString(strings.Repeat("<", markerSize) + " ours\n")
+	if ours != "" {
+		buf.WriteString(ours)
+		buf.WriteString("\n")
+	}
+	buf.WriteString(strings.Repeat("=", markerSize) + "\n")
+	if theirs != "" {
+		buf.WriteString(theirs)
+		buf.WriteString("\n")
+	}
+	buf.WriteString(strings.Repeat(">", markerSize) + " theirs")
+	return buf.String()
+}
+
+// toSet converts a string slice to a set
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+// unionSets returns the union of two sets
+func unionSets(a, b map[string]bool) map[string]bool {
+	union := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		union[k] = true
+	}
+	for k := range b {
+		union[k] = true
+	}
+	return union
+}
@@ -0,0 +1,303 @@
+package issue
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mergeFixture builds an issue markdown file for merge tests
+func mergeFixture(t *testing.T, mutate func(*Issue)) string {
+	t.Helper()
+	issue := &Issue{
+		Key:         "PROJ-1",
+		RemoteID:    "10001",
+		Title:       "Original title",
+		Status:      "To Do",
+		Reporter:    "Alice",
+		Created:     time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Updated:     time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+		Labels:      []string{"backend", "bug"},
+		Hash:        "abc123",
+		Description: "Line one\n\nLine two",
+		Comments: []Comment{
+			{Author: "Alice", Body: "First comment", Created: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC)},
+		},
+	}
+	if mutate != nil {
+		mutate(issue)
+	}
+	s := &Storage{}
+	return s.issueToMarkdown(issue)
+}
+
+// parseMerged parses merge output for assertions
+func parseMerged(t *testing.T, content string) *Issue {
+	t.Helper()
+	s := &Storage{}
+	issue, err := s.parseMarkdown(content)
+	if err != nil {
+		t.Fatalf("failed to parse merged content: %v\n%s", err, content)
+	}
+	return issue
+}
+
+// TestMergeIssueFiles_Labels tests that labels are merged as sets
+func TestMergeIssueFiles_Labels(t *testing.T) {
+	base := mergeFixture(t, nil)
+	ours := mergeFixture(t, func(i *Issue) { i.Labels = []string{"backend", "bug", "urgent"} })
+	theirs := mergeFixture(t, func(i *Issue) { i.Labels = []string{"backend", "frontend"} })
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+	if result.HasConflicts() {
+		t.Fatalf("Expected no conflicts, got %v", result.Conflicts)
+	}
+
+	merged := parseMerged(t, result.Content)
+	want := []string{"backend", "frontend", "urgent"}
+	if !slices.Equal(merged.Labels, want) {
+		t.Errorf("Expected labels %v, got %v", want, merged.Labels)
+	}
+}
+
+// TestMergeIssueFiles_Comments tests that comments added on both sides are unioned
+func TestMergeIssueFiles_Comments(t *testing.T) {
+	base := mergeFixture(t, nil)
+	ours := mergeFixture(t, func(i *Issue) {
+		i.Comments = append(i.Comments, Comment{Author: "Bob", Body: "Ours", Created: time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)})
+	})
+	theirs := mergeFixture(t, func(i *Issue) {
+		i.Comments = append(i.Comments, Comment{Author: "Carol", Body: "Theirs", Created: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)})
+	})
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+
+	merged := parseMerged(t, result.Content)
+	if len(merged.Comments) != 3 {
+		t.Fatalf("Expected 3 comments, got %d", len(merged.Comments))
+	}
+	wantBodies := []string{"First comment", "Theirs", "Ours"}
+	for i, want := range wantBodies {
+		if merged.Comments[i].Body != want {
+			t.Errorf("Comment %d: expected body %q, got %q", i, want, merged.Comments[i].Body)
+		}
+	}
+}
+
+// TestMergeIssueFiles_CommentConflict tests that a comment edited differently
+// on both sides is reported and keeps both bodies
+func TestMergeIssueFiles_CommentConflict(t *testing.T) {
+	withBody := func(body string) func(*Issue) {
+		return func(i *Issue) {
+			i.Comments[0].ID = "10100"
+			i.Comments[0].Body = body
+		}
+	}
+	base := mergeFixture(t, withBody("First comment"))
+	ours := mergeFixture(t, withBody("Ours"))
+	theirs := mergeFixture(t, withBody("Theirs"))
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+	if !slices.Equal(result.Conflicts, []string{"comment 10100"}) {
+		t.Fatalf("Expected a conflict in comment 10100, got %v", result.Conflicts)
+	}
+
+	merged := parseMerged(t, result.Content)
+	if len(merged.Comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %d", len(merged.Comments))
+	}
+	want := "<<<<<<< ours\nOurs\n=======\nTheirs\n>>>>>>> theirs"
+	if merged.Comments[0].Body != want {
+		t.Errorf("Expected body %q, got %q", want, merged.Comments[0].Body)
+	}
+
+	// An edit on one side only is taken without conflict
+	result, err = MergeIssueFiles(base, base, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+	if result.HasConflicts() {
+		t.Fatalf("Expected no conflicts, got %v", result.Conflicts)
+	}
+	if got := parseMerged(t, result.Content).Comments[0].Body; got != "Theirs" {
+		t.Errorf("Expected their edit, got %q", got)
+	}
+}
+
+// TestMergeIssueFiles_Scalars tests three-way scalar resolution
+func TestMergeIssueFiles_Scalars(t *testing.T) {
+	base := mergeFixture(t, nil)
+	ours := mergeFixture(t, func(i *Issue) {
+		i.Title = "Our title"
+		i.Status = "In Progress"
+		i.Updated = time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC)
+	})
+	theirs := mergeFixture(t, func(i *Issue) {
+		i.Status = "Done"
+		i.Assignee = "Bob"
+		i.Updated = time.Date(2025, 1, 4, 10, 0, 0, 0, time.UTC)
+	})
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+
+	merged := parseMerged(t, result.Content)
+	if merged.Title != "Our title" {
+		t.Errorf("Expected title from ours, got %q", merged.Title)
+	}
+	if merged.Assignee != "Bob" {
+		t.Errorf("Expected assignee from theirs, got %q", merged.Assignee)
+	}
+	if merged.Status != "Done" {
+		t.Errorf("Expected status from later side (theirs), got %q", merged.Status)
+	}
+	if !merged.Updated.Equal(time.Date(2025, 1, 4, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected latest updated timestamp, got %v", merged.Updated)
+	}
+}
+
+// TestMergeIssueFiles_DescriptionConflict tests that only description conflicts remain
+func TestMergeIssueFiles_DescriptionConflict(t *testing.T) {
+	base := mergeFixture(t, nil)
+	ours := mergeFixture(t, func(i *Issue) { i.Description = "Our description" })
+	theirs := mergeFixture(t, func(i *Issue) { i.Description = "Their description" })
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+	if !result.HasConflicts() || result.Conflicts[0] != "description" {
+		t.Fatalf("Expected description conflict, got %v", result.Conflicts)
+	}
+
+	for _, marker := range []string{"<<<<<<< ours\nOur description\n", "=======\nTheir description\n", ">>>>>>> theirs"} {
+		if !strings.Contains(result.Content, marker) {
+			t.Errorf("Expected merged content to contain %q:\n%s", marker, result.Content)
+		}
+	}
+}
+
+// TestMergeIssueFiles_DescriptionOneSide tests that a one-sided description edit merges cleanly
+func TestMergeIssueFiles_DescriptionOneSide(t *testing.T) {
+	base := mergeFixture(t, nil)
+	ours := mergeFixture(t, nil)
+	theirs := mergeFixture(t, func(i *Issue) { i.Description = "Their description" })
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+	if result.HasConflicts() {
+		t.Fatalf("Expected no conflicts, got %v", result.Conflicts)
+	}
+
+	merged := parseMerged(t, result.Content)
+	if merged.Description != "Their description" {
+		t.Errorf("Expected description from theirs, got %q", merged.Description)
+	}
+}
+
+// TestMergeIssueFiles_EmptyBase tests merging a file added on both sides
+func TestMergeIssueFiles_EmptyBase(t *testing.T) {
+	ours := mergeFixture(t, func(i *Issue) { i.Labels = []string{"a"} })
+	theirs := mergeFixture(t, func(i *Issue) { i.Labels = []string{"b"} })
+
+	result, err := MergeIssueFiles("", ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+
+	merged := parseMerged(t, result.Content)
+	if !slices.Equal(merged.Labels, []string{"a", "b"}) {
+		t.Errorf("Expected labels [a b], got %v", merged.Labels)
+	}
+}
+
+// TestMergeIssueFiles_Custom tests that custom fields merge key by key
+func TestMergeIssueFiles_Custom(t *testing.T) {
+	base := mergeFixture(t, func(i *Issue) {
+		i.Custom = map[string]interface{}{"severity": "S2", "team": "Web", "effort": 3}
+	})
+	ours := mergeFixture(t, func(i *Issue) {
+		i.Custom = map[string]interface{}{"severity": "S1", "team": "Web", "effort": 3}
+	})
+	theirs := mergeFixture(t, func(i *Issue) {
+		i.Custom = map[string]interface{}{"severity": "S2", "effort": 3, "tags": []string{"a", "b"}}
+	})
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+
+	merged := parseMerged(t, result.Content)
+	want := `{"effort":3,"severity":"S1","tags":["a","b"]}`
+	if got := CanonicalValue(merged.Custom); got != want {
+		t.Errorf("Expected custom %s, got %s", want, got)
+	}
+}
+
+// TestMergeIssueFiles_Links tests that links merge as sets and keep remote IDs
+func TestMergeIssueFiles_Links(t *testing.T) {
+	relates := Link{Type: "Relates", Direction: LinkOutward, Key: "PROJ-9", ID: "1"}
+	base := mergeFixture(t, func(i *Issue) { i.Links = []Link{relates} })
+	ours := mergeFixture(t, func(i *Issue) {
+		i.Links = []Link{relates, {Type: "Blocks", Direction: LinkOutward, Key: "PROJ-2"}}
+	})
+	theirs := mergeFixture(t, func(i *Issue) {
+		i.Links = []Link{{Type: "Blocks", Direction: LinkOutward, Key: "PROJ-2", ID: "7"}}
+	})
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+
+	merged := parseMerged(t, result.Content)
+	want := []Link{{Type: "Blocks", Direction: LinkOutward, Key: "PROJ-2", ID: "7"}}
+	if !slices.Equal(merged.Links, want) {
+		t.Errorf("Expected links %v, got %v", want, merged.Links)
+	}
+}
+
+// TestMergeIssueFiles_Worklogs tests that worklogs logged on both sides are
+// kept and that a worklog deleted on one side stays deleted
+func TestMergeIssueFiles_Worklogs(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 9, 0, 0, 0, time.UTC) }
+	pulled := Worklog{Author: "Ann", Started: day(10), TimeSpent: 3600}
+	removed := Worklog{Author: "Ann", Started: day(11), TimeSpent: 1800}
+
+	base := mergeFixture(t, func(i *Issue) { i.Worklogs = []Worklog{pulled, removed} })
+	ours := mergeFixture(t, func(i *Issue) {
+		i.Worklogs = []Worklog{pulled, removed, {Started: day(13), TimeSpent: 2700, Comment: "Ours"}}
+	})
+	theirs := mergeFixture(t, func(i *Issue) {
+		i.Worklogs = []Worklog{pulled, {Started: day(12), TimeSpent: 600, Comment: "Theirs"}}
+	})
+
+	result, err := MergeIssueFiles(base, ours, theirs, 0)
+	if err != nil {
+		t.Fatalf("MergeIssueFiles failed: %v", err)
+	}
+
+	merged := parseMerged(t, result.Content)
+	var comments []string
+	for _, w := range merged.Worklogs {
+		comments = append(comments, w.Started.Format("02")+":"+w.Comment)
+	}
+	if got, want := strings.Join(comments, ","), "10:,12:Theirs,13:Ours"; got != want {
+		t.Errorf("Expected worklogs %q, got %q", want, got)
+	}
+}
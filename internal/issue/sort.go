@@ -0,0 +1,90 @@
+package issue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort orders accepted by SortIssues
+const (
+	SortUpdated  = "updated"  // Most recently updated first (default)
+	SortPriority = "priority" // Highest priority first
+	SortSprint   = "sprint"   // By sprint name, then priority; issues without a sprint last
+	SortDue      = "due"      // Earliest due date first; issues without one last
+	SortRank     = "rank"     // Board order; unranked issues last
+	SortKey      = "key"      // By key
+)
+
+// priorityRanks orders common priority names across trackers, highest first.
+// Unknown and empty priorities sort after all known ones.
+var priorityRanks = map[string]int{
+	"highest": 0, "blocker": 0, "urgent": 0,
+	"critical": 1,
+	"high":     2, "major": 2,
+	"medium": 3, "normal": 3,
+	"low": 4, "minor": 4,
+	"lowest": 5, "trivial": 5,
+}
+
+// PriorityRank returns the sort rank of a priority name (lower is more urgent)
+func PriorityRank(priority string) int {
+	if rank, ok := priorityRanks[strings.ToLower(strings.TrimSpace(priority))]; ok {
+		return rank
+	}
+	return len(priorityRanks)
+}
+
+// SortIssues sorts issues in place by the given order. Ties fall back to
+// most recently updated, then key, so the output is stable.
+func SortIssues(issues []*Issue, by string) error {
+	var less func(a, b *Issue) (bool, bool)
+	switch by {
+	case "", SortUpdated:
+		less = func(a, b *Issue) (bool, bool) { return false, false }
+	case SortPriority:
+		less = func(a, b *Issue) (bool, bool) {
+			ra, rb := PriorityRank(a.Priority), PriorityRank(b.Priority)
+			return ra < rb, ra != rb
+		}
+	case SortSprint:
+		less = func(a, b *Issue) (bool, bool) {
+			if a.Sprint != b.Sprint {
+				return emptyLast(a.Sprint, b.Sprint), true
+			}
+			ra, rb := PriorityRank(a.Priority), PriorityRank(b.Priority)
+			return ra < rb, ra != rb
+		}
+	case SortDue:
+		less = func(a, b *Issue) (bool, bool) {
+			return emptyLast(a.DueDate, b.DueDate), a.DueDate != b.DueDate
+		}
+	case SortRank:
+		less = func(a, b *Issue) (bool, bool) {
+			return emptyLast(a.Rank, b.Rank), a.Rank != b.Rank
+		}
+	case SortKey:
+		less = func(a, b *Issue) (bool, bool) { return a.Key < b.Key, a.Key != b.Key }
+	default:
+		return fmt.Errorf("invalid sort %q: must be one of %s, %s, %s, %s, %s, %s", by, SortUpdated, SortPriority, SortSprint, SortDue, SortRank, SortKey)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if result, decided := less(issues[i], issues[j]); decided {
+			return result
+		}
+		if !issues[i].Updated.Equal(issues[j].Updated) {
+			return issues[i].Updated.After(issues[j].Updated)
+		}
+		return issues[i].Key < issues[j].Key
+	})
+	return nil
+}
+
+// emptyLast compares two different strings ascending, with empty ones last
+func emptyLast(a, b string) bool {
+	if a == "" || b == "" {
+		return b == ""
+	}
+	return a < b
+}
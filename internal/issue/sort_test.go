@@ -0,0 +1,61 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// keys returns the keys of issues, joined for comparison
+func keys(issues []*Issue) string {
+	out := make([]string, 0, len(issues))
+	for _, i := range issues {
+		out = append(out, i.Key)
+	}
+	return strings.Join(out, ",")
+}
+
+// TestSortIssues tests each sort order, including ties and empty values
+func TestSortIssues(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixture := func() []*Issue {
+		return []*Issue{
+			{Key: "P-1", Priority: "Low", Sprint: "Sprint 2", DueDate: "2025-03-01", Rank: "0|i0000b:", Updated: base},
+			{Key: "P-2", Priority: "Highest", Sprint: "Sprint 1", Rank: "0|i0000c:", Updated: base.Add(time.Hour)},
+			{Key: "P-3", Priority: "", Sprint: "", DueDate: "2025-02-01", Updated: base.Add(2 * time.Hour)},
+			{Key: "P-4", Priority: "medium", Sprint: "Sprint 1", DueDate: "2025-02-01", Rank: "0|i0000a:", Updated: base.Add(3 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		by   string
+		want string
+	}{
+		{"", "P-4,P-3,P-2,P-1"},
+		{SortUpdated, "P-4,P-3,P-2,P-1"},
+		{SortPriority, "P-2,P-4,P-1,P-3"},
+		{SortSprint, "P-2,P-4,P-1,P-3"},
+		{SortDue, "P-4,P-3,P-1,P-2"},
+		{SortRank, "P-4,P-1,P-2,P-3"},
+		{SortKey, "P-1,P-2,P-3,P-4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			issues := fixture()
+			if err := SortIssues(issues, tt.by); err != nil {
+				t.Fatalf("SortIssues failed: %v", err)
+			}
+			if got := keys(issues); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSortIssues_Invalid tests that unknown sort orders are rejected
+func TestSortIssues_Invalid(t *testing.T) {
+	if err := SortIssues(nil, "votes"); err == nil {
+		t.Errorf("Expected error for unknown sort order")
+	}
+}
@@ -0,0 +1,917 @@
+package issue
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotFound is returned when an issue file does not exist in storage
+var ErrNotFound = errors.New("issue not found")
+
+// ErrNoIssuesDir is returned when opening storage of a project that has no
+// issues directory yet
+var ErrNoIssuesDir = errors.New("issues directory not found")
+
+// Storage handles reading and writing issue markdown files
+type Storage struct {
+	projectPath    string
+	issuesDir      string
+	attachmentsDir string
+}
+
+// NewStorage creates a new storage instance and ensures directories exist (for write operations)
+func NewStorage(projectPath string) (*Storage, error) {
+	issuesDir := filepath.Join(projectPath, ".takl", "issues")
+	attachmentsDir := filepath.Join(projectPath, ".takl", "attachments")
+
+	// Ensure directories exist
+	if err := os.MkdirAll(issuesDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create issues directory: %w", err)
+	}
+	if err := os.MkdirAll(attachmentsDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	return &Storage{
+		projectPath:    projectPath,
+		issuesDir:      issuesDir,
+		attachmentsDir: attachmentsDir,
+	}, nil
+}
+
+// OpenStorage opens existing storage for read-only operations (does not create directories)
+func OpenStorage(projectPath string) (*Storage, error) {
+	issuesDir := filepath.Join(projectPath, ".takl", "issues")
+	attachmentsDir := filepath.Join(projectPath, ".takl", "attachments")
+
+	// Verify issues directory exists
+	if st, err := os.Stat(issuesDir); err != nil || !st.IsDir() {
+		return nil, fmt.Errorf("%w at %s (have you run 'takl pull'?)", ErrNoIssuesDir, issuesDir)
+	}
+
+	return &Storage{
+		projectPath:    projectPath,
+		issuesDir:      issuesDir,
+		attachmentsDir: attachmentsDir,
+	}, nil
+}
+
+// ProjectPath returns the project root this storage belongs to
+func (s *Storage) ProjectPath() string {
+	return s.projectPath
+}
+
+// SaveIssue writes an issue to a markdown file, recording its current content
+// hash as the sync base (use after fetching from the remote)
+func (s *Storage) SaveIssue(issue *Issue) error {
+	// Compute hash before saving
+	issue.Hash = s.ComputeHash(issue)
+
+	return s.WriteIssue(issue)
+}
+
+// WriteIssue writes an issue to a markdown file as-is, preserving its hash.
+// Used for local edits, which must keep the base hash from the last sync so
+// that push can detect them.
+func (s *Storage) WriteIssue(issue *Issue) error {
+	return s.writeMarkdown(issue.Key, s.issueToMarkdown(issue))
+}
+
+// writeMarkdown writes the issue file of key atomically
+func (s *Storage) writeMarkdown(key, content string) error {
+	filePath := filepath.Join(s.issuesDir, key+".md")
+
+	// Write atomically via temp file in the same directory (for atomic rename)
+	tmpFile, err := os.CreateTemp(s.issuesDir, "."+key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	// Ensure cleanup on any error
+	defer func() {
+		if tmpFile != nil {
+			tmpFile.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	// Write content and set permissions
+	if _, err := tmpFile.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmpFile.Chmod(0600); err != nil {
+		return fmt.Errorf("failed to set temp file permissions: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	// Atomically rename temp file to final location
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	// Success - prevent cleanup from removing the file
+	tmpFile = nil
+	return nil
+}
+
+// ListIssues returns all issue keys in storage
+func (s *Storage) ListIssues() ([]string, error) {
+	entries, err := os.ReadDir(s.issuesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read issues directory: %w", err)
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			key := strings.TrimSuffix(entry.Name(), ".md")
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Fingerprint returns a token that changes whenever an issue file is added,
+// removed or modified, from the names, sizes and modification times of the files
+func (s *Storage) Fingerprint() (string, error) {
+	entries, err := os.ReadDir(s.issuesDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read issues directory: %w", err)
+	}
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// Removed since the directory was read
+			continue
+		}
+		fmt.Fprintf(hash, "%s:%d:%d;", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// DeleteIssue deletes an issue file from local storage
+func (s *Storage) DeleteIssue(key string) error {
+	filePath := filepath.Join(s.issuesDir, key+".md")
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return nil // Already deleted, not an error
+		}
+		return fmt.Errorf("failed to delete issue %s: %w", key, err)
+	}
+	return nil
+}
+
+// RenameIssue moves an issue to a new key and rewrites references to the old
+// key (parent and links) in the other issues. Files are written with
+// WriteIssue, so every issue keeps its sync base hash.
+func (s *Storage) RenameIssue(oldKey, newKey string) error {
+	renamed, err := s.ReadIssue(oldKey)
+	if err != nil {
+		return err
+	}
+	if _, err := s.ReadIssue(newKey); err == nil {
+		return fmt.Errorf("cannot rename %s: issue %s already exists", oldKey, newKey)
+	}
+
+	renamed.Key = newKey
+	if err := s.WriteIssue(renamed); err != nil {
+		return err
+	}
+	if err := s.DeleteIssue(oldKey); err != nil {
+		return err
+	}
+
+	others, err := s.ListAllIssues()
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+	for _, other := range others {
+		changed := false
+		if other.Parent == oldKey {
+			other.Parent = newKey
+			changed = true
+		}
+		for i := range other.Links {
+			if other.Links[i].Key == oldKey {
+				other.Links[i].Key = newKey
+				changed = true
+			}
+		}
+		if changed {
+			if err := s.WriteIssue(other); err != nil {
+				return fmt.Errorf("failed to update references in %s: %w", other.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadIssue reads a single issue from disk
+func (s *Storage) ReadIssue(key string) (*Issue, error) {
+	filePath := filepath.Join(s.issuesDir, key+".md")
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to read issue file: %w", err)
+	}
+
+	return s.parseMarkdown(string(data))
+}
+
+// ReadMarkdown reads the markdown of an issue file as it is on disk
+func (s *Storage) ReadMarkdown(key string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.issuesDir, key+".md"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return "", fmt.Errorf("failed to read issue file: %w", err)
+	}
+	return string(data), nil
+}
+
+// NextDraftKey returns the first unused key of the form <prefix>-<n> for a
+// new local-only issue
+func (s *Storage) NextDraftKey(prefix string) (string, error) {
+	keys, err := s.ListIssues()
+	if err != nil {
+		return "", err
+	}
+	used := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		used[strings.ToUpper(key)] = true
+	}
+	for n := 1; ; n++ {
+		key := fmt.Sprintf("%s-%d", prefix, n)
+		if !used[strings.ToUpper(key)] {
+			return key, nil
+		}
+	}
+}
+
+// IssueFilter defines filtering criteria for issues
+type IssueFilter struct {
+	Status     string   // Filter by status (empty = all)
+	Assignee   string   // Filter by assignee (empty = all)
+	Labels     []string // Filter by labels (empty = all, must match ALL provided labels)
+	Search     string   // Search in title and description (empty = no search)
+	Type       string   // Filter by issue type (empty = all)
+	Priority   string   // Filter by priority (empty = all)
+	Component  string   // Filter by component (empty = all)
+	FixVersion string   // Filter by fix version (empty = all)
+	Sprint     string   // Filter by sprint (empty = all)
+	Parent     string   // Filter by parent or epic key (empty = all)
+}
+
+// ListAllIssues returns all issues with their metadata
+func (s *Storage) ListAllIssues() ([]*Issue, error) {
+	return s.ListFilteredIssues(IssueFilter{})
+}
+
+// ListFilteredIssues returns issues matching the provided filter
+func (s *Storage) ListFilteredIssues(filter IssueFilter) ([]*Issue, error) {
+	keys, err := s.ListIssues()
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]*Issue, 0, len(keys))
+	for _, key := range keys {
+		issue, err := s.ReadIssue(key)
+		if err != nil {
+			// Log but don't fail the entire list
+			fmt.Fprintf(os.Stderr, "Warning: failed to read issue %s: %v\n", key, err)
+			continue
+		}
+
+		// Apply filters
+		if !s.matchesFilter(issue, filter) {
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// matchesFilter checks if an issue matches the filter criteria
+func (s *Storage) matchesFilter(issue *Issue, filter IssueFilter) bool {
+	// Status filter
+	if filter.Status != "" && !strings.EqualFold(issue.Status, filter.Status) {
+		return false
+	}
+
+	// Assignee filter - case-insensitive substring match (works for name or email)
+	if a := strings.TrimSpace(filter.Assignee); a != "" {
+		al := strings.ToLower(a)
+		matched := false
+		for _, assignee := range append([]string{issue.Assignee}, issue.Assignees...) {
+			il := strings.ToLower(assignee)
+			if il != "" && (il == al || strings.Contains(il, al)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	// Labels filter (must have ALL specified labels)
+	if len(filter.Labels) > 0 {
+		issueLabels := make(map[string]bool)
+		for _, label := range issue.Labels {
+			issueLabels[strings.ToLower(label)] = true
+		}
+		for _, filterLabel := range filter.Labels {
+			if !issueLabels[strings.ToLower(filterLabel)] {
+				return false
+			}
+		}
+	}
+
+	// Planning filters (case-insensitive exact match)
+	if filter.Type != "" && !strings.EqualFold(issue.Type, filter.Type) {
+		return false
+	}
+	if filter.Priority != "" && !strings.EqualFold(issue.Priority, filter.Priority) {
+		return false
+	}
+	if filter.Sprint != "" && !strings.EqualFold(issue.Sprint, filter.Sprint) {
+		return false
+	}
+	if filter.Parent != "" && !strings.EqualFold(issue.Parent, filter.Parent) {
+		return false
+	}
+	if filter.Component != "" && !containsFold(issue.Components, filter.Component) {
+		return false
+	}
+	if filter.FixVersion != "" && !containsFold(issue.FixVersions, filter.FixVersion) {
+		return false
+	}
+
+	// Search filter (case-insensitive search in title and description)
+	if filter.Search != "" {
+		searchLower := strings.ToLower(filter.Search)
+		titleLower := strings.ToLower(issue.Title)
+		descLower := strings.ToLower(issue.Description)
+		if !strings.Contains(titleLower, searchLower) && !strings.Contains(descLower, searchLower) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsFold reports whether values contains v, ignoring case
+func containsFold(values []string, v string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMarkdown parses a markdown file into an Issue struct, upgrading files
+// of older format versions in memory first (see MigrateMarkdown)
+func (s *Storage) parseMarkdown(content string) (*Issue, error) {
+	// Normalize newlines (handle CRLF)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	// Expect frontmatter between --- markers
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, fmt.Errorf("invalid issue file: missing frontmatter")
+	}
+
+	// Find end of frontmatter
+	if !strings.Contains(content[4:], "\n---\n") {
+		return nil, fmt.Errorf("invalid issue file: malformed frontmatter")
+	}
+
+	content, _, err := MigrateMarkdown(content)
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, body, _ := splitFrontmatter(content)
+
+	// Parse frontmatter
+	var issue Issue
+	if err := yaml.Unmarshal([]byte(frontmatter), &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	// Parse body sections
+	s.parseBody(&issue, body)
+
+	return &issue, nil
+}
+
+// parseBody parses the markdown body into description, comments, and attachments
+// Recognizes setext-style headings (underlined with = or -)
+func (s *Storage) parseBody(issue *Issue, body string) {
+	for _, sec := range splitSections(body) {
+		if sec.title == "" {
+			continue
+		}
+		t := strings.TrimSpace(strings.Join(sec.lines[2:], "\n"))
+		switch sec.title {
+		case "Description":
+			issue.Description = t
+		case "Comments":
+			s.parseComments(issue, t)
+		case "Worklog":
+			s.parseWorklogs(issue, t)
+		case "Attachments":
+			s.parseAttachments(issue, t)
+		}
+	}
+}
+
+// section is a part of an issue file body under a setext heading
+type section struct {
+	title string   // Heading text; empty for the text before the first heading
+	line  int      // 0-based line of the heading in the body
+	lines []string // The heading, its underline and the content
+}
+
+// splitSections splits an issue file body at its setext headings (a line
+// followed by a line of = or -). Comments fenced by markers are kept whole, so
+// their bodies may contain anything.
+func splitSections(body string) []section {
+	lines := strings.Split(body, "\n")
+	sections := []section{{}}
+	for i := 0; i < len(lines); i++ {
+		last := &sections[len(sections)-1]
+		if last.title == "Comments" && isCommentStart(lines[i]) {
+			if end := commentEnd(lines, i+1); end != -1 {
+				last.lines = append(last.lines, lines[i:end+1]...)
+				i = end
+				continue
+			}
+		}
+		if i+1 < len(lines) && len(lines[i+1]) > 0 && (isAllChars(lines[i+1], '=') || isAllChars(lines[i+1], '-')) {
+			sections = append(sections, section{
+				title: strings.TrimSpace(lines[i]),
+				line:  i,
+				lines: []string{lines[i], lines[i+1]},
+			})
+			i++
+			continue
+		}
+		last.lines = append(last.lines, lines[i])
+	}
+	return sections
+}
+
+// isAllChars checks if a string consists entirely of a specific character (and optional whitespace)
+func isAllChars(s string, ch rune) bool {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		if r != ch {
+			return false
+		}
+	}
+	return true
+}
+
+// parseWorklogs parses the worklog section
+// Format: ## Logged <duration> [by <author>] at <timestamp>, followed by the comment
+func (s *Storage) parseWorklogs(issue *Issue, content string) {
+	for _, ws := range strings.Split("\n"+content, "\n## Logged ") {
+		ws = strings.TrimSpace(ws)
+		if ws == "" {
+			continue
+		}
+
+		header, comment, _ := strings.Cut(ws, "\n")
+		at := strings.LastIndex(header, " at ")
+		if at == -1 {
+			continue
+		}
+		started, err := time.Parse(time.RFC3339, header[at+4:])
+		if err != nil {
+			continue
+		}
+
+		duration, author, _ := strings.Cut(header[:at], " by ")
+		seconds, err := ParseWorkDuration(duration)
+		if err != nil {
+			continue
+		}
+
+		issue.Worklogs = append(issue.Worklogs, Worklog{
+			Author:    author,
+			Started:   started,
+			TimeSpent: seconds,
+			Comment:   strings.TrimSpace(comment),
+		})
+	}
+}
+
+// parseAttachments parses the attachments section
+// Format: - [filename](url) (size bytes, timestamp)
+// Handles URLs with parentheses by counting balanced parentheses
+func (s *Storage) parseAttachments(issue *Issue, content string) {
+	lines := strings.Split(content, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- [") && !strings.HasPrefix(line, "-    [") {
+			continue
+		}
+
+		// Extract filename: find [ and ]
+		filenameStart := strings.Index(line, "[")
+		if filenameStart == -1 {
+			continue
+		}
+		filenameEnd := strings.Index(line[filenameStart:], "]")
+		if filenameEnd == -1 {
+			continue
+		}
+		filenameEnd += filenameStart
+
+		filename := line[filenameStart+1 : filenameEnd]
+		if filename == "" {
+			// Empty filename - skip unless there's a URL
+			// (allow for edge case testing)
+		}
+
+		// Check for ]( after filename
+		if filenameEnd+2 >= len(line) || line[filenameEnd:filenameEnd+2] != "](" {
+			continue
+		}
+
+		// Extract URL by finding matching closing parenthesis
+		urlStart := filenameEnd + 2
+		urlEnd := findMatchingParen(line, urlStart)
+		if urlEnd == -1 {
+			continue
+		}
+
+		url := line[urlStart:urlEnd]
+		if url == "" {
+			continue
+		}
+
+		// Skip if both filename and URL are empty
+		if filename == "" && url == "" {
+			continue
+		}
+
+		attachment := Attachment{Filename: filename, URL: url}
+		if m := attachmentInfoRegex.FindStringSubmatch(line[urlEnd+1:]); m != nil {
+			attachment.Size, _ = strconv.ParseInt(m[1], 10, 64)
+			attachment.Created, _ = time.Parse(time.RFC3339, m[2])
+		}
+		issue.Attachments = append(issue.Attachments, attachment)
+	}
+}
+
+// attachmentInfoRegex matches the size and time after an attachment link
+var attachmentInfoRegex = regexp.MustCompile(`^\s*\((\d+) bytes, ([^)]*)\)`)
+
+// findMatchingParen finds the position of the closing parenthesis that matches
+// the opening parenthesis at position start-1, handling nested parentheses.
+// Returns -1 if no matching parenthesis is found.
+func findMatchingParen(s string, start int) int {
+	depth := 1 // We're already inside one level of parens
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// ReadExistingHash reads the hash field from an existing issue file.
+// Returns the hash and true if found, or empty string and false if not found or on error.
+func (s *Storage) ReadExistingHash(key string) (string, bool) {
+	filePath := filepath.Join(s.issuesDir, key+".md")
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", false
+	}
+
+	// Quick parse: look for "hash: " in the frontmatter
+	content := string(data)
+	// Find the frontmatter section (between --- markers)
+	if !strings.HasPrefix(content, "---\n") {
+		return "", false
+	}
+
+	endIdx := strings.Index(content[4:], "\n---\n")
+	if endIdx == -1 {
+		return "", false
+	}
+
+	frontmatter := content[4 : 4+endIdx]
+
+	// Parse YAML frontmatter for hash field
+	var fm struct {
+		Hash string `yaml:"hash"`
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		return "", false
+	}
+
+	return fm.Hash, fm.Hash != ""
+}
+
+// issueToMarkdown converts an Issue to markdown format
+func (s *Storage) issueToMarkdown(issue *Issue) string {
+	var buf strings.Builder
+
+	// Write frontmatter
+	buf.WriteString("---\n")
+
+	// Marshal frontmatter (excluding Description and Comments)
+	frontmatter := map[string]interface{}{
+		"jira_key": issue.Key,
+		"jira_id":  issue.RemoteID,
+		"title":    issue.Title,
+		"status":   issue.Status,
+		"reporter": issue.Reporter,
+		"created":  issue.Created.Format(time.RFC3339),
+		"updated":  issue.Updated.Format(time.RFC3339),
+		"hash":     issue.Hash,
+
+		"format_version": FormatVersion,
+	}
+
+	if issue.Assignee != "" {
+		frontmatter["assignee"] = issue.Assignee
+	}
+
+	if len(issue.Labels) > 0 {
+		// Sort labels for stable output and reduced diff noise
+		frontmatter["labels"] = NormalizeLabels(issue.Labels)
+	}
+
+	if len(issue.Assignees) > 0 {
+		frontmatter["assignees"] = NormalizeLabels(issue.Assignees)
+	}
+
+	if issue.Milestone != "" {
+		frontmatter["milestone"] = issue.Milestone
+	}
+
+	// Planning fields, only when set
+	for key, value := range map[string]string{
+		"type":     issue.Type,
+		"priority": issue.Priority,
+		"sprint":   issue.Sprint,
+		"rank":     issue.Rank,
+		"due_date": issue.DueDate,
+		"parent":   issue.Parent,
+
+		"original_estimate":  issue.OriginalEstimate,
+		"remaining_estimate": issue.RemainingEstimate,
+	} {
+		if value != "" {
+			frontmatter[key] = value
+		}
+	}
+	if len(issue.Components) > 0 {
+		frontmatter["components"] = NormalizeLabels(issue.Components)
+	}
+	if len(issue.FixVersions) > 0 {
+		frontmatter["fix_versions"] = NormalizeLabels(issue.FixVersions)
+	}
+	if issue.StoryPoints != 0 {
+		frontmatter["story_points"] = issue.StoryPoints
+	}
+	if len(issue.Custom) > 0 {
+		frontmatter["custom"] = issue.Custom
+	}
+	if len(issue.Links) > 0 {
+		links := append([]Link(nil), issue.Links...)
+		sortLinks(links)
+		frontmatter["links"] = links
+	}
+
+	yamlData, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		// This should never happen with our simple data types, but handle it gracefully
+		return fmt.Sprintf("ERROR: failed to marshal frontmatter: %v\n", err)
+	}
+	buf.Write(yamlData)
+	buf.WriteString("---\n\n")
+
+	// Write description (setext-style heading)
+	buf.WriteString("Description\n")
+	buf.WriteString("===========\n\n")
+	if issue.Description != "" {
+		buf.WriteString(issue.Description)
+		buf.WriteString("\n\n")
+	}
+
+	// Write comments (setext-style heading)
+	if len(issue.Comments) > 0 {
+		buf.WriteString("Comments\n")
+		buf.WriteString("========\n\n")
+		for _, comment := range issue.Comments {
+			writeComment(&buf, comment)
+		}
+	}
+
+	// Write worklogs (setext-style heading)
+	if len(issue.Worklogs) > 0 {
+		buf.WriteString("Worklog\n")
+		buf.WriteString("=======\n\n")
+		for _, w := range issue.Worklogs {
+			by := ""
+			if w.Author != "" {
+				by = " by " + w.Author
+			}
+			buf.WriteString(fmt.Sprintf("## Logged %s%s at %s\n\n",
+				FormatWorkDuration(w.TimeSpent),
+				by,
+				w.Started.Format(time.RFC3339)))
+			if w.Comment != "" {
+				buf.WriteString(w.Comment)
+				buf.WriteString("\n\n")
+			}
+		}
+	}
+
+	// Write attachments (setext-style heading)
+	if len(issue.Attachments) > 0 {
+		buf.WriteString("Attachments\n")
+		buf.WriteString("===========\n\n")
+		for _, att := range issue.Attachments {
+			buf.WriteString(fmt.Sprintf("- [%s](%s) (%d bytes, %s)\n",
+				att.Filename,
+				att.URL,
+				att.Size,
+				att.Created.Format(time.RFC3339)))
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+// NormalizeLabels returns a sorted copy of the labels slice.
+// This ensures consistent ordering for storage, API updates, and hashing.
+func NormalizeLabels(labels []string) []string {
+	if len(labels) == 0 {
+		return labels
+	}
+	normalized := make([]string, len(labels))
+	copy(normalized, labels)
+	sort.Strings(normalized)
+	return normalized
+}
+
+// CanonicalValue returns the JSON encoding of a frontmatter value, so values
+// decoded from YAML (int, []interface{}) compare equal to the ones a bridge
+// produced (float64, []string). Map keys are sorted by encoding/json.
+func CanonicalValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// ComputeHash calculates SHA256 hash of issue content for conflict detection.
+//
+// Included fields: Key, Title, Description, Status, Labels, Comments,
+// Assignees, Milestone, Type, Priority, Components, FixVersions, DueDate,
+// Parent, StoryPoints, OriginalEstimate, RemainingEstimate, Custom, Links,
+// Worklogs (only when set, so existing hashes stay stable) and Assignee (see
+// chainAssignee)
+// Excluded fields: Attachments (metadata only),
+//
+//	Sprint (moves when sprints are closed), Rank (changes as other issues are ranked),
+//	Created/Updated timestamps, Hash itself
+//
+// This hash is used to detect when both local and remote copies have been modified
+// since the last sync, allowing three-way merge conflict detection.
+func (s *Storage) ComputeHash(issue *Issue) string {
+	// Create a canonical representation for hashing
+	var buf strings.Builder
+
+	buf.WriteString(issue.Key)
+	buf.WriteString("|")
+	buf.WriteString(issue.Title)
+	buf.WriteString("|")
+	buf.WriteString(issue.Description)
+	buf.WriteString("|")
+	buf.WriteString(issue.Status)
+	buf.WriteString("|")
+
+	// Sort labels for consistent hashing
+	buf.WriteString(strings.Join(NormalizeLabels(issue.Labels), ","))
+	buf.WriteString("|")
+
+	// Include comments
+	for _, comment := range issue.Comments {
+		buf.WriteString(comment.Author)
+		buf.WriteString(":")
+		buf.WriteString(comment.Body)
+		buf.WriteString(";")
+	}
+
+	// Optional fields are appended only when present so that hashes of
+	// issues that don't use them are unchanged
+	if len(issue.Assignees) > 0 {
+		buf.WriteString("|assignees:")
+		buf.WriteString(strings.Join(NormalizeLabels(issue.Assignees), ","))
+	}
+	if issue.Milestone != "" {
+		buf.WriteString("|milestone:")
+		buf.WriteString(issue.Milestone)
+	}
+	optional := []struct{ name, value string }{
+		{"type", issue.Type},
+		{"priority", issue.Priority},
+		{"components", strings.Join(NormalizeLabels(issue.Components), ",")},
+		{"fix_versions", strings.Join(NormalizeLabels(issue.FixVersions), ",")},
+		{"due_date", issue.DueDate},
+		{"parent", issue.Parent},
+		{"original_estimate", issue.OriginalEstimate},
+		{"remaining_estimate", issue.RemainingEstimate},
+	}
+	if issue.StoryPoints != 0 {
+		optional = append(optional, struct{ name, value string }{"story_points", strconv.FormatFloat(issue.StoryPoints, 'g', -1, 64)})
+	}
+	if len(issue.Custom) > 0 {
+		optional = append(optional, struct{ name, value string }{"custom", CanonicalValue(issue.Custom)})
+	}
+	if len(issue.Links) > 0 {
+		// Remote link IDs are left out so pushing a new link doesn't change the hash
+		keys := make([]string, 0, len(issue.Links))
+		for _, l := range issue.Links {
+			keys = append(keys, linkKey(l))
+		}
+		sort.Strings(keys)
+		optional = append(optional, struct{ name, value string }{"links", strings.Join(keys, ",")})
+	}
+	if len(issue.Worklogs) > 0 {
+		// Start times in seconds and time spent in minutes, as stored in markdown
+		entries := make([]string, 0, len(issue.Worklogs))
+		for _, w := range issue.Worklogs {
+			entries = append(entries, fmt.Sprintf("%s@%s:%d:%s", w.Author, w.Started.UTC().Format(time.RFC3339), w.TimeSpent/workMinute, w.Comment))
+		}
+		optional = append(optional, struct{ name, value string }{"worklogs", strings.Join(entries, ";")})
+	}
+	for _, field := range optional {
+		if field.value != "" {
+			buf.WriteString("|" + field.name + ":")
+			buf.WriteString(field.value)
+		}
+	}
+
+	hash := sha256.Sum256([]byte(buf.String()))
+	return chainAssignee(fmt.Sprintf("%x", hash), issue.Assignee)
+}
+
+// chainAssignee folds the assignee into the hash of the other fields. It is
+// hashed on top of them rather than with them so that the hashes recorded by
+// format version 3, which left it out, can be upgraded without the content
+// they were computed from (see migrateV3).
+func chainAssignee(hash, assignee string) string {
+	if assignee == "" {
+		return hash
+	}
+	sum := sha256.Sum256([]byte(hash + "|assignee:" + assignee))
+	return fmt.Sprintf("%x", sum)
+}
@@ -1,6 +1,8 @@
-package jira
+package issue
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -353,3 +355,237 @@ Looks good!`
 		}
 	}
 }
+
+// TestReadIssue_NotFound tests that missing issues are reported with ErrNotFound
+func TestReadIssue_NotFound(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	_, err = s.ReadIssue("PROJ-404")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestRenameIssue tests that renaming moves the file, rewrites parent and link
+// references in other issues, and keeps every base hash
+func TestRenameIssue(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	issues := []*Issue{
+		{Key: "NEW-1", Title: "Draft"},
+		{Key: "PROJ-1", Title: "Child", Parent: "NEW-1", Hash: "base-1"},
+		{Key: "PROJ-2", Title: "Blocked", Hash: "base-2", Links: []Link{{Type: "Blocks", Direction: LinkInward, Key: "NEW-1"}}},
+	}
+	for _, i := range issues {
+		if err := s.WriteIssue(i); err != nil {
+			t.Fatalf("WriteIssue failed: %v", err)
+		}
+	}
+
+	if err := s.RenameIssue("NEW-1", "PROJ-3"); err != nil {
+		t.Fatalf("RenameIssue failed: %v", err)
+	}
+
+	if _, err := s.ReadIssue("NEW-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected NEW-1 to be gone, got %v", err)
+	}
+	if renamed, err := s.ReadIssue("PROJ-3"); err != nil || renamed.Title != "Draft" {
+		t.Errorf("Expected PROJ-3 to be the renamed draft, got %+v (err: %v)", renamed, err)
+	}
+	child, err := s.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if child.Parent != "PROJ-3" || child.Hash != "base-1" {
+		t.Errorf("Expected parent PROJ-3 and hash base-1, got %q and %q", child.Parent, child.Hash)
+	}
+	blocked, err := s.ReadIssue("PROJ-2")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if blocked.Links[0].Key != "PROJ-3" || blocked.Hash != "base-2" {
+		t.Errorf("Expected link to PROJ-3 and hash base-2, got %q and %q", blocked.Links[0].Key, blocked.Hash)
+	}
+
+	if err := s.RenameIssue("PROJ-3", "PROJ-1"); err == nil {
+		t.Errorf("Expected renaming onto an existing issue to fail")
+	}
+}
+
+// TestFingerprint tests that the fingerprint changes when issue files are
+// added, edited or removed, and only then
+func TestFingerprint(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	fingerprint := func() string {
+		t.Helper()
+		f, err := s.Fingerprint()
+		if err != nil {
+			t.Fatalf("Fingerprint failed: %v", err)
+		}
+		return f
+	}
+
+	empty := fingerprint()
+	if err := s.WriteIssue(&Issue{Key: "PROJ-1", Title: "First"}); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+	added := fingerprint()
+	if added == empty {
+		t.Errorf("Expected fingerprint to change when an issue is added")
+	}
+	if again := fingerprint(); again != added {
+		t.Errorf("Expected stable fingerprint, got %s and %s", added, again)
+	}
+
+	if err := s.WriteIssue(&Issue{Key: "PROJ-1", Title: "First, edited"}); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+	edited := fingerprint()
+	if edited == added {
+		t.Errorf("Expected fingerprint to change when an issue is edited")
+	}
+
+	if err := s.DeleteIssue("PROJ-1"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if removed := fingerprint(); removed != empty {
+		t.Errorf("Expected the empty fingerprint after removing the issue, got %s", removed)
+	}
+}
+
+// TestPlanningFields_RoundTrip tests that planning fields survive a save and read
+func TestPlanningFields_RoundTrip(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	want := &Issue{
+		Key:         "PROJ-1",
+		Title:       "Planned",
+		Status:      "To Do",
+		Type:        "Story",
+		Priority:    "High",
+		Components:  []string{"UI", "API"},
+		FixVersions: []string{"1.2"},
+		Sprint:      "Sprint 12",
+		DueDate:     "2025-03-31",
+		Parent:      "PROJ-100",
+		StoryPoints: 2.5,
+	}
+	if err := s.SaveIssue(want); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+
+	got, err := s.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if got.Type != want.Type || got.Priority != want.Priority || got.Sprint != want.Sprint ||
+		got.DueDate != want.DueDate || got.Parent != want.Parent || got.StoryPoints != want.StoryPoints {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if !reflect.DeepEqual(got.Components, []string{"API", "UI"}) || !reflect.DeepEqual(got.FixVersions, []string{"1.2"}) {
+		t.Errorf("Expected sorted components and fix versions, got %v and %v", got.Components, got.FixVersions)
+	}
+}
+
+// TestComputeHash_PlanningFields tests that unset planning fields leave the hash
+// unchanged, set ones change it, and the sprint is ignored
+func TestComputeHash_PlanningFields(t *testing.T) {
+	s := &Storage{}
+	base := Issue{Key: "PROJ-1", Title: "Title", Status: "To Do"}
+	baseHash := s.ComputeHash(&base)
+
+	sprint := base
+	sprint.Sprint = "Sprint 12"
+	if s.ComputeHash(&sprint) != baseHash {
+		t.Errorf("Expected sprint to be excluded from the hash")
+	}
+
+	for name, edit := range map[string]func(*Issue){
+		"type":         func(i *Issue) { i.Type = "Bug" },
+		"priority":     func(i *Issue) { i.Priority = "High" },
+		"components":   func(i *Issue) { i.Components = []string{"API"} },
+		"fix_versions": func(i *Issue) { i.FixVersions = []string{"1.2"} },
+		"due_date":     func(i *Issue) { i.DueDate = "2025-03-31" },
+		"parent":       func(i *Issue) { i.Parent = "PROJ-100" },
+		"story_points": func(i *Issue) { i.StoryPoints = 3 },
+	} {
+		edited := base
+		edit(&edited)
+		if s.ComputeHash(&edited) == baseHash {
+			t.Errorf("Expected %s to change the hash", name)
+		}
+	}
+}
+
+// TestMatchesFilter_PlanningFields tests the type, priority, component, fix
+// version, sprint and parent filters
+func TestMatchesFilter_PlanningFields(t *testing.T) {
+	s := &Storage{}
+	planned := &Issue{
+		Key:         "PROJ-1",
+		Type:        "Bug",
+		Priority:    "High",
+		Components:  []string{"API", "UI"},
+		FixVersions: []string{"1.2"},
+		Sprint:      "Sprint 12",
+		Parent:      "PROJ-100",
+	}
+
+	tests := []struct {
+		name   string
+		filter IssueFilter
+		want   bool
+	}{
+		{"type", IssueFilter{Type: "bug"}, true},
+		{"other type", IssueFilter{Type: "Story"}, false},
+		{"priority", IssueFilter{Priority: "HIGH"}, true},
+		{"component", IssueFilter{Component: "ui"}, true},
+		{"missing component", IssueFilter{Component: "Docs"}, false},
+		{"fix version", IssueFilter{FixVersion: "1.2"}, true},
+		{"sprint", IssueFilter{Sprint: "sprint 12"}, true},
+		{"other sprint", IssueFilter{Sprint: "Sprint 1"}, false},
+		{"parent", IssueFilter{Parent: "proj-100"}, true},
+		{"combined", IssueFilter{Type: "Bug", Priority: "Low"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.matchesFilter(planned, tt.filter); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestNextDraftKey tests that draft keys skip the keys in use
+func TestNextDraftKey(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+	for _, key := range []string{"DRAFT-1", "DRAFT-3"} {
+		if err := s.WriteIssue(&Issue{Key: key, Title: key}); err != nil {
+			t.Fatalf("WriteIssue failed: %v", err)
+		}
+	}
+
+	key, err := s.NextDraftKey("DRAFT")
+	if err != nil {
+		t.Fatalf("NextDraftKey failed: %v", err)
+	}
+	if key != "DRAFT-2" {
+		t.Errorf("Expected DRAFT-2, got %s", key)
+	}
+}
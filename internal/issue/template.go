@@ -0,0 +1,239 @@
+package issue
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateKey is the frontmatter key of a template's own settings, which are
+// removed from the issues made from it
+const templateKey = "template"
+
+// Template is an issue template from .takl/templates/<name>.md: an issue file
+// with the frontmatter defaults and description skeleton of new issues.
+//
+// The frontmatter may hold a template block, and text/template variables
+// (see TemplateVars) anywhere:
+//
+//	---
+//	template:
+//	  description: Bug report
+//	  bridge: jira
+//	title: "Bug: "
+//	type: Bug
+//	labels: [bug]
+//	---
+//
+//	Description
+//	===========
+//
+//	Reported by {{.Me}} on {{.Today}}
+type Template struct {
+	Name        string `json:"name"`                  // File name without .md
+	Description string `json:"description,omitempty"` // Shown when listing templates
+	Bridge      string `json:"bridge,omitempty"`      // Only offered for projects on this bridge if set
+	content     string
+}
+
+// TemplateVars are the variables available in templates
+type TemplateVars struct {
+	Key   string // Key of the new issue
+	Today string // Today as YYYY-MM-DD
+	Me    string // The current user, as an assignee or reporter
+}
+
+// templateSettings is the template block of a template's frontmatter
+type templateSettings struct {
+	Description string `yaml:"description"`
+	Bridge      string `yaml:"bridge"`
+}
+
+// ErrTemplateNotFound is returned when a project has no template by a name
+var ErrTemplateNotFound = errors.New("template not found")
+
+// templatesDir returns the directory of a project's templates
+func templatesDir(projectPath string) string {
+	return filepath.Join(projectPath, ".takl", "templates")
+}
+
+// LoadTemplates loads the templates of a project, sorted by name. Templates
+// bound to another bridge than bridgeType are left out unless bridgeType is
+// empty. A project without templates has none.
+func LoadTemplates(projectPath, bridgeType string) ([]*Template, error) {
+	paths, err := filepath.Glob(filepath.Join(templatesDir(projectPath), "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	sort.Strings(paths)
+
+	templates := make([]*Template, 0, len(paths))
+	for _, path := range paths {
+		t, err := readTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		if bridgeType == "" || t.Bridge == "" || t.Bridge == bridgeType {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+// LoadTemplate loads a template of a project by name
+func LoadTemplate(projectPath, name string) (*Template, error) {
+	if !ValidKey(name) {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	t, err := readTemplate(filepath.Join(templatesDir(projectPath), name+".md"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return t, err
+}
+
+// readTemplate reads and checks a template file
+func readTemplate(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	t := &Template{
+		Name:    strings.TrimSuffix(filepath.Base(path), ".md"),
+		content: strings.ReplaceAll(string(data), "\r\n", "\n"),
+	}
+	frontmatter, _, err := splitTemplate(t.content)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	if node := mappingValue(frontmatter, templateKey); node != nil {
+		var settings templateSettings
+		if err := node.Decode(&settings); err != nil {
+			return nil, fmt.Errorf("template %s: invalid template block: %w", t.Name, err)
+		}
+		t.Description, t.Bridge = settings.Description, settings.Bridge
+	}
+	if _, err := template.New(t.Name).Parse(t.content); err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	return t, nil
+}
+
+// DefaultTemplate returns the template used for new issues when none is given:
+// a title, the status and, for Jira, the type
+func DefaultTemplate(bridgeType, status string) *Template {
+	var b strings.Builder
+	b.WriteString("---\ntitle: \"\"\n")
+	fmt.Fprintf(&b, "status: %s\n", yamlString(status))
+	if bridgeType == BridgeJira {
+		b.WriteString("type: Task\n")
+	}
+	b.WriteString("# Optional: assignee, labels, priority, parent, due_date, components, fix_versions\n")
+	b.WriteString("---\n\nDescription\n===========\n\n")
+	return &Template{Name: "default", content: b.String()}
+}
+
+// Instantiate returns the markdown of a new issue made from the template: the
+// variables expanded, the template block removed, jira_key set to vars.Key,
+// and title and status (defaulting to status) added where the template has none
+func (t *Template) Instantiate(vars TemplateVars, status string) (string, error) {
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(t.content)
+	if err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	var expanded bytes.Buffer
+	if err := tmpl.Execute(&expanded, vars); err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+
+	frontmatter, body, err := splitTemplate(expanded.String())
+	if err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+
+	// jira_key first, then the template's fields in its order
+	fields := []*yaml.Node{scalarNode("jira_key"), scalarNode(vars.Key)}
+	seen := make(map[string]bool)
+	for n := 0; n+1 < len(frontmatter.Content); n += 2 {
+		key := frontmatter.Content[n].Value
+		if key == templateKey || key == "jira_key" {
+			continue
+		}
+		seen[key] = true
+		fields = append(fields, frontmatter.Content[n], frontmatter.Content[n+1])
+	}
+	if !seen["title"] {
+		fields = append(fields, scalarNode("title"), &yaml.Node{Kind: yaml.ScalarNode, Value: "", Style: yaml.DoubleQuotedStyle})
+	}
+	if !seen["status"] {
+		fields = append(fields, scalarNode("status"), scalarNode(status))
+	}
+	frontmatter.Content = fields
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(frontmatter); err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	if strings.TrimSpace(body) == "" {
+		body = "\nDescription\n===========\n\n"
+	}
+	return "---\n" + out.String() + "---\n" + body, nil
+}
+
+// splitTemplate splits template content into its frontmatter mapping and body
+func splitTemplate(content string) (*yaml.Node, string, error) {
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, "", errors.New("missing frontmatter")
+	}
+	raw, body, ok := strings.Cut(content[4:], "\n---\n")
+	if !ok {
+		return nil, "", errors.New("malformed frontmatter")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		// Comments only
+		return &yaml.Node{Kind: yaml.MappingNode, HeadComment: doc.HeadComment}, body, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, "", errors.New("frontmatter is not a mapping")
+	}
+	return doc.Content[0], body, nil
+}
+
+// mappingValue returns the value of key in a mapping node, if present
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for n := 0; n+1 < len(mapping.Content); n += 2 {
+		if mapping.Content[n].Value == key {
+			return mapping.Content[n+1]
+		}
+	}
+	return nil
+}
+
+// scalarNode returns a YAML string node
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// yamlString formats a string as a YAML scalar, quoted where needed
+func yamlString(s string) string {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
@@ -0,0 +1,125 @@
+package issue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTemplate writes .takl/templates/<name>.md in a project
+func writeTemplate(t *testing.T, projectPath, name, content string) {
+	t.Helper()
+	dir := templatesDir(projectPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".md"), []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+const bugTemplate = `---
+template:
+  description: Bug report
+  bridge: jira
+title: "Bug: "
+type: Bug
+labels: [bug]
+assignee: "{{.Me}}"
+---
+
+Description
+===========
+
+## Steps to reproduce
+
+Reported on {{.Today}}
+`
+
+// TestLoadTemplates tests listing templates, leaving out those bound to
+// other bridges
+func TestLoadTemplates(t *testing.T) {
+	projectPath := t.TempDir()
+	if templates, err := LoadTemplates(projectPath, BridgeJira); err != nil || len(templates) != 0 {
+		t.Fatalf("Expected no templates, got %v (%v)", templates, err)
+	}
+
+	writeTemplate(t, projectPath, "bug", bugTemplate)
+	writeTemplate(t, projectPath, "spike", "---\ntitle: \"Spike: \"\n---\n")
+
+	templates, err := LoadTemplates(projectPath, BridgeJira)
+	if err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "bug" || templates[0].Description != "Bug report" || templates[0].Bridge != BridgeJira {
+		t.Errorf("Expected bug and spike templates, got %+v", templates)
+	}
+
+	templates, err = LoadTemplates(projectPath, BridgeGitHub)
+	if err != nil {
+		t.Fatalf("LoadTemplates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "spike" {
+		t.Errorf("Expected only the spike template for GitHub, got %+v", templates)
+	}
+
+	if _, err := LoadTemplate(projectPath, "incident"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+	}
+}
+
+// TestTemplate_Instantiate tests that variables are expanded, the template
+// block removed and the key and status added
+func TestTemplate_Instantiate(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTemplate(t, projectPath, "bug", bugTemplate)
+	tmpl, err := LoadTemplate(projectPath, "bug")
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+
+	vars := TemplateVars{Key: "DRAFT-1", Today: "2025-03-14", Me: "Ann Lee <ann@example.com>"}
+	markdown, err := tmpl.Instantiate(vars, "To Do")
+	if err != nil {
+		t.Fatalf("Instantiate failed: %v", err)
+	}
+	if strings.Contains(markdown, "template:") {
+		t.Errorf("Expected the template block to be removed:\n%s", markdown)
+	}
+
+	// The result is a valid issue file once titled
+	parsed, problems := (&Storage{}).ValidateMarkdown(strings.Replace(markdown, `"Bug: "`, `"Bug: crash"`, 1), Schema{})
+	if len(problems) > 0 {
+		t.Fatalf("Expected no problems, got %v in:\n%s", problems, markdown)
+	}
+	if parsed.Key != "DRAFT-1" || parsed.Status != "To Do" || parsed.Type != "Bug" || parsed.Assignee != vars.Me {
+		t.Errorf("Expected the template fields, got %+v", parsed)
+	}
+	if len(parsed.Labels) != 1 || parsed.Labels[0] != "bug" {
+		t.Errorf("Expected label bug, got %v", parsed.Labels)
+	}
+	if !strings.Contains(parsed.Description, "Reported on 2025-03-14") {
+		t.Errorf("Expected the expanded description, got %q", parsed.Description)
+	}
+}
+
+// TestTemplate_Invalid tests that broken templates are reported by name
+func TestTemplate_Invalid(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTemplate(t, projectPath, "broken", "---\ntitle: \"{{.Me\"\n---\n")
+	writeTemplate(t, projectPath, "unknown", "---\ntitle: \"{{.Nobody}}\"\n---\n")
+
+	if _, err := LoadTemplate(projectPath, "broken"); err == nil || !strings.Contains(err.Error(), "template broken") {
+		t.Errorf("Expected a parse error naming the template, got %v", err)
+	}
+
+	tmpl, err := LoadTemplate(projectPath, "unknown")
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+	if _, err := tmpl.Instantiate(TemplateVars{Key: "DRAFT-1"}, "To Do"); err == nil {
+		t.Errorf("Expected an error for an unknown variable")
+	}
+}
@@ -0,0 +1,259 @@
+package issue
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Issue represents a tracker issue with all its metadata.
+//
+// Key and RemoteID are stored as jira_key and jira_id on disk and in the API
+// for compatibility with files written before other bridges existed.
+type Issue struct {
+	Key      string    `yaml:"jira_key" json:"jira_key"` // Local key, also the file name (e.g. PROJ-123, GH-42)
+	RemoteID string    `yaml:"jira_id" json:"jira_id"`   // Tracker-internal ID
+	Title    string    `yaml:"title" json:"title"`
+	Status   string    `yaml:"status" json:"status"`
+	Assignee string    `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Reporter string    `yaml:"reporter" json:"reporter"`
+	Created  time.Time `yaml:"created" json:"created"`
+	Updated  time.Time `yaml:"updated" json:"updated"`
+	Labels   []string  `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Hash     string    `yaml:"hash" json:"hash"` // SHA256 of content (excluding hash field)
+
+	// FormatVersion is the format version of the file the issue was read
+	// from, after migration; files are always written in the current one
+	FormatVersion int `yaml:"format_version,omitempty" json:"format_version,omitempty"`
+
+	// Fields used by trackers with multiple assignees or milestones (e.g. GitHub)
+	Assignees []string `yaml:"assignees,omitempty" json:"assignees,omitempty"` // Multiple assignees (by login)
+	Milestone string   `yaml:"milestone,omitempty" json:"milestone,omitempty"` // Milestone title
+
+	// Planning fields (e.g. Jira); all optional
+	Type        string   `yaml:"type,omitempty" json:"type,omitempty"`                 // Issue type (Bug, Story, ...)
+	Priority    string   `yaml:"priority,omitempty" json:"priority,omitempty"`         // Priority name (High, Medium, ...)
+	Components  []string `yaml:"components,omitempty" json:"components,omitempty"`     // Component names
+	FixVersions []string `yaml:"fix_versions,omitempty" json:"fix_versions,omitempty"` // Fix version names
+	Sprint      string   `yaml:"sprint,omitempty" json:"sprint,omitempty"`             // Current sprint name (read-only)
+	DueDate     string   `yaml:"due_date,omitempty" json:"due_date,omitempty"`         // Due date as YYYY-MM-DD
+	Parent      string   `yaml:"parent,omitempty" json:"parent,omitempty"`             // Parent or epic key
+	StoryPoints float64  `yaml:"story_points,omitempty" json:"story_points,omitempty"` // Estimate in story points
+	Rank        string   `yaml:"rank,omitempty" json:"rank,omitempty"`                 // Board rank, sorts lexically (read-only)
+
+	// Time tracking estimates as work durations, e.g. "2d 4h" (see ParseWorkDuration)
+	OriginalEstimate  string `yaml:"original_estimate,omitempty" json:"original_estimate,omitempty"`
+	RemainingEstimate string `yaml:"remaining_estimate,omitempty" json:"remaining_estimate,omitempty"`
+
+	// Custom holds mapped tracker-specific fields by their configured name.
+	// Values are strings, numbers or lists of strings.
+	Custom map[string]interface{} `yaml:"custom,omitempty" json:"custom,omitempty"`
+
+	// Links are relations to other issues (blocks, relates to, subtasks, ...)
+	Links []Link `yaml:"links,omitempty" json:"links,omitempty"`
+
+	Description string       `yaml:"-" json:"description,omitempty"` // Not in frontmatter
+	Comments    []Comment    `yaml:"-" json:"comments,omitempty"`    // Not in frontmatter
+	Worklogs    []Worklog    `yaml:"-" json:"worklogs,omitempty"`    // Not in frontmatter
+	Attachments []Attachment `yaml:"-" json:"attachments,omitempty"` // Not in frontmatter
+}
+
+// Comment represents a comment on an issue
+type Comment struct {
+	ID       string    `yaml:"-" json:"id,omitempty"`
+	Author   string    `yaml:"-" json:"author"`
+	AuthorID string    `yaml:"-" json:"author_id,omitempty"` // Tracker account ID of the author
+	Body     string    `yaml:"-" json:"body"`
+	Created  time.Time `yaml:"-" json:"created"`
+	Updated  time.Time `yaml:"-" json:"updated,omitempty"`
+}
+
+// Attachment represents a file attachment on an issue
+type Attachment struct {
+	ID       string    `yaml:"-" json:"id,omitempty"`
+	Filename string    `yaml:"-" json:"filename"`
+	URL      string    `yaml:"-" json:"url"`
+	MimeType string    `yaml:"-" json:"mime_type,omitempty"`
+	Size     int64     `yaml:"-" json:"size,omitempty"`
+	Created  time.Time `yaml:"-" json:"created,omitempty"`
+}
+
+// Member represents a project member in the remote tracker
+type Member struct {
+	AccountID    string `json:"accountId"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	Active       bool   `json:"active"`
+}
+
+// MemberCache holds cached project members
+type MemberCache struct {
+	Members map[string]*Member `json:"members"` // Key is account ID
+}
+
+// NewMemberCache creates an empty member cache
+func NewMemberCache() *MemberCache {
+	return &MemberCache{
+		Members: make(map[string]*Member),
+	}
+}
+
+// Add adds a member to the cache
+func (mc *MemberCache) Add(member *Member) {
+	if mc.Members == nil {
+		mc.Members = make(map[string]*Member)
+	}
+	mc.Members[member.AccountID] = member
+}
+
+// FindByAccountID looks up a member by account ID
+func (mc *MemberCache) FindByAccountID(accountID string) *Member {
+	return mc.Members[accountID]
+}
+
+// FindByEmail looks up a member by email address
+func (mc *MemberCache) FindByEmail(email string) *Member {
+	for _, member := range mc.Members {
+		if member.EmailAddress == email {
+			return member
+		}
+	}
+	return nil
+}
+
+// FindByDisplayName looks up a member by display name
+func (mc *MemberCache) FindByDisplayName(displayName string) *Member {
+	for _, member := range mc.Members {
+		if member.DisplayName == displayName {
+			return member
+		}
+	}
+	return nil
+}
+
+// FormatMember returns the canonical format: "Display Name <email>"
+func (m *Member) FormatMember() string {
+	if m.EmailAddress != "" {
+		return fmt.Sprintf("%s <%s>", m.DisplayName, m.EmailAddress)
+	}
+	return m.DisplayName
+}
+
+// Regular expression to parse "Display Name <email>" format
+var userFormatRegex = regexp.MustCompile(`^(.+?)\s*<([^>]+)>$`)
+
+// ParseUser parses a user string in various formats and resolves to a Member
+// Supports:
+// - "Display Name <email>" (canonical format)
+// - "email@example.com" (email only)
+// - "Display Name" (display name only)
+//
+// Returns the resolved Member and the original string for error messages
+func (mc *MemberCache) ParseUser(userStr string) (*Member, error) {
+	userStr = strings.TrimSpace(userStr)
+	if userStr == "" {
+		return nil, fmt.Errorf("empty user string")
+	}
+
+	// Try parsing "Display Name <email>" format
+	if matches := userFormatRegex.FindStringSubmatch(userStr); matches != nil {
+		displayName := strings.TrimSpace(matches[1])
+		email := strings.TrimSpace(matches[2])
+
+		// Prefer email lookup (more stable)
+		if member := mc.FindByEmail(email); member != nil {
+			return member, nil
+		}
+
+		// Fallback to display name
+		if member := mc.FindByDisplayName(displayName); member != nil {
+			return member, nil
+		}
+
+		return nil, fmt.Errorf("user not found: %q", userStr)
+	}
+
+	// Check if it looks like an email (contains @)
+	if strings.Contains(userStr, "@") {
+		if member := mc.FindByEmail(userStr); member != nil {
+			return member, nil
+		}
+		return nil, fmt.Errorf("user with email %q not found", userStr)
+	}
+
+	// Try as display name
+	if member := mc.FindByDisplayName(userStr); member != nil {
+		return member, nil
+	}
+
+	return nil, fmt.Errorf("user %q not found", userStr)
+}
+
+// StatusInfo represents a workflow status with its category
+type StatusInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"` // "new", "indeterminate", "done", or "undefined"
+}
+
+// WorkflowCache holds cached project statuses
+type WorkflowCache struct {
+	Statuses map[string]*StatusInfo `json:"statuses"` // Key is status ID
+}
+
+// NewWorkflowCache creates an empty workflow cache
+func NewWorkflowCache() *WorkflowCache {
+	return &WorkflowCache{
+		Statuses: make(map[string]*StatusInfo),
+	}
+}
+
+// AddStatus adds a status to the cache
+func (wc *WorkflowCache) AddStatus(status *StatusInfo) {
+	if wc.Statuses == nil {
+		wc.Statuses = make(map[string]*StatusInfo)
+	}
+	wc.Statuses[status.ID] = status
+}
+
+// FindByID looks up a status by ID
+func (wc *WorkflowCache) FindByID(id string) *StatusInfo {
+	return wc.Statuses[id]
+}
+
+// FindByName looks up a status by name
+func (wc *WorkflowCache) FindByName(name string) *StatusInfo {
+	for _, status := range wc.Statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	return nil
+}
+
+// ResolveStatus looks up a status by name, ignoring case. The error lists the
+// known statuses.
+func (wc *WorkflowCache) ResolveStatus(name string) (*StatusInfo, error) {
+	names := make([]string, 0, len(wc.Statuses))
+	for _, status := range wc.Statuses {
+		if strings.EqualFold(status.Name, strings.TrimSpace(name)) {
+			return status, nil
+		}
+		names = append(names, status.Name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown status %q (known: %s)", name, strings.Join(names, ", "))
+}
+
+// GetByCategory returns all statuses in a given category
+func (wc *WorkflowCache) GetByCategory(category string) []*StatusInfo {
+	var statuses []*StatusInfo
+	for _, status := range wc.Statuses {
+		if status.Category == category {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
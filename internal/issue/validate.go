@@ -0,0 +1,183 @@
+package issue
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a validation problem found in an issue file
+type Problem struct {
+	Line    int    `json:"line,omitempty"` // 1-based line in the file; 0 if not tied to a line
+	Message string `json:"message"`
+	Fixable bool   `json:"fixable,omitempty"` // Can be normalized by lint --fix
+}
+
+// String formats the problem as "line N: message"
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return p.Message
+}
+
+// Schema holds what issue files are validated against besides their structure.
+// Empty caches accept any status or assignee.
+type Schema struct {
+	Bridge   string // Bridge type of the project, for bridge-specific checks
+	Workflow *WorkflowCache
+	Members  *MemberCache
+}
+
+// FrontmatterKeys are the keys allowed in the frontmatter of issue files,
+// taken from the yaml tags of Issue
+var FrontmatterKeys = frontmatterKeys()
+
+func frontmatterKeys() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(Issue{})
+	for n := 0; n < t.NumField(); n++ {
+		name, _, _ := strings.Cut(t.Field(n).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// keyRegex matches keys usable as issue file names (PROJ-1, GH-42, OPS-draft)
+var keyRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ValidKey reports whether key can name an issue file
+func ValidKey(key string) bool {
+	return keyRegex.MatchString(key)
+}
+
+// yamlLineRegex finds the line numbers in yaml.v3 errors ("line 3: ...")
+var yamlLineRegex = regexp.MustCompile(`line (\d+): ([^\n]+)`)
+
+// ValidateMarkdown parses the content of an issue file and checks it against
+// the schema: the file structure, unknown frontmatter keys, a title, and the
+// status and assignee against the caches. Status and assignee are normalized
+// to their cached form (e.g. "alice@example.com" to "Alice <alice@example.com>").
+// Returns the issue if it could be parsed, and the problems found.
+func (s *Storage) ValidateMarkdown(content string, schema Schema) (*Issue, []Problem) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	parsed, err := s.parseMarkdown(content)
+	if err != nil {
+		return nil, parseProblems(err)
+	}
+
+	// The frontmatter starts on line 2, after the opening ---
+	frontmatter, _, _ := strings.Cut(content[4:], "\n---\n")
+	var problems []Problem
+	keyLines := make(map[string]int)
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err == nil && len(doc.Content) > 0 {
+		fields := doc.Content[0].Content
+		for n := 0; n+1 < len(fields); n += 2 {
+			key := fields[n]
+			keyLines[key.Value] = key.Line + 1
+			if FrontmatterKeys[key.Value] {
+				continue
+			}
+			message := fmt.Sprintf("unknown field %q", key.Value)
+			if suggestion := closestKey(key.Value); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			problems = append(problems, Problem{Line: key.Line + 1, Message: message})
+		}
+	}
+
+	if strings.TrimSpace(parsed.Key) == "" {
+		problems = append(problems, Problem{Line: keyLines["jira_key"], Message: "jira_key is required"})
+	} else if !ValidKey(parsed.Key) {
+		problems = append(problems, Problem{Line: keyLines["jira_key"], Message: fmt.Sprintf("jira_key %q is not a valid key", parsed.Key)})
+	}
+	if strings.TrimSpace(parsed.Title) == "" {
+		problems = append(problems, Problem{Line: keyLines["title"], Message: "title is required"})
+	}
+
+	if parsed.Status == "" {
+		problems = append(problems, Problem{Line: keyLines["status"], Message: "status is required"})
+	} else if schema.Workflow != nil && len(schema.Workflow.Statuses) > 0 {
+		status, err := schema.Workflow.ResolveStatus(parsed.Status)
+		if err != nil {
+			problems = append(problems, Problem{Line: keyLines["status"], Message: err.Error()})
+		} else {
+			parsed.Status = status.Name
+		}
+	}
+
+	if parsed.Assignee != "" && schema.Members != nil && len(schema.Members.Members) > 0 {
+		member, err := schema.Members.ParseUser(parsed.Assignee)
+		if err != nil {
+			problems = append(problems, Problem{Line: keyLines["assignee"], Message: "assignee: " + err.Error()})
+		} else {
+			parsed.Assignee = member.FormatMember()
+		}
+	}
+
+	return parsed, problems
+}
+
+// parseProblems turns a parse error into problems, with file lines where the
+// YAML error has them
+func parseProblems(err error) []Problem {
+	matches := yamlLineRegex.FindAllStringSubmatch(err.Error(), -1)
+	if len(matches) == 0 {
+		return []Problem{{Message: err.Error()}}
+	}
+
+	var problems []Problem
+	for _, m := range matches {
+		line, _ := strconv.Atoi(m[1])
+		problems = append(problems, Problem{Line: line + 1, Message: strings.TrimSpace(m[2])})
+	}
+	return problems
+}
+
+// closestKey returns the known frontmatter key closest to a misspelled one,
+// if any is within two edits
+func closestKey(key string) string {
+	best, bestDistance := "", 3
+	var known []string
+	for k := range FrontmatterKeys {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+	for _, k := range known {
+		if d := editDistance(strings.ToLower(key), k); d < bestDistance {
+			best, bestDistance = k, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,122 @@
+package issue
+
+import (
+	"strings"
+	"testing"
+)
+
+// validIssueFile is an issue file that passes validation
+const validIssueFile = `---
+jira_key: PROJ-1
+jira_id: "10001"
+title: Fix login
+status: To Do
+assignee: alice@example.com
+---
+
+Description
+===========
+
+The login form fails.
+`
+
+// newTestSchema returns a schema with a two-status workflow and one member
+func newTestSchema() Schema {
+	workflow := NewWorkflowCache()
+	workflow.AddStatus(&StatusInfo{ID: "1", Name: "To Do", Category: "new"})
+	workflow.AddStatus(&StatusInfo{ID: "3", Name: "In Progress", Category: "indeterminate"})
+	members := NewMemberCache()
+	members.Add(&Member{AccountID: "a1", DisplayName: "Alice Smith", EmailAddress: "alice@example.com", Active: true})
+	return Schema{Workflow: workflow, Members: members}
+}
+
+// TestValidateMarkdown_Valid tests that a valid file has no problems and that
+// status and assignee are normalized
+func TestValidateMarkdown_Valid(t *testing.T) {
+	s := &Storage{}
+	content := strings.Replace(validIssueFile, "status: To Do", "status: in progress", 1)
+
+	parsed, problems := s.ValidateMarkdown(content, newTestSchema())
+	if len(problems) > 0 {
+		t.Fatalf("Expected no problems, got %v", problems)
+	}
+	if parsed.Status != "In Progress" {
+		t.Errorf("Expected status In Progress, got %q", parsed.Status)
+	}
+	if parsed.Assignee != "Alice Smith <alice@example.com>" {
+		t.Errorf("Expected the canonical assignee, got %q", parsed.Assignee)
+	}
+	if parsed.Description != "The login form fails." {
+		t.Errorf("Expected the description, got %q", parsed.Description)
+	}
+}
+
+// TestValidateMarkdown_Problems tests the problems found in invalid files and
+// their lines
+func TestValidateMarkdown_Problems(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "misspelled key",
+			content: strings.Replace(validIssueFile, "assignee:", "asignee:", 1),
+			want:    `line 6: unknown field "asignee" (did you mean "assignee"?)`,
+		},
+		{
+			name:    "unknown status",
+			content: strings.Replace(validIssueFile, "status: To Do", "status: Nope", 1),
+			want:    `line 5: unknown status "Nope" (known: In Progress, To Do)`,
+		},
+		{
+			name:    "unknown assignee",
+			content: strings.Replace(validIssueFile, "alice@example.com", "bob@example.com", 1),
+			want:    `line 6: assignee: user with email "bob@example.com" not found`,
+		},
+		{
+			name:    "missing title",
+			content: strings.Replace(validIssueFile, "title: Fix login", `title: ""`, 1),
+			want:    "line 4: title is required",
+		},
+		{
+			name:    "invalid key",
+			content: strings.Replace(validIssueFile, "jira_key: PROJ-1", "jira_key: ../PROJ-1", 1),
+			want:    `line 2: jira_key "../PROJ-1" is not a valid key`,
+		},
+		{
+			name:    "YAML error",
+			content: strings.Replace(validIssueFile, "title: Fix login", "title: Fix: login", 1),
+			want:    "line 4: mapping values are not allowed",
+		},
+		{
+			name:    "missing frontmatter",
+			content: "Just text\n",
+			want:    "missing frontmatter",
+		},
+	}
+
+	s := &Storage{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problems := s.ValidateMarkdown(tt.content, newTestSchema())
+			if len(problems) != 1 {
+				t.Fatalf("Expected 1 problem, got %v", problems)
+			}
+			if got := problems[0].String(); !strings.Contains(got, tt.want) {
+				t.Errorf("Expected problem %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestValidateMarkdown_EmptyCaches tests that any status and assignee are
+// accepted without caches
+func TestValidateMarkdown_EmptyCaches(t *testing.T) {
+	s := &Storage{}
+	content := strings.Replace(validIssueFile, "status: To Do", "status: Whatever", 1)
+
+	if _, problems := s.ValidateMarkdown(content, Schema{}); len(problems) > 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
@@ -0,0 +1,132 @@
+package issue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Worklog is time logged on an issue
+type Worklog struct {
+	ID        string    `yaml:"-" json:"id,omitempty"` // Not persisted in markdown
+	Author    string    `yaml:"-" json:"author,omitempty"`
+	Started   time.Time `yaml:"-" json:"started"`
+	TimeSpent int       `yaml:"-" json:"time_spent"` // Seconds
+	Comment   string    `yaml:"-" json:"comment,omitempty"`
+}
+
+// Work duration units, using Jira's default working time of 8 hours a day
+// and 5 days a week
+const (
+	workMinute = 60
+	workHour   = 60 * workMinute
+	workDay    = 8 * workHour
+	workWeek   = 5 * workDay
+)
+
+// ParseWorkDuration parses a duration such as "1h30m", "2d 4h" or "45m" into
+// seconds. Units are w, d, h and m; a day is 8 hours and a week 5 days.
+func ParseWorkDuration(s string) (int, error) {
+	rest := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if rest == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	total := 0
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		if i == 0 || i == len(rest) {
+			return 0, fmt.Errorf("invalid duration %q: expected e.g. 1h30m or 2d 4h", s)
+		}
+		n, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+
+		var unit int
+		switch rest[i] {
+		case 'w':
+			unit = workWeek
+		case 'd':
+			unit = workDay
+		case 'h':
+			unit = workHour
+		case 'm':
+			unit = workMinute
+		default:
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q (use w, d, h or m)", s, rest[i])
+		}
+		total += int(n * float64(unit))
+		rest = rest[i+1:]
+	}
+
+	if total < workMinute {
+		return 0, fmt.Errorf("invalid duration %q: must be at least 1m", s)
+	}
+	return total, nil
+}
+
+// FormatWorkDuration formats seconds as hours and minutes, e.g. "1h 30m".
+// Days are not used, so totals read the same whatever the working day length.
+func FormatWorkDuration(seconds int) string {
+	hours, minutes := seconds/workHour, seconds%workHour/workMinute
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+// ParseDay resolves a day as given on the command line to midnight of that day
+// in now's location: "today", "yesterday", a weekday name (the latest such day,
+// today included) or a date as YYYY-MM-DD.
+func ParseDay(s string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch day := strings.ToLower(strings.TrimSpace(s)); day {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	default:
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			name := strings.ToLower(wd.String())
+			if day == name || day == name[:3] {
+				back := (int(today.Weekday()) - int(wd) + 7) % 7
+				return today.AddDate(0, 0, -back), nil
+			}
+		}
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(s), now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day %q: use today, yesterday, a weekday or YYYY-MM-DD", s)
+	}
+	return t, nil
+}
+
+// worklogKey identifies a worklog across versions of the same file and the
+// remote. Worklog IDs are not persisted in markdown, so the start time, author
+// and time spent (in minutes, as stored) are used.
+func worklogKey(w Worklog) string {
+	return w.Started.UTC().Format(time.RFC3339) + "|" + w.Author + "|" + strconv.Itoa(w.TimeSpent/workMinute)
+}
+
+// HasWorklog reports whether the issue has a worklog started at the same time
+// (to the second) with the same time spent (to the minute). Authors are not
+// compared: a worklog logged locally has none until it is pushed, and pulled
+// authors depend on the members cache.
+func (i *Issue) HasWorklog(w Worklog) bool {
+	for _, existing := range i.Worklogs {
+		if existing.Started.Unix() == w.Started.Unix() && existing.TimeSpent/workMinute == w.TimeSpent/workMinute {
+			return true
+		}
+	}
+	return false
+}
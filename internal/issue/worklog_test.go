@@ -0,0 +1,147 @@
+package issue
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseWorkDuration tests units, spacing and invalid durations
+func TestParseWorkDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"1h30m", 5400},
+		{"1h 30m", 5400},
+		{"45m", 2700},
+		{"2d 4h", 20 * 3600},
+		{"1w", 40 * 3600},
+		{"1.5h", 5400},
+		{" 3H ", 3 * 3600},
+	}
+	for _, tt := range tests {
+		got, err := ParseWorkDuration(tt.in)
+		if err != nil {
+			t.Errorf("ParseWorkDuration(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWorkDuration(%q): expected %d, got %d", tt.in, tt.want, got)
+		}
+	}
+
+	for _, in := range []string{"", "90", "h", "1x", "30s", "0m"} {
+		if _, err := ParseWorkDuration(in); err == nil {
+			t.Errorf("Expected error for duration %q", in)
+		}
+	}
+}
+
+// TestFormatWorkDuration tests that durations are written in hours and minutes
+func TestFormatWorkDuration(t *testing.T) {
+	tests := map[int]string{
+		2700:      "45m",
+		3600:      "1h",
+		5400:      "1h 30m",
+		20 * 3600: "20h",
+	}
+	for seconds, want := range tests {
+		if got := FormatWorkDuration(seconds); got != want {
+			t.Errorf("FormatWorkDuration(%d): expected %q, got %q", seconds, want, got)
+		}
+	}
+}
+
+// TestParseDay tests relative days, weekdays and dates
+func TestParseDay(t *testing.T) {
+	// A Wednesday afternoon
+	now := time.Date(2025, 3, 12, 15, 4, 0, 0, time.UTC)
+
+	tests := map[string]string{
+		"today":      "2025-03-12",
+		"yesterday":  "2025-03-11",
+		"monday":     "2025-03-10",
+		"Mon":        "2025-03-10",
+		"wednesday":  "2025-03-12",
+		"thursday":   "2025-03-06",
+		"2025-02-28": "2025-02-28",
+	}
+	for in, want := range tests {
+		got, err := ParseDay(in, now)
+		if err != nil {
+			t.Errorf("ParseDay(%q) failed: %v", in, err)
+			continue
+		}
+		if got.Format("2006-01-02 15:04") != want+" 00:00" {
+			t.Errorf("ParseDay(%q): expected %s at midnight, got %s", in, want, got)
+		}
+	}
+
+	if _, err := ParseDay("next week", now); err == nil {
+		t.Errorf("Expected error for an unknown day")
+	}
+}
+
+// TestWorklogs_RoundTripAndHash tests that worklogs and estimates survive a
+// save and read, and that the stored precision does not change the hash
+func TestWorklogs_RoundTripAndHash(t *testing.T) {
+	s, err := NewStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorage failed: %v", err)
+	}
+
+	// As pulled: millisecond start times and a comment
+	want := &Issue{
+		Key:               "PROJ-1",
+		Title:             "Tracked",
+		OriginalEstimate:  "2d",
+		RemainingEstimate: "1d 4h",
+		Worklogs: []Worklog{
+			{ID: "100", Author: "Ann Lee <ann@example.com>", Started: time.Date(2025, 3, 10, 9, 0, 0, 123e6, time.UTC), TimeSpent: 5400, Comment: "Pairing\n\non the parser"},
+			{ID: "101", Author: "Bob", Started: time.Date(2025, 3, 11, 14, 30, 0, 0, time.UTC), TimeSpent: 1800},
+		},
+	}
+	if err := s.SaveIssue(want); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+
+	got, err := s.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if got.OriginalEstimate != "2d" || got.RemainingEstimate != "1d 4h" {
+		t.Errorf("Expected estimates 2d and 1d 4h, got %q and %q", got.OriginalEstimate, got.RemainingEstimate)
+	}
+	if len(got.Worklogs) != 2 {
+		t.Fatalf("Expected 2 worklogs, got %+v", got.Worklogs)
+	}
+	first := got.Worklogs[0]
+	if first.Author != "Ann Lee <ann@example.com>" || first.TimeSpent != 5400 || first.Comment != "Pairing\n\non the parser" {
+		t.Errorf("Expected the first worklog to round-trip, got %+v", first)
+	}
+	if got.Worklogs[1].Comment != "" || got.Worklogs[1].TimeSpent != 1800 {
+		t.Errorf("Expected the second worklog without comment, got %+v", got.Worklogs[1])
+	}
+	if hash := s.ComputeHash(got); hash != want.Hash {
+		t.Errorf("Expected the read issue to keep hash %s, got %s", want.Hash, hash)
+	}
+
+	// A worklog logged locally has no author and changes the hash
+	got.Worklogs = append(got.Worklogs, Worklog{Started: time.Date(2025, 3, 12, 8, 0, 0, 0, time.UTC), TimeSpent: 3600})
+	if err := s.WriteIssue(got); err != nil {
+		t.Fatalf("WriteIssue failed: %v", err)
+	}
+	logged, err := s.ReadIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("ReadIssue failed: %v", err)
+	}
+	if len(logged.Worklogs) != 3 || logged.Worklogs[2].Author != "" {
+		t.Errorf("Expected the local worklog without author, got %+v", logged.Worklogs)
+	}
+	if s.ComputeHash(logged) == want.Hash {
+		t.Errorf("Expected a new worklog to change the hash")
+	}
+	if !want.HasWorklog(logged.Worklogs[0]) || want.HasWorklog(logged.Worklogs[2]) {
+		t.Errorf("Expected only the pulled worklogs to match the remote")
+	}
+}
@@ -222,3 +222,25 @@ func (r *Registry) List() []*Project {
 
 	return projects
 }
+
+// FindByPath returns the project registered at path, if any.
+// The path is canonicalized the same way as on registration.
+func (r *Registry) FindByPath(path string) (*Project, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false
+	}
+	if realPath, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = realPath
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.data.Projects {
+		if p.Path == absPath {
+			return p, true
+		}
+	}
+	return nil, false
+}
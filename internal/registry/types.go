@@ -4,10 +4,11 @@ import "time"
 
 // Project represents a registered project in the TAKL registry
 type Project struct {
-	ID           string    `yaml:"id" json:"id"`                       // UUID v4
-	Name         string    `yaml:"name" json:"name"`                   // Human-readable project name
-	Path         string    `yaml:"path" json:"path"`                   // Absolute path to project directory
-	RegisteredAt time.Time `yaml:"registered_at" json:"registered_at"` // When project was registered
+	ID           string    `yaml:"id" json:"id"`                             // UUID v4
+	Name         string    `yaml:"name" json:"name"`                         // Human-readable project name
+	Path         string    `yaml:"path" json:"path"`                         // Absolute path to project directory
+	RegisteredAt time.Time `yaml:"registered_at" json:"registered_at"`       // When project was registered
+	Bridge       string    `yaml:"bridge,omitempty" json:"bridge,omitempty"` // Issue tracker bridge (jira, github, gitlab, linear, local); detected when empty
 }
 
 // RegistryData holds all registered projects
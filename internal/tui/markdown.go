@@ -0,0 +1,137 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Styles of the rendered markdown
+var (
+	headingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	boldStyle    = lipgloss.NewStyle().Bold(true)
+	italicStyle  = lipgloss.NewStyle().Italic(true)
+	codeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	quoteStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+var (
+	headingRegex  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletRegex   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	numberedRegex = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	ruleRegex     = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+
+	linkRegex   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	codeRegex   = regexp.MustCompile("`([^`]+)`")
+	boldRegex   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRegex = regexp.MustCompile(`(^|[\s(])[*_]([^*_\s][^*_]*)[*_]`)
+)
+
+// renderMarkdown renders the markdown of a description or comment for the
+// terminal, wrapped to width: headings, lists, quotes, fenced code and
+// inline emphasis, code and links. Anything else is shown as written.
+func renderMarkdown(text string, width int) string {
+	width = max(width, 10)
+
+	var out []string
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			out = append(out, wrap(renderInline(strings.Join(paragraph, " ")), width, ""))
+			paragraph = nil
+		}
+	}
+
+	inCode := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			// Code is indented and cut rather than wrapped
+			out = append(out, codeStyle.Render(ansi.Truncate("    "+line, width, "…")))
+			continue
+		}
+
+		switch m := headingRegex.FindStringSubmatch(trimmed); {
+		case trimmed == "":
+			flush()
+			out = append(out, "")
+		case m != nil:
+			flush()
+			out = append(out, wrap(headingStyle.Render(m[2]), width, ""))
+		case ruleRegex.MatchString(line):
+			flush()
+			out = append(out, quoteStyle.Render(strings.Repeat("─", width)))
+		case bulletRegex.MatchString(line):
+			flush()
+			m := bulletRegex.FindStringSubmatch(line)
+			out = append(out, listItem(m[1], "• ", m[2], width))
+		case numberedRegex.MatchString(line):
+			flush()
+			m := numberedRegex.FindStringSubmatch(line)
+			out = append(out, listItem(m[1], m[2]+" ", m[3], width))
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			out = append(out, quoteStyle.Render(wrap(renderInline(quote), width-2, "│ ")))
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+
+	// Collapse the blank lines left around blocks
+	rendered := strings.Join(out, "\n")
+	for strings.Contains(rendered, "\n\n\n") {
+		rendered = strings.ReplaceAll(rendered, "\n\n\n", "\n\n")
+	}
+	return strings.Trim(rendered, "\n")
+}
+
+// listItem renders a list item with a hanging indent
+func listItem(indent, marker, text string, width int) string {
+	prefix := strings.Repeat(" ", len(indent)) + marker
+	hang := strings.Repeat(" ", ansi.StringWidth(prefix))
+	lines := strings.Split(ansi.Wordwrap(renderInline(text), width-len(hang), ""), "\n")
+	for n := range lines {
+		if n == 0 {
+			lines[n] = prefix + lines[n]
+		} else {
+			lines[n] = hang + lines[n]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap word-wraps s to width, prefixing every line
+func wrap(s string, width int, prefix string) string {
+	lines := strings.Split(ansi.Wordwrap(s, max(width, 1), ""), "\n")
+	for n := range lines {
+		lines[n] = prefix + lines[n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderInline styles inline code, emphasis and links; links are shown as
+// "text (url)" since terminals cannot follow them
+func renderInline(s string) string {
+	s = linkRegex.ReplaceAllString(s, "$1 ($2)")
+	s = codeRegex.ReplaceAllStringFunc(s, func(m string) string {
+		return codeStyle.Render(strings.Trim(m, "`"))
+	})
+	s = boldRegex.ReplaceAllStringFunc(s, func(m string) string {
+		return boldStyle.Render(m[2 : len(m)-2])
+	})
+	return italicRegex.ReplaceAllStringFunc(s, func(m string) string {
+		sub := italicRegex.FindStringSubmatch(m)
+		return sub[1] + italicStyle.Render(sub[2])
+	})
+}
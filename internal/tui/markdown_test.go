@@ -0,0 +1,53 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestRenderMarkdown tests rendering of markdown blocks and inline markup
+func TestRenderMarkdown(t *testing.T) {
+	text := "## Steps\n\n" +
+		"1. Open the [settings](https://example.com/s)\n" +
+		"- Press **Save** and `ctrl+s`\n\n" +
+		"> Quoted\n\n" +
+		"```\nfunc main() {}\n```\n\n" +
+		"First line\nof a paragraph."
+
+	got := ansi.Strip(renderMarkdown(text, 80))
+	want := strings.Join([]string{
+		"Steps",
+		"",
+		"1. Open the settings (https://example.com/s)",
+		"• Press Save and ctrl+s",
+		"",
+		"│ Quoted",
+		"",
+		"    func main() {}",
+		"",
+		"First line of a paragraph.",
+	}, "\n")
+	if got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+// TestRenderMarkdown_Wrap tests that paragraphs and list items wrap to the
+// width, list items with a hanging indent
+func TestRenderMarkdown_Wrap(t *testing.T) {
+	got := ansi.Strip(renderMarkdown("- one two three four five six", 16))
+	want := "• one two three\n  four five six"
+	if got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	for _, line := range strings.Split(ansi.Strip(renderMarkdown(strings.Repeat("word ", 40), 20)), "\n") {
+		if ansi.StringWidth(line) > 20 {
+			t.Errorf("Expected lines of at most 20 columns, got %q", line)
+		}
+	}
+}
@@ -0,0 +1,660 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// mode is what the keyboard currently drives
+type mode int
+
+const (
+	modeList    mode = iota // Moving through the list
+	modeDetail              // Scrolling the detail pane
+	modeFilter              // Typing the list filter
+	modePicker              // Choosing a status or assignee
+	modeComment             // Writing a comment
+)
+
+// unassigned is the assignee picker option that clears the assignee
+const unassigned = "(unassigned)"
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("8"))
+	activeStyle   = paneStyle.BorderForeground(lipgloss.Color("12"))
+)
+
+// picker chooses a value for an issue field from a list of options
+type picker struct {
+	field   string // Request field: status or assignee
+	title   string
+	options []string // Empty allows any value typed in the input
+	input   textinput.Model
+	cursor  int
+}
+
+// matches returns the options containing the typed text
+func (p *picker) matches() []string {
+	query := strings.ToLower(strings.TrimSpace(p.input.Value()))
+	var out []string
+	for _, o := range p.options {
+		if strings.Contains(strings.ToLower(o), query) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+type model struct {
+	api *api
+
+	width, height int
+	mode          mode
+
+	all      []*issue.Issue // In rank order
+	visible  []*issue.Issue // Matching the filter
+	cursor   int
+	offset   int // First visible row of the list
+	statuses []*issue.StatusInfo
+	members  []*issue.Member
+	loaded   bool
+
+	filter  textinput.Model
+	detail  viewport.Model
+	picker  picker
+	comment textarea.Model
+
+	message     string // Status line
+	failed      bool   // Whether message is an error
+	busy        bool   // A push is running
+	fingerprint string
+	fatal       error // Reported by Run after quitting
+}
+
+func newModel(api *api) model {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter by key, title, status, assignee or label"
+
+	comment := textarea.New()
+	comment.Placeholder = "Comment (markdown)"
+	comment.ShowLineNumbers = false
+
+	return model{
+		api:     api,
+		filter:  filter,
+		comment: comment,
+		detail:  viewport.New(0, 0),
+		message: "Loading issues...",
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.api.load()
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.layout()
+		return m, nil
+
+	case issuesMsg:
+		m.statuses, m.members = msg.statuses, msg.members
+		m.setIssues(msg.issues)
+		if !m.loaded {
+			m.loaded = true
+			m.message = fmt.Sprintf("%d issues", len(m.all))
+			return m, m.api.watch("")
+		}
+		return m, nil
+
+	case issueMsg:
+		for n, i := range m.all {
+			if i.Key == msg.issue.Key {
+				m.all[n] = msg.issue
+			}
+		}
+		m.setIssues(m.all)
+		m.setMessage(msg.action+" (push to send)", false)
+		return m, nil
+
+	case pushMsg:
+		m.busy = false
+		r := msg.result
+		text := fmt.Sprintf("Pushed %d, created %d, skipped %d", r.Pushed, r.Created, r.Skipped)
+		if len(r.Errors) > 0 {
+			m.setMessage(fmt.Sprintf("%s; %d error(s): %s", text, len(r.Errors), r.Errors[0]), true)
+		} else {
+			m.setMessage(text, false)
+		}
+		return m, m.api.load()
+
+	case changesMsg:
+		m.fingerprint = msg.fingerprint
+		if msg.changed {
+			return m, tea.Batch(m.api.load(), m.api.watch(m.fingerprint))
+		}
+		return m, m.api.watch(m.fingerprint)
+
+	case errMsg:
+		m.busy = false
+		if !m.loaded {
+			// Nothing to show: no daemon or no issues
+			m.fatal = msg.err
+			return m, tea.Quit
+		}
+		m.setMessage(msg.err.Error(), true)
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeFilter:
+			return m.updateFilter(msg)
+		case modePicker:
+			return m.updatePicker(msg)
+		case modeComment:
+			return m.updateComment(msg)
+		}
+		// The status line gives way to the key help once read
+		if !m.busy {
+			m.message = ""
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateBrowse handles keys in the list and detail panes
+func (m model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "tab", "enter", "l", "right":
+		if m.mode == modeList && m.selected() != nil {
+			m.mode = modeDetail
+			return m, nil
+		}
+		if msg.String() == "tab" {
+			m.mode = modeList
+			return m, nil
+		}
+	case "esc", "h", "left":
+		if m.mode == modeDetail {
+			m.mode = modeList
+			return m, nil
+		}
+		if msg.String() == "esc" && m.filter.Value() != "" {
+			m.filter.Reset()
+			m.setIssues(m.all)
+		}
+		return m, nil
+	case "/":
+		m.mode = modeFilter
+		return m, m.filter.Focus()
+	case "r":
+		m.setMessage("Reloading...", false)
+		return m, m.api.load()
+	case "P":
+		if m.busy {
+			return m, nil
+		}
+		m.busy = true
+		m.setMessage("Pushing...", false)
+		return m, m.api.push()
+	case "s":
+		return m.openPicker("status")
+	case "a":
+		return m.openPicker("assignee")
+	case "c":
+		if m.selected() == nil {
+			return m, nil
+		}
+		m.mode = modeComment
+		m.comment.Reset()
+		return m, m.comment.Focus()
+	}
+
+	if m.mode == modeDetail {
+		var cmd tea.Cmd
+		m.detail, cmd = m.detail.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "j", "down", "ctrl+n":
+		m.move(1)
+	case "k", "up", "ctrl+p":
+		m.move(-1)
+	case "pgdown", "ctrl+f":
+		m.move(m.listHeight())
+	case "pgup", "ctrl+b":
+		m.move(-m.listHeight())
+	case "g", "home":
+		m.move(-len(m.visible))
+	case "G", "end":
+		m.move(len(m.visible))
+	case "ctrl+d", " ":
+		m.detail.HalfPageDown()
+	case "ctrl+u":
+		m.detail.HalfPageUp()
+	}
+	return m, nil
+}
+
+// updateFilter handles keys while typing the filter, which applies as you type
+func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.filter.Reset()
+		fallthrough
+	case "enter":
+		m.filter.Blur()
+		m.mode = modeList
+		m.setIssues(m.all)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	m.setIssues(m.all)
+	return m, cmd
+}
+
+// openPicker starts choosing a status or assignee for the selected issue
+func (m model) openPicker(field string) (tea.Model, tea.Cmd) {
+	selected := m.selected()
+	if selected == nil {
+		return m, nil
+	}
+
+	p := picker{field: field, input: textinput.New()}
+	p.input.Prompt = "> "
+	switch field {
+	case "status":
+		p.title = "Status of " + selected.Key
+		p.options = statusOptions(m.statuses, m.all)
+	case "assignee":
+		p.title = "Assign " + selected.Key
+		if len(m.members) > 0 {
+			p.options = append(p.options, unassigned)
+			for _, member := range m.members {
+				p.options = append(p.options, member.FormatMember())
+			}
+		} else {
+			p.input.Placeholder = "login or name, empty to unassign"
+		}
+	}
+	m.picker = p
+	m.mode = modePicker
+	return m, m.picker.input.Focus()
+}
+
+// updatePicker handles keys while choosing from the picker
+func (m model) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.picker.matches()
+	switch msg.String() {
+	case "esc":
+		m.mode = modeList
+		return m, nil
+	case "down", "ctrl+n":
+		m.picker.cursor = min(m.picker.cursor+1, max(len(matches)-1, 0))
+		return m, nil
+	case "up", "ctrl+p":
+		m.picker.cursor = max(m.picker.cursor-1, 0)
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.picker.input.Value())
+		if len(m.picker.options) > 0 {
+			if len(matches) == 0 {
+				return m, nil
+			}
+			value = matches[min(m.picker.cursor, len(matches)-1)]
+		}
+		if value == unassigned {
+			value = ""
+		}
+		m.mode = modeList
+		key := m.selected().Key
+		action := fmt.Sprintf("Set %s of %s to %q", m.picker.field, key, value)
+		if m.picker.field == "assignee" && value == "" {
+			action = "Unassigned " + key
+		}
+		return m, m.api.update(key, m.picker.field, value, action)
+	}
+	var cmd tea.Cmd
+	m.picker.input, cmd = m.picker.input.Update(msg)
+	m.picker.cursor = 0
+	return m, cmd
+}
+
+// updateComment handles keys while writing a comment
+func (m model) updateComment(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.comment.Blur()
+		m.mode = modeList
+		return m, nil
+	case "ctrl+s":
+		m.comment.Blur()
+		m.mode = modeList
+		body := strings.TrimSpace(m.comment.Value())
+		if body == "" {
+			return m, nil
+		}
+		key := m.selected().Key
+		return m, m.api.update(key, "comment", body, "Commented on "+key)
+	}
+	var cmd tea.Cmd
+	m.comment, cmd = m.comment.Update(msg)
+	return m, cmd
+}
+
+// setIssues replaces the issues and reapplies the filter, keeping the
+// selection on the same issue where possible
+func (m *model) setIssues(issues []*issue.Issue) {
+	var key string
+	if selected := m.selected(); selected != nil {
+		key = selected.Key
+	}
+
+	m.all = issues
+	m.visible = filterIssues(issues, m.filter.Value())
+	m.cursor = 0
+	for n, i := range m.visible {
+		if i.Key == key {
+			m.cursor = n
+		}
+	}
+	m.move(0)
+}
+
+// move moves the selection by delta rows, scrolling the list to keep it in view
+func (m *model) move(delta int) {
+	previous := m.selected()
+	m.cursor = max(min(m.cursor+delta, len(m.visible)-1), 0)
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(min(m.offset, len(m.visible)-height), 0)
+
+	m.refreshDetail()
+	if m.selected() != previous {
+		m.detail.GotoTop()
+	}
+}
+
+// selected returns the issue under the cursor, if any
+func (m *model) selected() *issue.Issue {
+	if m.cursor < len(m.visible) {
+		return m.visible[m.cursor]
+	}
+	return nil
+}
+
+// setMessage sets the status line
+func (m *model) setMessage(text string, failed bool) {
+	m.message, m.failed = text, failed
+}
+
+// Layout
+
+// paneWidths returns the inner widths of the list and detail panes
+func (m *model) paneWidths() (int, int) {
+	list := max(m.width*2/5, 30)
+	return list - 2, max(m.width-list-2, 10)
+}
+
+// listHeight returns the number of list rows that fit the screen
+func (m *model) listHeight() int {
+	// Header, footer and the pane borders
+	return max(m.height-4, 1)
+}
+
+// layout sizes the panes to the window
+func (m *model) layout() {
+	listWidth, detailWidth := m.paneWidths()
+	m.filter.Width = listWidth - 2
+	m.detail.Width, m.detail.Height = detailWidth, m.listHeight()
+	m.comment.SetWidth(detailWidth)
+	m.comment.SetHeight(max(m.listHeight()-2, 3))
+	m.move(0)
+}
+
+// refreshDetail renders the selected issue into the detail pane
+func (m *model) refreshDetail() {
+	selected := m.selected()
+	if selected == nil {
+		m.detail.SetContent(faintStyle.Render("No issue selected"))
+		return
+	}
+	m.detail.SetContent(renderIssue(selected, m.detail.Width))
+}
+
+// View
+
+func (m model) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	listWidth, detailWidth := m.paneWidths()
+	height := m.listHeight()
+
+	header := titleStyle.Render("takl") + " " + filepath.Base(m.api.projectPath) +
+		faintStyle.Render(fmt.Sprintf("  %d/%d issues", len(m.visible), len(m.all)))
+	if m.mode == modeFilter || m.filter.Value() != "" {
+		header += "  " + m.filter.View()
+	}
+
+	var right string
+	switch m.mode {
+	case modePicker:
+		right = m.pickerView(detailWidth, height)
+	case modeComment:
+		right = titleStyle.Render("Comment on "+m.selected().Key) + "\n" + m.comment.View() +
+			"\n" + faintStyle.Render("ctrl+s save • esc cancel")
+	default:
+		right = m.detail.View()
+	}
+
+	listPane, detailPane := paneStyle, activeStyle
+	if m.mode == modeList || m.mode == modeFilter {
+		listPane, detailPane = activeStyle, paneStyle
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		listPane.Width(listWidth).Height(height).Render(m.listView(listWidth, height)),
+		detailPane.Width(detailWidth).Height(height).MaxHeight(height+2).Render(right),
+	)
+
+	footer := faintStyle.Render(m.help())
+	if m.message != "" {
+		style := faintStyle
+		if m.failed {
+			style = errorStyle
+		}
+		footer = style.Render(ansi.Truncate(m.message, m.width, "…"))
+	}
+	return header + "\n" + body + "\n" + footer
+}
+
+// listView renders the visible rows of the issue list
+func (m model) listView(width, height int) string {
+	if len(m.visible) == 0 {
+		return faintStyle.Render("No matching issues")
+	}
+
+	keyWidth, statusWidth := 0, 0
+	for _, i := range m.visible {
+		keyWidth = max(keyWidth, len(i.Key))
+		statusWidth = min(max(statusWidth, ansi.StringWidth(i.Status)), 12)
+	}
+
+	var rows []string
+	for n := m.offset; n < min(m.offset+height, len(m.visible)); n++ {
+		i := m.visible[n]
+		status := ansi.Truncate(i.Status, statusWidth, "…")
+		row := fmt.Sprintf("%-*s %-*s %s", keyWidth, i.Key, statusWidth, status, i.Title)
+		row = ansi.Truncate(row, width, "…")
+		if n == m.cursor {
+			row = selectedStyle.Render(row + strings.Repeat(" ", max(width-ansi.StringWidth(row), 0)))
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// pickerView renders the picker options matching the typed text
+func (m model) pickerView(width, height int) string {
+	lines := []string{titleStyle.Render(m.picker.title), m.picker.input.View(), ""}
+	if len(m.picker.options) == 0 {
+		lines = append(lines, faintStyle.Render("enter to set • esc to cancel"))
+		return strings.Join(lines, "\n")
+	}
+
+	matches := m.picker.matches()
+	if len(matches) == 0 {
+		lines = append(lines, faintStyle.Render("No match"))
+	}
+	for n, option := range matches {
+		if len(lines) >= height {
+			break
+		}
+		option = ansi.Truncate(option, width-2, "…")
+		if n == m.picker.cursor {
+			lines = append(lines, selectedStyle.Render("> "+option))
+		} else {
+			lines = append(lines, "  "+option)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// help returns the key bindings of the current mode
+func (m model) help() string {
+	switch m.mode {
+	case modeFilter:
+		return "type to filter • enter keep • esc clear"
+	case modePicker:
+		return "type to narrow • ↑/↓ choose • enter set • esc cancel"
+	case modeComment:
+		return "ctrl+s save • esc cancel"
+	case modeDetail:
+		return "↑/↓ scroll • esc back • s status • a assign • c comment • P push • q quit"
+	}
+	return "↑/↓ move • / filter • enter details • s status • a assign • c comment • P push • r reload • q quit"
+}
+
+// renderIssue renders the detail pane of an issue: fields, description and comments
+func renderIssue(i *issue.Issue, width int) string {
+	var b strings.Builder
+	b.WriteString(wrap(titleStyle.Render(i.Key+" "+i.Title), width, "") + "\n\n")
+
+	assignee := i.Assignee
+	if assignee == "" {
+		assignee = strings.Join(i.Assignees, ", ")
+	}
+	fields := []struct{ name, value string }{
+		{"Status", i.Status},
+		{"Assignee", assignee},
+		{"Type", i.Type},
+		{"Priority", i.Priority},
+		{"Sprint", i.Sprint},
+		{"Labels", strings.Join(i.Labels, ", ")},
+		{"Parent", i.Parent},
+		{"Updated", i.Updated.Local().Format("2006-01-02 15:04")},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			b.WriteString(ansi.Truncate(fmt.Sprintf("%-9s %s", f.name+":", f.value), width, "…") + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if strings.TrimSpace(i.Description) == "" {
+		b.WriteString(faintStyle.Render("No description") + "\n")
+	} else {
+		b.WriteString(renderMarkdown(i.Description, width) + "\n")
+	}
+
+	if len(i.Comments) > 0 {
+		b.WriteString("\n" + headingStyle.Render(fmt.Sprintf("Comments (%d)", len(i.Comments))) + "\n")
+		for _, c := range i.Comments {
+			b.WriteString("\n" + faintStyle.Render(fmt.Sprintf("%s, %s", c.Author, c.Created.Local().Format("2006-01-02 15:04"))) + "\n")
+			b.WriteString(renderMarkdown(c.Body, width) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// filterIssues returns the issues matching every word of query in their
+// key, title, status, assignee or labels (case-insensitive)
+func filterIssues(issues []*issue.Issue, query string) []*issue.Issue {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return issues
+	}
+
+	var out []*issue.Issue
+	for _, i := range issues {
+		fields := []string{i.Key, i.Title, i.Status, i.Assignee}
+		fields = append(fields, i.Assignees...)
+		fields = append(fields, i.Labels...)
+		text := strings.ToLower(strings.Join(fields, " "))
+		matched := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// statusOptions returns the workflow statuses in workflow order, or the
+// statuses in use when the bridge has no workflow cache
+func statusOptions(workflow []*issue.StatusInfo, issues []*issue.Issue) []string {
+	var options []string
+	for _, s := range workflow {
+		options = append(options, s.Name)
+	}
+	if len(options) > 0 {
+		return options
+	}
+
+	seen := make(map[string]bool)
+	for _, i := range issues {
+		if i.Status != "" && !seen[i.Status] {
+			seen[i.Status] = true
+			options = append(options, i.Status)
+		}
+	}
+	sort.Strings(options)
+	return options
+}
@@ -0,0 +1,134 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// newTestModel returns a sized model showing issues, without a daemon
+func newTestModel(issues ...*issue.Issue) model {
+	m := newModel(&api{projectPath: "/tmp/project"})
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	next, _ = next.Update(issuesMsg{
+		issues:   issues,
+		statuses: []*issue.StatusInfo{{ID: "1", Name: "To Do"}, {ID: "3", Name: "In Progress"}},
+	})
+	return next.(model)
+}
+
+// press sends keys to the model, one message per key name
+func press(m model, keys ...string) model {
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		next, _ := m.Update(msg)
+		m = next.(model)
+	}
+	return m
+}
+
+// TestFilterIssues tests that every word must match a field of the issue
+func TestFilterIssues(t *testing.T) {
+	issues := []*issue.Issue{
+		{Key: "PROJ-1", Title: "Fix login", Status: "To Do", Assignee: "Alice"},
+		{Key: "PROJ-2", Title: "Login page", Status: "Done", Labels: []string{"frontend"}},
+		{Key: "GH-3", Title: "Docs", Status: "open", Assignees: []string{"bob"}},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "PROJ-1,PROJ-2,GH-3"},
+		{"login", "PROJ-1,PROJ-2"},
+		{"LOGIN alice", "PROJ-1"},
+		{"frontend", "PROJ-2"},
+		{"bob", "GH-3"},
+		{"missing", ""},
+	}
+	for _, tt := range tests {
+		var keys []string
+		for _, i := range filterIssues(issues, tt.query) {
+			keys = append(keys, i.Key)
+		}
+		if got := strings.Join(keys, ","); got != tt.want {
+			t.Errorf("Filter %q: expected %q, got %q", tt.query, tt.want, got)
+		}
+	}
+}
+
+// TestStatusOptions tests that the workflow limits the statuses, falling back
+// to the statuses in use
+func TestStatusOptions(t *testing.T) {
+	issues := []*issue.Issue{{Status: "open"}, {Status: "closed"}, {Status: "open"}}
+
+	workflow := []*issue.StatusInfo{{Name: "To Do"}, {Name: "Done"}}
+	if got := strings.Join(statusOptions(workflow, issues), ","); got != "To Do,Done" {
+		t.Errorf("Expected workflow statuses, got %q", got)
+	}
+	if got := strings.Join(statusOptions(nil, issues), ","); got != "closed,open" {
+		t.Errorf("Expected statuses in use, got %q", got)
+	}
+}
+
+// TestModel_FilterKeepsSelection tests filtering from the keyboard and that
+// the selection stays on the same issue
+func TestModel_FilterKeepsSelection(t *testing.T) {
+	m := newTestModel(
+		&issue.Issue{Key: "PROJ-1", Title: "Fix login"},
+		&issue.Issue{Key: "PROJ-2", Title: "Login page"},
+		&issue.Issue{Key: "PROJ-3", Title: "Docs"},
+	)
+
+	m = press(m, "down", "/", "l", "o", "g")
+	if len(m.visible) != 2 {
+		t.Fatalf("Expected 2 matching issues, got %d", len(m.visible))
+	}
+	if got := m.selected().Key; got != "PROJ-2" {
+		t.Errorf("Expected PROJ-2 to stay selected, got %s", got)
+	}
+
+	m = press(m, "esc")
+	if m.mode != modeList || len(m.visible) != 3 {
+		t.Errorf("Expected esc to clear the filter, got mode %d and %d issues", m.mode, len(m.visible))
+	}
+}
+
+// TestModel_StatusPicker tests that the status picker offers the workflow
+// statuses and narrows them as you type
+func TestModel_StatusPicker(t *testing.T) {
+	m := newTestModel(&issue.Issue{Key: "PROJ-1", Title: "Fix login", Status: "To Do"})
+
+	m = press(m, "s")
+	if m.mode != modePicker {
+		t.Fatalf("Expected the picker to open, got mode %d", m.mode)
+	}
+	if got := strings.Join(m.picker.matches(), ","); got != "To Do,In Progress" {
+		t.Errorf("Expected workflow statuses, got %q", got)
+	}
+
+	m = press(m, "p", "r", "o")
+	if got := strings.Join(m.picker.matches(), ","); got != "In Progress" {
+		t.Errorf("Expected In Progress to match, got %q", got)
+	}
+	if !strings.Contains(m.View(), "Status of PROJ-1") {
+		t.Errorf("Expected the picker in the view")
+	}
+
+	m = press(m, "esc")
+	if m.mode != modeList {
+		t.Errorf("Expected esc to close the picker, got mode %d", m.mode)
+	}
+}
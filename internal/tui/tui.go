@@ -0,0 +1,159 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+// Package tui implements the interactive terminal UI for browsing and
+// editing issues. All reads and writes go through the daemon API.
+//
+// It is built only where the clipboard package behind the bubbles text inputs
+// has an implementation; on other Unix systems takl has no tui command.
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gurisko/takl/internal/apiclient"
+	"github.com/gurisko/takl/internal/issue"
+)
+
+// Run shows the UI for the project until the user quits
+func Run(ctx context.Context, client *apiclient.Client, projectPath string) error {
+	api := &api{client: client, projectPath: projectPath, ctx: ctx}
+	program := tea.NewProgram(newModel(api), tea.WithAltScreen(), tea.WithContext(ctx))
+	final, err := program.Run()
+	if err != nil {
+		if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	if m, ok := final.(model); ok && m.fatal != nil {
+		return m.fatal
+	}
+	return nil
+}
+
+// Messages sent by the API commands
+
+type issuesMsg struct {
+	issues   []*issue.Issue
+	statuses []*issue.StatusInfo
+	members  []*issue.Member
+}
+
+type issueMsg struct {
+	issue  *issue.Issue
+	action string // What changed, for the status line
+}
+
+type pushMsg struct {
+	result issue.PushResult
+}
+
+type changesMsg struct {
+	fingerprint string
+	changed     bool
+}
+
+type errMsg struct {
+	err error
+}
+
+// api runs daemon requests as Bubble Tea commands
+type api struct {
+	client      *apiclient.Client
+	projectPath string
+	ctx         context.Context
+}
+
+// load fetches the issues in rank order with the cached workflow and members
+func (a *api) load() tea.Cmd {
+	return func() tea.Msg {
+		query := url.Values{"project_path": {a.projectPath}}.Encode()
+
+		var issues struct {
+			Issues []*issue.Issue `json:"issues"`
+		}
+		if err := a.client.GetJSON(a.ctx, "/api/issues?"+query+"&sort="+issue.SortRank, &issues); err != nil {
+			return errMsg{fmt.Errorf("failed to list issues: %w", err)}
+		}
+		var workflow struct {
+			Statuses []*issue.StatusInfo `json:"statuses"`
+		}
+		if err := a.client.GetJSON(a.ctx, "/api/workflow?"+query, &workflow); err != nil {
+			return errMsg{fmt.Errorf("failed to load workflow: %w", err)}
+		}
+		var members struct {
+			Members []*issue.Member `json:"members"`
+		}
+		if err := a.client.GetJSON(a.ctx, "/api/members?"+query, &members); err != nil {
+			return errMsg{fmt.Errorf("failed to load members: %w", err)}
+		}
+		return issuesMsg{issues: issues.Issues, statuses: workflow.Statuses, members: members.Members}
+	}
+}
+
+// show fetches a single issue with its description and comments
+func (a *api) show(key string) tea.Cmd {
+	return func() tea.Msg {
+		var resp struct {
+			Issue *issue.Issue `json:"issue"`
+		}
+		path := "/api/issues/" + url.PathEscape(key) + "?" + url.Values{"project_path": {a.projectPath}}.Encode()
+		if err := a.client.GetJSON(a.ctx, path, &resp); err != nil {
+			return errMsg{fmt.Errorf("failed to show %s: %w", key, err)}
+		}
+		return issueMsg{issue: resp.Issue}
+	}
+}
+
+// update sets the status or assignee of an issue or adds a comment
+func (a *api) update(key, field, value, action string) tea.Cmd {
+	return func() tea.Msg {
+		var resp struct {
+			Issue *issue.Issue `json:"issue"`
+		}
+		req := map[string]interface{}{"project_path": a.projectPath, field: value}
+		if err := a.client.PostJSON(a.ctx, "/api/issues/"+url.PathEscape(key), req, &resp); err != nil {
+			return errMsg{fmt.Errorf("failed to update %s: %w", key, err)}
+		}
+		return issueMsg{issue: resp.Issue, action: action}
+	}
+}
+
+// push pushes the local changes of the project to the tracker
+func (a *api) push() tea.Cmd {
+	return func() tea.Msg {
+		var result issue.PushResult
+		if err := a.client.PostJSON(a.ctx, "/api/bridge/push", map[string]interface{}{"project_path": a.projectPath}, &result); err != nil {
+			var apiErr *apiclient.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+				// Conflict responses carry the push result in the body
+				_ = json.Unmarshal(apiErr.Body, &result)
+				return errMsg{errors.New(issue.FormatConflictError(result.Conflicts))}
+			}
+			return errMsg{fmt.Errorf("push failed: %w", err)}
+		}
+		return pushMsg{result: result}
+	}
+}
+
+// watch long-polls the daemon until the issue files differ from fingerprint
+func (a *api) watch(fingerprint string) tea.Cmd {
+	return func() tea.Msg {
+		var resp struct {
+			Fingerprint string `json:"fingerprint"`
+			Changed     bool   `json:"changed"`
+		}
+		query := url.Values{"project_path": {a.projectPath}, "since": {fingerprint}}.Encode()
+		if err := a.client.GetJSON(a.ctx, "/api/changes?"+query, &resp); err != nil {
+			// Watching is best effort; r still reloads by hand
+			return nil
+		}
+		return changesMsg{fingerprint: resp.Fingerprint, changed: resp.Changed}
+	}
+}